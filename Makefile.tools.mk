@@ -13,9 +13,12 @@ KUSTOMIZE = $(LOCALBIN)/kustomize
 YQ = $(LOCALBIN)/yq
 HELM_DOCS = $(LOCALBIN)/helm-docs
 PINACT = $(LOCALBIN)/pinact
+PROTOC_GEN_GO = $(LOCALBIN)/protoc-gen-go
+PROTOC_GEN_GO_GRPC = $(LOCALBIN)/protoc-gen-go-grpc
 UV = $(PYTHON_BIN)/uv
 RUFF = $(PYTHON_BIN)/ruff
 PYTEST = $(PYTHON_BIN)/pytest
+GRPC_TOOLS = $(PYTHON_VENV)/.grpcio-tools
 
 ## Tool versions are defined in kserve-deps.env (included in main Makefile)
 
@@ -68,6 +71,14 @@ pinact: $(PINACT)
 $(PINACT): $(LOCALBIN)
 	$(call go-install-tool,$(PINACT),github.com/suzuki-shunsuke/pinact/v3/cmd/pinact,$(PINACT_VERSION))
 
+## Download the protoc plugins generating the Go gRPC bindings locally if necessary.
+.PHONY: protoc-gen-go
+protoc-gen-go: $(PROTOC_GEN_GO) $(PROTOC_GEN_GO_GRPC)
+$(PROTOC_GEN_GO): $(LOCALBIN) $(DEPS_ENV)
+	$(call go-install-tool,$(PROTOC_GEN_GO),google.golang.org/protobuf/cmd/protoc-gen-go,$(PROTOC_GEN_GO_VERSION))
+$(PROTOC_GEN_GO_GRPC): $(LOCALBIN) $(DEPS_ENV)
+	$(call go-install-tool,$(PROTOC_GEN_GO_GRPC),google.golang.org/grpc/cmd/protoc-gen-go-grpc,$(PROTOC_GEN_GO_GRPC_VERSION))
+
 $(PYTHON_VENV): | $(LOCALBIN)
 	python3 -m venv $(PYTHON_VENV)
 	$(PYTHON_BIN)/pip install --upgrade pip
@@ -78,6 +89,11 @@ $(UV): $(PYTHON_VENV) $(DEPS_ENV)
 $(RUFF): $(PYTHON_VENV) $(DEPS_ENV)
 	$(PYTHON_BIN)/pip install ruff==$(RUFF_VERSION)
 
+# protoc is run from grpcio-tools, as for the python SDK.
+$(GRPC_TOOLS): $(PYTHON_VENV) $(DEPS_ENV)
+	$(PYTHON_BIN)/pip install grpcio-tools==$(GRPCIO_TOOLS_VERSION)
+	touch $(GRPC_TOOLS)
+
 $(PYTEST): $(UV)
 	$(UV) pip install --python $(PYTHON_BIN)/python pytest
 
@@ -31,6 +31,7 @@ import (
 	"github.com/go-logr/zapr"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
@@ -49,6 +50,7 @@ import (
 	"github.com/kserve/kserve/pkg/agent/storage"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/batcher"
+	"github.com/kserve/kserve/pkg/constants"
 	kfslogger "github.com/kserve/kserve/pkg/logger"
 )
 
@@ -59,27 +61,57 @@ var (
 	enablePuller = flag.Bool("enable-puller", false, "Enable model puller")
 	configDir    = flag.String("config-dir", "/mnt/configs", "directory for model config files")
 	modelDir     = flag.String("model-dir", "/mnt/models", "directory for model files")
+	// model puller retry flags
+	pullerRetryMaxAttempts    = flag.Int("puller-retry-max-attempts", 5, "Max number of attempts to download and load a model, including the first one")
+	pullerRetryInitialBackoff = flag.Duration("puller-retry-initial-backoff", time.Second, "Wait before the first retry of a model, doubled with every retry")
+	pullerRetryMaxBackoff     = flag.Duration("puller-retry-max-backoff", time.Minute, "Max wait between two attempts to download and load a model")
+	downloadWorkers           = flag.Int("download-workers", storage.DefaultDownloadWorkers, "Max number of requests in flight to download the files, or the parts of the files, of a model")
+	downloadPartSize          = flag.Int64("download-part-size", storage.DefaultDownloadPartSize, "Size in bytes of the parts of a large model file downloaded concurrently")
 	// logger flags
-	logUrl              = flag.String("log-url", "", "The URL to send request/response logs to")
-	workers             = flag.Int("workers", 5, "Number of workers")
-	sourceUri           = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
-	logMode             = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response' or 'all'")
-	logStorePath        = flag.String("log-store-path", "", "The path to the log output")
-	logStoreFormat      = flag.String("log-store-format", "json", "Output format for the log marshaller (json, csv, parquet)")
-	logMarshallerUrl    = flag.String("log-marshaller-url", "http://localhost:9083/marshal", "URL of the log marshaller service")
-	logMarshallerPort   = flag.Int("log-marshaller-port", 9083, "Port for the embedded log marshaller HTTP server")
-	logBatchSize        = flag.Int("log-batch-size", 1, "Number of log records per batch for blob storage")
-	logBatchInterval    = flag.Duration("log-batch-interval", 0, "Max time to wait before flushing a partial batch")
-	inferenceService    = flag.String("inference-service", "", "The InferenceService name to add as header to log events")
-	namespace           = flag.String("namespace", "", "The namespace to add as header to log events")
-	endpoint            = flag.String("endpoint", "", "The endpoint name to add as header to log events")
-	component           = flag.String("component", "", "The component name (predictor, explainer, transformer) to add as header to log events")
-	metadataHeaders     = flag.StringSlice("metadata-headers", nil, "Allow list of headers that will be passed down as metadata")
-	metadataAnnotations = flag.StringSlice("metadata-annotations", nil, "Allow list of metadata annotation to be passed with payload logging")
+	logUrl                        = flag.String("log-url", "", "The URL to send request/response logs to")
+	workers                       = flag.Int("workers", 5, "Number of workers")
+	sourceUri                     = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
+	logMode                       = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response', 'all' or 'failures'")
+	logStorePath                  = flag.String("log-store-path", "", "The path to the log output")
+	logStoreFormat                = flag.String("log-store-format", "json", "Output format for the log marshaller (json, csv, parquet)")
+	logMarshallerUrl              = flag.String("log-marshaller-url", "http://localhost:9083/marshal", "URL of the log marshaller service")
+	logMarshallerPort             = flag.Int("log-marshaller-port", 9083, "Port for the embedded log marshaller HTTP server")
+	logBatchSize                  = flag.Int("log-batch-size", 1, "Number of log records per batch for blob storage")
+	logBatchInterval              = flag.Duration("log-batch-interval", 0, "Max time to wait before flushing a partial batch")
+	logRedactFields               = flag.StringArray("log-redact-field", nil, "GJSON path of a payload field to redact before logging, can be repeated")
+	logRedactHeaders              = flag.StringSlice("log-redact-headers", nil, "Metadata headers to redact before logging")
+	logRedactMode                 = flag.String("log-redact-mode", string(v1beta1.RedactionMask), "Whether to 'mask' or 'hash' the redacted values")
+	logSamplingPercent            = flag.Int("log-sampling-percentage", 100, "Percentage of the calls to log, sampled by request ID")
+	logKafkaAcks                  = flag.String("log-kafka-acks", kfslogger.KafkaAcksAll, "Acknowledgements required from the Kafka brokers for the kafka log url, 'all', 'leader' or 'none'")
+	logMaxFileSize                = flag.Int64("log-max-file-size", 0, "Size in bytes from which the file and pvc log sinks start a new file, every batch is written to its own file when neither rotation limit is set")
+	logMaxFileAge                 = flag.Duration("log-max-file-age", 0, "Age after which the file and pvc log sinks start a new file")
+	logRetryMaxAttempts           = flag.Int("log-retry-max-attempts", 1, "Max number of delivery attempts of a log cloud event, including the first one")
+	logRetryInitialBackoff        = flag.Duration("log-retry-initial-backoff", kfslogger.DefaultRetryInitialBackoff, "Wait before the first retry of a log cloud event, doubled with every retry")
+	logRetryMaxBackoff            = flag.Duration("log-retry-max-backoff", kfslogger.DefaultRetryMaxBackoff, "Max wait between two delivery attempts of a log cloud event")
+	logRetryMaxInFlight           = flag.Int("log-retry-max-in-flight", kfslogger.DefaultMaxRetriesInFlight, "Max number of log cloud events waiting for a retry, the events failing beyond it are sent to the dead letter url")
+	logCircuitBreakerThreshold    = flag.Int("log-circuit-breaker-threshold", 0, "Number of consecutive failed deliveries to a log destination which opens its circuit, disabled when 0")
+	logCircuitBreakerResetTimeout = flag.Duration("log-circuit-breaker-reset-timeout", kfslogger.DefaultCircuitBreakerResetTimeout, "Time after which a trial delivery is sent to a log destination with an open circuit")
+	logDeadLetterUrl              = flag.String("log-dead-letter-url", "", "Logger URL receiving the log cloud events which could not be delivered")
+	logSpoolDir                   = flag.String("log-spool-dir", "", "Directory where the log batches which fail to be delivered are persisted and retried, disabled when empty")
+	logSpoolMaxBytes              = flag.Int64("log-spool-max-bytes", 100<<20, "Max size of the log spool in bytes, the oldest batches are evicted beyond it")
+	logSpoolMinBackoff            = flag.Duration("log-spool-min-backoff", kfslogger.DefaultSpoolMinBackoff, "Initial wait before retrying a spooled log batch")
+	logSpoolMaxBackoff            = flag.Duration("log-spool-max-backoff", kfslogger.DefaultSpoolMaxBackoff, "Max wait between two retries of a spooled log batch")
+	logMaxBodySize                = flag.Int("log-max-body-size", 0, "Max number of bytes of a request or response body captured in a log record, unlimited when 0")
+	inferenceService              = flag.String("inference-service", "", "The InferenceService name to add as header to log events")
+	namespace                     = flag.String("namespace", "", "The namespace to add as header to log events")
+	endpoint                      = flag.String("endpoint", "", "The endpoint name to add as header to log events")
+	component                     = flag.String("component", "", "The component name (predictor, explainer, transformer) to add as header to log events")
+	metadataHeaders               = flag.StringSlice("metadata-headers", nil, "Allow list of headers that will be passed down as metadata")
+	metadataAnnotations           = flag.StringSlice("metadata-annotations", nil, "Allow list of metadata annotation to be passed with payload logging")
 	// batcher flags
 	enableBatcher = flag.Bool("enable-batcher", false, "Enable request batcher")
 	maxBatchSize  = flag.String("max-batchsize", "32", "Max Batch Size")
 	maxLatency    = flag.String("max-latency", "5000", "Max Latency in milliseconds")
+	targetLatency = flag.Int("target-latency", 0, "Target p99 model server latency in milliseconds, enables adaptive batching when set")
+	maxQueueDepth = flag.Int("max-queue-depth", 0, "Max number of requests waiting for a batch response per model, unbounded when not set")
+	modelBatching = flag.StringSlice("model-batch-config", nil, "Per model batching overrides in the format model=maxBatchSize:maxLatency")
+	// metrics flags
+	metricsPort = flag.Int("metrics-port", constants.InferenceServiceDefaultAgentMetricsPort, "Port for the agent Prometheus metrics")
 	// probing flags
 	readinessProbeTimeout = flag.Duration("probe-period", -1, "run readiness probe with given timeout") //nolint: unused
 	// This creates an abstract socket instead of an actual file.
@@ -128,11 +160,17 @@ type loggerArgs struct {
 	annotations      map[string]string
 	certName         string
 	tlsSkipVerify    bool
+	maxBodySize      int
+	redactor         *kfslogger.Redactor
+	samplingPercent  int
 }
 
 type batcherArgs struct {
-	maxBatchSize int
-	maxLatency   int
+	maxBatchSize  int
+	maxLatency    int
+	targetLatency int
+	maxQueueDepth int
+	modelConfigs  map[string]batcher.QueueConfig
 }
 
 func main() {
@@ -152,9 +190,14 @@ func main() {
 		probe = buildProbe(logger, env.ServingReadinessProbe, env.EnableHTTP2AutoDetection, env.EnableMultiContainerProbes).ProbeContainer
 	}
 
+	var modelStatuses *agent.ModelStatusTracker
 	if *enablePuller {
 		logger.Infof("Initializing model agent with config-dir %s, model-dir %s", *configDir, *modelDir)
-		startModelPuller(logger)
+		var puller *agent.Puller
+		modelStatuses, puller = startModelPuller(logger)
+		// the replica is not ready until it attempted to load the models found on startup
+		probeContainer := probe
+		probe = func() bool { return puller.Ready() && probeContainer() }
 	}
 
 	var loggerArgs *loggerArgs
@@ -171,10 +214,8 @@ func main() {
 	}
 	logger.Info("Starting agent http server...")
 	ctx := signals.NewContext()
-	mainServer, drain := buildServer(*port, *componentPort, loggerArgs, batcherArgs, probe, logger)
-	servers := map[string]*http.Server{
-		"main": mainServer,
-	}
+	mainServer, drain := buildServer(*port, *componentPort, loggerArgs, batcherArgs, modelStatuses, probe, logger)
+	servers := buildServers(mainServer, *metricsPort, loggerArgs, batcherArgs)
 	errCh := make(chan error)
 	listenCh := make(chan struct{})
 	for name, server := range servers {
@@ -266,9 +307,43 @@ func startBatcher(logger *zap.SugaredLogger) *batcherArgs {
 		os.Exit(1)
 	}
 
+	if *targetLatency < 0 {
+		logger.Error(errors.New("Invalid target latency"), *targetLatency)
+		os.Exit(1)
+	}
+
+	if *maxQueueDepth < 0 {
+		logger.Error(errors.New("Invalid max queue depth"), *maxQueueDepth)
+		os.Exit(1)
+	}
+
+	modelConfigs := map[string]batcher.QueueConfig{}
+	for _, modelConfig := range *modelBatching {
+		name, config, found := strings.Cut(modelConfig, "=")
+		batchSize, latency, valid := strings.Cut(config, ":")
+		if !found || !valid {
+			logger.Errorf("model batch config does not adhere to desired format model=maxBatchSize:maxLatency got: %s", modelConfig)
+			os.Exit(1)
+		}
+		batchSizeInt, err := strconv.Atoi(batchSize)
+		if err != nil || batchSizeInt <= 0 {
+			logger.Error(errors.New("Invalid max batch size"), modelConfig)
+			os.Exit(1)
+		}
+		latencyInt, err := strconv.Atoi(latency)
+		if err != nil || latencyInt <= 0 {
+			logger.Error(errors.New("Invalid max latency"), modelConfig)
+			os.Exit(1)
+		}
+		modelConfigs[name] = batcher.QueueConfig{MaxBatchSize: batchSizeInt, MaxLatency: latencyInt}
+	}
+
 	return &batcherArgs{
-		maxLatency:   maxLatencyInt,
-		maxBatchSize: maxBatchSizeInt,
+		maxLatency:    maxLatencyInt,
+		maxBatchSize:  maxBatchSizeInt,
+		targetLatency: *targetLatency,
+		maxQueueDepth: *maxQueueDepth,
+		modelConfigs:  modelConfigs,
 	}
 }
 
@@ -277,7 +352,7 @@ func startLogger(workers int, logStorePath *string, marshallerUrl string, marsha
 ) *loggerArgs {
 	loggingMode := v1beta1.LoggerType(*logMode)
 	switch loggingMode {
-	case v1beta1.LogAll, v1beta1.LogRequest, v1beta1.LogResponse:
+	case v1beta1.LogAll, v1beta1.LogRequest, v1beta1.LogResponse, v1beta1.LogFailures:
 	default:
 		log.Errorf("Malformed log-mode %s", *logMode)
 		os.Exit(-1)
@@ -310,6 +385,17 @@ func startLogger(workers int, logStorePath *string, marshallerUrl string, marsha
 		}
 	}
 
+	redactor, err := kfslogger.NewRedactor(*logRedactFields, *logRedactHeaders, v1beta1.LoggerRedactionMode(*logRedactMode))
+	if err != nil {
+		log.Errorf("Malformed log redaction policy: %v", err)
+		os.Exit(-1)
+	}
+
+	if *logSamplingPercent < 0 || *logSamplingPercent > 100 {
+		log.Errorf("Malformed log-sampling-percentage %d", *logSamplingPercent)
+		os.Exit(-1)
+	}
+
 	// Select BatchStrategy based on flags.
 	var batchStrategy kfslogger.BatchStrategy
 	switch {
@@ -321,47 +407,83 @@ func startLogger(workers int, logStorePath *string, marshallerUrl string, marsha
 		batchStrategy = &kfslogger.ImmediateBatch{}
 	}
 
+	if *logMaxFileSize < 0 || *logMaxFileAge < 0 {
+		log.Errorf("Malformed log file rotation %d bytes %s", *logMaxFileSize, *logMaxFileAge)
+		os.Exit(-1)
+	}
+	// the embedded marshaller server is started only when a store needs it
+	var marshaller kfslogger.Marshaller
+	getMarshaller := func() kfslogger.Marshaller {
+		if marshaller == nil {
+			marshaller = startMarshaller(marshallerUrl, marshallerPort, log)
+		}
+		return marshaller
+	}
+
 	var store kfslogger.Store
-	if kfslogger.GetStorageStrategy(*logUrl) != kfslogger.HttpStorage {
+	switch kfslogger.GetStorageStrategy(*logUrl) {
+	case kfslogger.HttpStorage:
+	case kfslogger.KafkaStorage:
+		log.Infow("Logger kafka sink is enabled", "brokers", logUrlParsed.Host, "acks", *logKafkaAcks)
+		store, err = newLogStore(logUrlParsed, *logStorePath, getMarshaller, log)
+	case kfslogger.FileStorage, kfslogger.PVCStorage:
+		log.Infow("Logger file storage is enabled", "url", *logUrl, "path", *logStorePath,
+			"maxFileSize", *logMaxFileSize, "maxFileAge", *logMaxFileAge)
+		store, err = newLogStore(logUrlParsed, *logStorePath, getMarshaller, log)
+	default:
 		if logStorePath != nil && *logStorePath != "" {
-			// Start the embedded marshaller HTTP server only when blob storage is needed.
-			var marshallerHandler http.Handler
-			switch *logStoreFormat {
-			case "csv":
-				marshallerHandler = kfslogger.NewCSVMarshallerHandler()
-			case "parquet":
-				marshallerHandler = kfslogger.NewParquetMarshallerHandler()
-			default:
-				marshallerHandler = kfslogger.NewJSONMarshallerHandler()
-			}
-			marshallerAddr := fmt.Sprintf(":%d", marshallerPort)
-			marshallerServer := &http.Server{
-				Addr:              marshallerAddr,
-				Handler:           marshallerHandler,
-				ReadHeaderTimeout: 30 * time.Second,
-			}
-			go func() {
-				log.Infof("Starting embedded log marshaller server on %s", marshallerAddr)
-				if err := marshallerServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-					log.Errorf("Log marshaller server failed: %v", err)
-				}
-			}()
-
-			// Create HTTPMarshaller client pointing to the configured URL.
-			httpClient := &http.Client{Timeout: 30 * time.Second}
-			marshaller := kfslogger.NewHTTPMarshaller(marshallerUrl, httpClient)
-
 			log.Infow("Logger storage is enabled", "path", *logStorePath, "marshallerUrl", marshallerUrl)
-			store, err = kfslogger.NewStoreForScheme(logUrlParsed.Scheme, *logStorePath, marshaller, log)
+			store, err = newLogStore(logUrlParsed, *logStorePath, getMarshaller, log)
+		}
+	}
+	if err != nil {
+		log.Errorw("Error creating logger store", zap.Error(err))
+		os.Exit(-1)
+	}
+
+	if *logRetryMaxAttempts < 1 || *logRetryInitialBackoff <= 0 || *logRetryMaxBackoff < *logRetryInitialBackoff || *logRetryMaxInFlight < 1 {
+		log.Errorf("Malformed log retry policy %d attempts %s-%s, %d in flight", *logRetryMaxAttempts, *logRetryInitialBackoff, *logRetryMaxBackoff, *logRetryMaxInFlight)
+		os.Exit(-1)
+	}
+	delivery := &kfslogger.Delivery{
+		Retry: kfslogger.RetryPolicy{
+			MaxAttempts:    *logRetryMaxAttempts,
+			InitialBackoff: *logRetryInitialBackoff,
+			MaxBackoff:     *logRetryMaxBackoff,
+		},
+		MaxRetriesInFlight: *logRetryMaxInFlight,
+	}
+	if *logCircuitBreakerThreshold > 0 {
+		delivery.CircuitBreaker = kfslogger.NewCircuitBreaker(*logCircuitBreakerThreshold, *logCircuitBreakerResetTimeout)
+	}
+	if *logDeadLetterUrl != "" {
+		delivery.DeadLetterUrl, err = url.Parse(*logDeadLetterUrl)
+		if err != nil {
+			log.Errorf("Malformed log-dead-letter-url %s", *logDeadLetterUrl)
+			os.Exit(-1)
+		}
+		if kfslogger.GetStorageStrategy(*logDeadLetterUrl) != kfslogger.HttpStorage {
+			delivery.DeadLetterStore, err = newLogStore(delivery.DeadLetterUrl, *logStorePath, getMarshaller, log)
 			if err != nil {
-				log.Errorw("Error creating logger store", zap.Error(err))
+				log.Errorw("Error creating logger dead letter store", zap.Error(err))
 				os.Exit(-1)
 			}
 		}
+		log.Infow("Logger dead letter url is enabled", "url", *logDeadLetterUrl)
+	}
+
+	var spool *kfslogger.Spool
+	if *logSpoolDir != "" {
+		spool, err = kfslogger.NewSpool(*logSpoolDir, *logSpoolMaxBytes, *logSpoolMinBackoff, *logSpoolMaxBackoff, log)
+		if err != nil {
+			log.Errorw("Error creating logger spool", zap.Error(err))
+			os.Exit(-1)
+		}
+		log.Infow("Logger spool is enabled", "dir", *logSpoolDir, "maxBytes", *logSpoolMaxBytes)
 	}
 
 	log.Info("Starting the log dispatcher")
-	kfslogger.StartDispatcher(workers, store, batchStrategy, log)
+	kfslogger.StartDispatcher(workers, store, batchStrategy, spool, delivery, log)
 	return &loggerArgs{
 		loggerType:       loggingMode,
 		logUrl:           logUrlParsed,
@@ -374,19 +496,86 @@ func startLogger(workers int, logStorePath *string, marshallerUrl string, marsha
 		annotations:      annotationKVPair,
 		certName:         *CaCertFile,
 		tlsSkipVerify:    *TlsSkipVerify,
+		maxBodySize:      *logMaxBodySize,
+		redactor:         redactor,
+		samplingPercent:  *logSamplingPercent,
 	}
 }
 
-func startModelPuller(logger *zap.SugaredLogger) {
+// newLogStore creates the store of a logger URL which is not an HTTP URL.
+func newLogStore(logUrl *url.URL, storePath string, marshaller func() kfslogger.Marshaller, log *zap.SugaredLogger) (kfslogger.Store, error) {
+	switch kfslogger.GetStorageStrategy(logUrl.String()) {
+	case kfslogger.KafkaStorage:
+		return kfslogger.NewKafkaStoreForURL(logUrl, *logKafkaAcks, log)
+	case kfslogger.FileStorage, kfslogger.PVCStorage:
+		rotation := kfslogger.FileRotation{MaxFileSize: *logMaxFileSize, MaxFileAge: *logMaxFileAge}
+		return kfslogger.NewFileStore(storePath, marshaller(), rotation, log), nil
+	default:
+		return kfslogger.NewStoreForScheme(logUrl.Scheme, storePath, marshaller(), log)
+	}
+}
+
+// startMarshaller starts the embedded marshaller HTTP server in the log store format, and
+// returns a marshaller client pointing to the configured URL.
+func startMarshaller(marshallerUrl string, marshallerPort int, log *zap.SugaredLogger) kfslogger.Marshaller {
+	var marshallerHandler http.Handler
+	switch *logStoreFormat {
+	case "csv":
+		marshallerHandler = kfslogger.NewCSVMarshallerHandler()
+	case "parquet":
+		marshallerHandler = kfslogger.NewParquetMarshallerHandler()
+	default:
+		marshallerHandler = kfslogger.NewJSONMarshallerHandler()
+	}
+	marshallerAddr := fmt.Sprintf(":%d", marshallerPort)
+	marshallerServer := &http.Server{
+		Addr:              marshallerAddr,
+		Handler:           marshallerHandler,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		log.Infof("Starting embedded log marshaller server on %s", marshallerAddr)
+		if err := marshallerServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Log marshaller server failed: %v", err)
+		}
+	}()
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	return kfslogger.NewHTTPMarshaller(marshallerUrl, httpClient)
+}
+
+// startModelPuller loads the models of the model config, and returns the tracker of their state along
+// with the puller.
+func startModelPuller(logger *zap.SugaredLogger) (*agent.ModelStatusTracker, *agent.Puller) {
+	if *pullerRetryMaxAttempts < 1 || *pullerRetryInitialBackoff < 0 || *pullerRetryMaxBackoff < *pullerRetryInitialBackoff {
+		logger.Errorf("Malformed puller retry policy %d attempts %s-%s", *pullerRetryMaxAttempts, *pullerRetryInitialBackoff, *pullerRetryMaxBackoff)
+		os.Exit(-1)
+	}
+	if *downloadWorkers < 1 || *downloadPartSize < 1 {
+		logger.Errorf("Malformed download options %d workers %d part size", *downloadWorkers, *downloadPartSize)
+		os.Exit(-1)
+	}
 	downloader := agent.Downloader{
 		ModelDir:  *modelDir,
 		Providers: map[storage.Protocol]storage.Provider{},
 		Logger:    logger,
+		Options: storage.DownloadOptions{
+			Workers:  *downloadWorkers,
+			PartSize: *downloadPartSize,
+		},
 	}
+	modelStatuses := agent.NewModelStatusTracker()
 	watcher := agent.NewWatcher(*configDir, *modelDir, logger)
 	logger.Info("Starting puller")
-	agent.StartPullerAndProcessModels(&downloader, watcher.ModelEvents, logger)
+	modelServerURL := "http://" + net.JoinHostPort("localhost", strconv.Itoa(*componentPort))
+	retry := agent.RetryPolicy{
+		MaxAttempts:    *pullerRetryMaxAttempts,
+		InitialBackoff: *pullerRetryInitialBackoff,
+		MaxBackoff:     *pullerRetryMaxBackoff,
+	}
+	puller := agent.StartPullerAndProcessModels(&downloader, modelServerURL, modelStatuses, retry, watcher.ModelEvents, logger)
 	go watcher.Start()
+	return modelStatuses, puller
 }
 
 func buildProbe(logger *zap.SugaredLogger, probeJSON string, autodetectHTTP2 bool, multiContainerProbes bool) *readiness.Probe {
@@ -408,7 +597,7 @@ func buildProbe(logger *zap.SugaredLogger, probeJSON string, autodetectHTTP2 boo
 }
 
 func buildServer(port string, userPort int, loggerArgs *loggerArgs, batcherArgs *batcherArgs,
-	probeContainer func() bool, logging *zap.SugaredLogger,
+	modelStatuses *agent.ModelStatusTracker, probeContainer func() bool, logging *zap.SugaredLogger,
 ) (server *http.Server, drain func()) {
 	logging.Infof("Building server user port %d port %s", userPort, port)
 	target := &url.URL{
@@ -429,12 +618,25 @@ func buildServer(port string, userPort int, loggerArgs *loggerArgs, batcherArgs
 	var composedHandler http.Handler = httpProxy
 
 	if batcherArgs != nil {
-		composedHandler = batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, composedHandler, logging)
+		batchHandler := batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, composedHandler, logging)
+		batchHandler.TargetLatency = batcherArgs.targetLatency
+		batchHandler.ModelConfigs = batcherArgs.modelConfigs
+		batchHandler.MaxQueueDepth = batcherArgs.maxQueueDepth
+		composedHandler = batchHandler
 	}
 	if loggerArgs != nil {
 		composedHandler = kfslogger.New(loggerArgs.logUrl, loggerArgs.sourceUrl, loggerArgs.loggerType,
 			loggerArgs.inferenceService, loggerArgs.namespace, loggerArgs.endpoint, loggerArgs.component, composedHandler,
-			loggerArgs.metadataHeaders, loggerArgs.certName, loggerArgs.annotations, loggerArgs.tlsSkipVerify)
+			loggerArgs.metadataHeaders, loggerArgs.certName, loggerArgs.annotations, loggerArgs.tlsSkipVerify,
+			loggerArgs.maxBodySize, loggerArgs.redactor, loggerArgs.samplingPercent)
+	}
+
+	if modelStatuses != nil {
+		// the state of the models is served by the agent rather than proxied to the model server
+		mux := http.NewServeMux()
+		mux.Handle(constants.AgentModelStatusPath, modelStatuses)
+		mux.Handle("/", composedHandler)
+		composedHandler = mux
 	}
 
 	composedHandler = queue.ForwardedShimHandler(composedHandler)
@@ -449,3 +651,27 @@ func buildServer(port string, userPort int, loggerArgs *loggerArgs, batcherArgs
 	composedHandler = drainer
 	return pkgnet.NewServer(":"+port, composedHandler), drainer.Drain
 }
+
+// buildServers returns the servers of the agent by name. The metrics server is started whenever the logger or the
+// batcher is enabled, since both of them record metrics.
+func buildServers(mainServer *http.Server, metricsPort int, loggerArgs *loggerArgs, batcherArgs *batcherArgs) map[string]*http.Server {
+	servers := map[string]*http.Server{
+		"main": mainServer,
+	}
+	if loggerArgs != nil || batcherArgs != nil {
+		servers["metrics"] = buildMetricsServer(metricsPort)
+	}
+	return servers
+}
+
+// buildMetricsServer exposes the agent Prometheus metrics on a separate port, so that
+// the metrics of the model server proxied on the main port are not shadowed.
+func buildMetricsServer(port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(constants.DefaultPrometheusPath, promhttp.Handler())
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(port),
+		Handler:           mux,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+}
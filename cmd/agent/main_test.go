@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestBuildServers(t *testing.T) {
+	scenarios := map[string]struct {
+		loggerArgs      *loggerArgs
+		batcherArgs     *batcherArgs
+		expectedMetrics bool
+	}{
+		"proxy only":         {},
+		"logger only":        {loggerArgs: &loggerArgs{}, expectedMetrics: true},
+		"batcher only":       {batcherArgs: &batcherArgs{}, expectedMetrics: true},
+		"logger and batcher": {loggerArgs: &loggerArgs{}, batcherArgs: &batcherArgs{}, expectedMetrics: true},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			mainServer := &http.Server{}
+			servers := buildServers(mainServer, 9082, scenario.loggerArgs, scenario.batcherArgs)
+			assert.Same(t, mainServer, servers["main"])
+			metricsServer, ok := servers["metrics"]
+			require.Equal(t, scenario.expectedMetrics, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, ":9082", metricsServer.Addr)
+			recorder := httptest.NewRecorder()
+			metricsServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, constants.DefaultPrometheusPath, nil))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
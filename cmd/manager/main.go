@@ -225,6 +225,7 @@ func main() {
 	trainedModelEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
 	if err = (&trainedmodelcontroller.TrainedModelReconciler{
 		Client:                mgr.GetClient(),
+		APIReader:             mgr.GetAPIReader(),
 		Log:                   ctrl.Log.WithName("v1beta1Controllers").WithName("TrainedModel"),
 		Scheme:                mgr.GetScheme(),
 		Recorder:              eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "v1beta1Controllers"}),
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const defaultCacheMaxEntries = 1000
+
+// cachedResponse is a successful step response kept in a cache.
+type cachedResponse struct {
+	StatusCode int
+	Body       []byte
+	// HeaderLength is the length of the JSON header of a binary tensor response, zero otherwise
+	HeaderLength int
+}
+
+// responseCache stores the responses of a step by the key of their request. The router keeps
+// them in process by default, and another backend, like a Redis-compatible one, only needs to
+// implement this interface and be returned by newResponseCache.
+type responseCache interface {
+	// Get returns the response cached for the key, if it has not expired.
+	Get(key string) (*cachedResponse, bool)
+	// Set caches the response for the key.
+	Set(key string, response *cachedResponse)
+}
+
+// newResponseCache creates the cache of a step from its cache policy, which is validated by the webhook.
+var newResponseCache = func(policy *v1alpha1.InferenceStepCache) responseCache {
+	ttl, _ := time.ParseDuration(policy.TTL)
+	maxEntries := defaultCacheMaxEntries
+	if policy.MaxEntries != nil {
+		maxEntries = int(*policy.MaxEntries)
+	}
+	return newLRUCache(maxEntries, ttl)
+}
+
+// stepCaches holds the caches of the steps, by node and step index
+var stepCaches sync.Map
+
+// stepIndex returns the index of the step in its node, which identifies the step even when it has no
+// name and targets the same service as another step of the node.
+func stepIndex(graph v1alpha1.InferenceGraphSpec, nodeName string, step *v1alpha1.InferenceStep) int {
+	steps := graph.Nodes[nodeName].Steps
+	for i := range steps {
+		if &steps[i] == step {
+			return i
+		}
+	}
+	return -1
+}
+
+// executeCachedStep returns the cached response of the step for the request when there is one,
+// and otherwise executes the step and caches its response when it succeeds. The responses of the
+// cached steps are not streamed, since they are read entirely to be cached.
+func executeCachedStep(nodeName string, step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, stream *responseStream) (payload, int, error) {
+	if step.Cache == nil {
+		return executeStep(step, graph, input, headers, stream)
+	}
+	name := nodeName + "/" + strconv.Itoa(stepIndex(graph, nodeName, step))
+	cache, ok := stepCaches.Load(name)
+	if !ok {
+		cache, _ = stepCaches.LoadOrStore(name, newResponseCache(step.Cache))
+	}
+	key := cacheKey(input.body, headers, step.Cache.Headers)
+	if response, ok := cache.(responseCache).Get(key); ok {
+		cacheHits.WithLabelValues(nodeName, stepLabel(step)).Inc()
+		log.Info("Returning the cached response of the step", "nodeName", nodeName, "stepName", step.StepName)
+		return payload{body: response.Body, headerLength: response.HeaderLength}, response.StatusCode, nil
+	}
+	cacheMisses.WithLabelValues(nodeName, stepLabel(step)).Inc()
+	response, statusCode, err := executeStep(step, graph, input, headers, nil)
+	if err == nil && isSuccessFul(statusCode) {
+		cache.(responseCache).Set(key, &cachedResponse{StatusCode: statusCode, Body: response.body, HeaderLength: response.headerLength})
+	}
+	return response, statusCode, err
+}
+
+// cacheKey hashes the request with the values of the cached headers.
+func cacheKey(input []byte, headers http.Header, cachedHeaders []string) string {
+	hash := sha256.New()
+	write := func(data []byte) {
+		// the length prefix keeps the boundaries between the fields
+		_ = binary.Write(hash, binary.BigEndian, uint64(len(data)))
+		hash.Write(data)
+	}
+	write(input)
+	for _, name := range cachedHeaders {
+		write([]byte(strings.ToLower(name)))
+		write([]byte(strings.Join(headers.Values(name), ",")))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// lruCache is an in-process responseCache which evicts the least recently used responses once
+// it is full, and the responses older than its TTL.
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	// entries index the elements of the recency list, whose front is the most recently used
+	entries map[string]*list.Element
+	recency *list.List
+	now     func() time.Time
+}
+
+type lruEntry struct {
+	key      string
+	response *cachedResponse
+	expires  time.Time
+}
+
+func newLRUCache(maxEntries int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    map[string]*list.Element{},
+		recency:    list.New(),
+		now:        time.Now,
+	}
+}
+
+func (c *lruCache) Get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !c.now().Before(entry.expires) {
+		c.recency.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.recency.MoveToFront(element)
+	return entry.response, true
+}
+
+func (c *lruCache) Set(key string, response *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := c.now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.response, entry.expires = response, expires
+		c.recency.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.recency.PushFront(&lruEntry{key: key, response: response, expires: expires})
+	for c.recency.Len() > c.maxEntries {
+		oldest := c.recency.Back()
+		c.recency.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Now()
+	cache := newLRUCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", &cachedResponse{StatusCode: http.StatusOK, Body: []byte("a")})
+	cache.Set("b", &cachedResponse{StatusCode: http.StatusOK, Body: []byte("b")})
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+	// b is the least recently used response
+	cache.Set("c", &cachedResponse{StatusCode: http.StatusOK, Body: []byte("c")})
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	response, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("a"), response.Body)
+
+	now = now.Add(30 * time.Second)
+	cache.Set("a", &cachedResponse{StatusCode: http.StatusOK, Body: []byte("a2")})
+	now = now.Add(45 * time.Second)
+	// c has expired, while a was refreshed
+	_, ok = cache.Get("c")
+	assert.False(t, ok)
+	response, ok = cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("a2"), response.Body)
+	assert.Equal(t, 1, cache.recency.Len())
+}
+
+func TestCachedStep(t *testing.T) {
+	var calls, failures atomic.Int32
+	embedder := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		if failures.Load() > 0 {
+			failures.Add(-1)
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions": [[0.1, 0.2]]}`))
+	}))
+	defer embedder.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"cached": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "embed",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: embedder.URL},
+						Cache:           &v1alpha1.InferenceStepCache{TTL: "1m", Headers: []string{"X-Tenant"}},
+					},
+				},
+			},
+		},
+	}
+	scenarios := []struct {
+		name          string
+		input         string
+		tenant        string
+		failures      int32
+		expectedCalls int32
+		expectedCode  int
+	}{
+		{name: "first request", input: `{"instances": ["hello"]}`, tenant: "a", expectedCalls: 1, expectedCode: http.StatusOK},
+		{name: "cached request", input: `{"instances": ["hello"]}`, tenant: "a", expectedCalls: 1, expectedCode: http.StatusOK},
+		{name: "other tenant", input: `{"instances": ["hello"]}`, tenant: "b", expectedCalls: 2, expectedCode: http.StatusOK},
+		{name: "other input", input: `{"instances": ["world"]}`, tenant: "a", expectedCalls: 3, expectedCode: http.StatusOK},
+		{name: "failed request", input: `{"instances": ["again"]}`, tenant: "a", failures: 1, expectedCalls: 4, expectedCode: http.StatusServiceUnavailable},
+		{name: "failure is not cached", input: `{"instances": ["again"]}`, tenant: "a", expectedCalls: 5, expectedCode: http.StatusOK},
+	}
+	for _, scenario := range scenarios {
+		failures.Store(scenario.failures)
+		_, statusCode, err := routeStep("cached", graphSpec, payload{body: []byte(scenario.input)}, http.Header{"X-Tenant": {scenario.tenant}})
+		require.NoError(t, err, scenario.name)
+		assert.Equal(t, scenario.expectedCode, statusCode, scenario.name)
+		assert.Equal(t, scenario.expectedCalls, calls.Load(), scenario.name)
+	}
+	assert.InDelta(t, 1, testutil.ToFloat64(cacheHits.WithLabelValues("cached", "embed")), 0)
+	assert.InDelta(t, 5, testutil.ToFloat64(cacheMisses.WithLabelValues("cached", "embed")), 0)
+}
+
+func TestCachedUnnamedStepsOfTheSameService(t *testing.T) {
+	var calls atomic.Int32
+	embedder := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		_, _ = rw.Write([]byte(`{"predictions": [[0.1, 0.2]]}`))
+	}))
+	defer embedder.Close()
+
+	// both steps send the request to the same service, with caches of different sizes
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"unnamed": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: embedder.URL},
+						Cache:           &v1alpha1.InferenceStepCache{TTL: "1m", MaxEntries: ptr.To(int32(10))},
+					},
+					{
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: embedder.URL},
+						Data:            "$request",
+						Cache:           &v1alpha1.InferenceStepCache{TTL: "1m", MaxEntries: ptr.To(int32(1))},
+					},
+				},
+			},
+		},
+	}
+	for range 2 {
+		_, statusCode, err := routeStep("unnamed", graphSpec, payload{body: []byte(`{"instances": ["hello"]}`)}, http.Header{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	}
+	// the second step is not answered from the cache of the first one
+	assert.Equal(t, int32(2), calls.Load())
+	for _, name := range []string{"unnamed/0", "unnamed/1"} {
+		_, ok := stepCaches.Load(name)
+		assert.True(t, ok, "expected a cache for step %s", name)
+	}
+}
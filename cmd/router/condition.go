@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"sync"
+
+	"github.com/kserve/kserve/pkg/inferencegraph/condition"
+)
+
+// compiledConditions caches the compiled step conditions by their scope and text
+var compiledConditions sync.Map
+
+type conditionKey struct {
+	scope condition.Scope
+	text  string
+}
+
+func compileCondition(text string, scope condition.Scope) (*condition.Condition, error) {
+	key := conditionKey{scope, text}
+	if cached, ok := compiledConditions.Load(key); ok {
+		return cached.(*condition.Condition), nil
+	}
+	compiled, err := condition.Compile(text, scope)
+	if err != nil {
+		return nil, err
+	}
+	compiledConditions.Store(key, compiled)
+	return compiled, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestSwitchWithExpressionConditions(t *testing.T) {
+	large := modelServer(t, `{"predictions": ["large"]}`, http.StatusOK, 0)
+	legacy := modelServer(t, `{"predictions": ["legacy"]}`, http.StatusOK, 0)
+	small := modelServer(t, `{"predictions": ["small"]}`, http.StatusOK, 0)
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Switch,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "default",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: small},
+					},
+					{
+						StepName:        "premium",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: large},
+						Condition:       `cel:request.headers["x-tier"] == "premium" && request.body.length > 100`,
+					},
+					{
+						StepName:        "legacy",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: legacy},
+						Condition:       "instances",
+					},
+				},
+			},
+		},
+	}
+	scenarios := map[string]struct {
+		input    string
+		headers  http.Header
+		expected string
+	}{
+		"header and threshold": {
+			input:    `{"length": 120}`,
+			headers:  http.Header{"X-Tier": {"premium"}},
+			expected: `{"predictions": ["large"]}`,
+		},
+		"below the threshold": {
+			input:    `{"length": 80}`,
+			headers:  http.Header{"X-Tier": {"premium"}},
+			expected: `{"predictions": ["small"]}`,
+		},
+		"gjson path": {
+			input:    `{"instances": [1]}`,
+			headers:  http.Header{"X-Tier": {"premium"}},
+			expected: `{"predictions": ["legacy"]}`,
+		},
+		"not evaluable": {
+			input:    `{"prompt": "Hi"}`,
+			headers:  http.Header{"X-Tier": {"premium"}},
+			expected: `{"predictions": ["small"]}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(scenario.input)}, scenario.headers)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+			assert.JSONEq(t, scenario.expected, string(res.body))
+		})
+	}
+
+	// without a default step the request is not routed when no condition matches
+	graphSpec.Nodes["root"] = v1alpha1.InferenceRouter{RouterType: v1alpha1.Switch, Steps: graphSpec.Nodes["root"].Steps[1:]}
+	_, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"length": 80}`)}, http.Header{})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+func TestSequenceWithExpressionCondition(t *testing.T) {
+	classifier := modelServer(t, `{"predictions": [0.25]}`, http.StatusOK, 0)
+	explainer := modelServer(t, `{"explanations": ["..."]}`, http.StatusOK, 0)
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "classifier", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: classifier}},
+					{
+						StepName:        "explainer",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: explainer},
+						Condition:       `cel:response.predictions[0] > request.body.threshold`,
+					},
+				},
+			},
+		},
+	}
+	res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"threshold": 0.5}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"predictions": [0.25]}`, string(res.body))
+
+	res, statusCode, err = routeStep("root", graphSpec, payload{body: []byte(`{"threshold": 0.1}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"explanations": ["..."]}`, string(res.body))
+}
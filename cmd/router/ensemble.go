@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/inferencegraph/template"
+)
+
+type ensembleStepResult struct {
+	index int
+	stepResult
+}
+
+func (r ensembleStepResult) successful() bool {
+	return r.err == nil && isSuccessFul(r.statusCode)
+}
+
+// routeEnsemble sends the request to all the steps of an Ensemble node in parallel, and
+// aggregates their responses.
+//
+// The failure of a hard dependency step decides the response of the node. The failures of the
+// soft dependency steps are left out of the aggregation when the node has a quorum, as long as
+// enough steps succeed, and fail the node otherwise, except for FirstSuccessful nodes which only
+// fail when no step succeeds.
+func routeEnsemble(nodeName string, node v1alpha1.InferenceRouter, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header) (payload, int, error) {
+	// buffered, so that the steps which complete after the node has returned do not leak
+	resultChan := make(chan ensembleStepResult, len(node.Steps))
+	for i := range node.Steps {
+		step := &node.Steps[i]
+		stepType := "serviceUrl"
+		if step.NodeName != "" {
+			stepType = "node"
+		}
+		log.Info("Starting execution of step", "type", stepType, "stepName", step.StepName)
+		go func() {
+			ctx := template.Context{Request: input.body, Response: input.body}
+			response, statusCode, err := executeNodeStep(nodeName, step, graph, input, headers, ctx, nil)
+			resultChan <- ensembleStepResult{i, stepResult{response, statusCode, err}}
+		}()
+	}
+
+	aggregation := node.Aggregation
+	if aggregation == "" {
+		aggregation = v1alpha1.MergeAggregation
+	}
+	tolerateFailures := node.Quorum != nil || aggregation == v1alpha1.FirstSuccessfulAggregation
+	results := make([]*ensembleStepResult, len(node.Steps))
+	succeeded := 0
+	for range node.Steps {
+		result := <-resultChan
+		step := &node.Steps[result.index]
+		if result.successful() {
+			if aggregation == v1alpha1.FirstSuccessfulAggregation {
+				return result.response, result.statusCode, nil
+			}
+			succeeded++
+			results[result.index] = &result
+			continue
+		}
+		if step.Dependency == v1alpha1.Hard {
+			if result.err != nil {
+				return payload{}, 500, result.err
+			}
+			// First failed hard dependency will decide the response and response code for ensemble node
+			log.Info("This step is a hard dependency and it is unsuccessful", "stepName", step.StepName, "statusCode", result.statusCode)
+			return result.response, result.statusCode, nil
+		}
+		if !tolerateFailures {
+			if result.err != nil {
+				return payload{}, 500, result.err
+			}
+			// the unsuccessful responses of the soft dependencies are merged with the others
+			results[result.index] = &result
+			continue
+		}
+		log.Info("Leaving out the unsuccessful soft dependency step", "stepName", step.StepName, "statusCode", result.statusCode, "error", result.err)
+	}
+
+	// the responses are merged as they are without a quorum, and the other aggregations need a successful response
+	quorum := 0
+	if node.Quorum != nil {
+		quorum = int(*node.Quorum)
+	} else if aggregation != v1alpha1.MergeAggregation {
+		quorum = 1
+	}
+	if succeeded < quorum {
+		err := fmt.Errorf("only %d of the %d steps of ensemble node %s succeeded, %d required", succeeded, len(node.Steps), nodeName, quorum)
+		log.Error(err, "ensemble quorum not met", "nodeName", nodeName)
+		return payload{}, 500, err
+	}
+
+	var response []byte
+	var err error
+	if aggregation == v1alpha1.MergeAggregation {
+		response, err = mergeEnsembleResponses(node, results)
+	} else {
+		response, err = aggregateEnsemblePredictions(aggregation, node, results)
+	}
+	if err != nil {
+		log.Error(err, "failed to aggregate the ensemble responses", "nodeName", nodeName, "aggregation", aggregation)
+		return payload{}, 500, err
+	}
+	return payload{body: response}, 200, nil
+}
+
+// mergeEnsembleResponses returns the step responses keyed by step name, or by index for the unnamed steps.
+func mergeEnsembleResponses(node v1alpha1.InferenceRouter, results []*ensembleStepResult) ([]byte, error) {
+	response := map[string]interface{}{}
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		key := node.Steps[i].StepName
+		if key == "" {
+			key = strconv.Itoa(i) // Use index if no step name
+		}
+		var res map[string]interface{}
+		if err := json.Unmarshal(result.response.body, &res); err != nil {
+			return nil, err
+		}
+		response[key] = res
+	}
+	return json.Marshal(response)
+}
+
+// aggregateEnsemblePredictions combines the predictions of every instance across the successful step responses.
+func aggregateEnsemblePredictions(aggregation v1alpha1.EnsembleAggregation, node v1alpha1.InferenceRouter, results []*ensembleStepResult) ([]byte, error) {
+	var memberPredictions [][]interface{}
+	var weights []float64
+	for i, result := range results {
+		if result == nil || !result.successful() {
+			continue
+		}
+		decoded := sequenceReqRes{}
+		if err := json.Unmarshal(result.response.body, &decoded); err != nil {
+			return nil, errors.Wrapf(err, "invalid response of step %d", i)
+		}
+		if len(memberPredictions) > 0 && len(decoded.Predictions) != len(memberPredictions[0]) {
+			return nil, fmt.Errorf("step %d returned %d predictions instead of %d", i, len(decoded.Predictions), len(memberPredictions[0]))
+		}
+		memberPredictions = append(memberPredictions, decoded.Predictions)
+		weight := 1.0
+		if aggregation == v1alpha1.WeightedMeanAggregation && node.Steps[i].Weight != nil {
+			weight = float64(*node.Steps[i].Weight)
+		}
+		weights = append(weights, weight)
+	}
+
+	predictions := make([]interface{}, len(memberPredictions[0]))
+	for instance := range predictions {
+		values := make([]interface{}, len(memberPredictions))
+		for member := range memberPredictions {
+			values[member] = memberPredictions[member][instance]
+		}
+		var err error
+		switch aggregation {
+		case v1alpha1.MajorityVoteAggregation:
+			predictions[instance], err = majorityVote(values)
+		case v1alpha1.MeanAggregation, v1alpha1.WeightedMeanAggregation:
+			predictions[instance], err = weightedMean(values, weights)
+		case v1alpha1.MaxConfidenceAggregation:
+			predictions[instance], err = maxConfidence(values)
+		default:
+			err = fmt.Errorf("unsupported ensemble aggregation %s", aggregation)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to aggregate the predictions of instance %d", instance)
+		}
+	}
+	return json.Marshal(sequenceReqRes{Predictions: predictions})
+}
+
+// majorityVote returns the most frequent value, the earliest one when several are as frequent.
+func majorityVote(values []interface{}) (interface{}, error) {
+	keys := make([]string, len(values))
+	counts := map[string]int{}
+	for i, value := range values {
+		key, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = string(key)
+		counts[keys[i]]++
+	}
+	var winner interface{}
+	best := 0
+	for i, value := range values {
+		if counts[keys[i]] > best {
+			winner, best = value, counts[keys[i]]
+		}
+	}
+	return winner, nil
+}
+
+// weightedMean returns the element wise weighted mean of numbers or equally shaped lists of numbers.
+func weightedMean(values []interface{}, weights []float64) (interface{}, error) {
+	var sum interface{}
+	total := 0.0
+	for i, value := range values {
+		scaled, err := scalePrediction(value, weights[i])
+		if err != nil {
+			return nil, err
+		}
+		if sum == nil {
+			sum = scaled
+		} else if sum, err = addPredictions(sum, scaled); err != nil {
+			return nil, err
+		}
+		total += weights[i]
+	}
+	if total == 0 {
+		return nil, errors.New("the total weight of the steps is 0")
+	}
+	return scalePrediction(sum, 1/total)
+}
+
+func scalePrediction(value interface{}, factor float64) (interface{}, error) {
+	switch v := value.(type) {
+	case float64:
+		return v * factor, nil
+	case []interface{}:
+		scaled := make([]interface{}, len(v))
+		for i := range v {
+			var err error
+			if scaled[i], err = scalePrediction(v[i], factor); err != nil {
+				return nil, err
+			}
+		}
+		return scaled, nil
+	default:
+		return nil, fmt.Errorf("prediction %v is not numeric", value)
+	}
+}
+
+func addPredictions(a, b interface{}) (interface{}, error) {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			return x + y, nil
+		}
+	case []interface{}:
+		if y, ok := b.([]interface{}); ok && len(x) == len(y) {
+			sum := make([]interface{}, len(x))
+			for i := range x {
+				var err error
+				if sum[i], err = addPredictions(x[i], y[i]); err != nil {
+					return nil, err
+				}
+			}
+			return sum, nil
+		}
+	}
+	return nil, errors.New("the predictions of the steps have different shapes")
+}
+
+// maxConfidence returns the value with the highest confidence, which is the value itself for a
+// score and the highest probability for a list of class probabilities.
+func maxConfidence(values []interface{}) (interface{}, error) {
+	var winner interface{}
+	best := 0.0
+	for i, value := range values {
+		confidence, err := predictionConfidence(value)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 || confidence > best {
+			winner, best = value, confidence
+		}
+	}
+	return winner, nil
+}
+
+func predictionConfidence(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case []interface{}:
+		confidence := 0.0
+		for i, probability := range v {
+			p, ok := probability.(float64)
+			if !ok {
+				return 0, fmt.Errorf("prediction %v is not a list of probabilities", value)
+			}
+			if i == 0 || p > confidence {
+				confidence = p
+			}
+		}
+		return confidence, nil
+	default:
+		return 0, fmt.Errorf("prediction %v has no confidence", value)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// modelServer returns a model answering every request with the response and status code after the delay.
+func modelServer(t *testing.T, response string, statusCode int, delay time.Duration) string {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(delay)
+		rw.WriteHeader(statusCode)
+		_, _ = rw.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func ensembleGraph(aggregation v1alpha1.EnsembleAggregation, quorum *int32, steps ...v1alpha1.InferenceStep) v1alpha1.InferenceGraphSpec {
+	return v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType:  v1alpha1.Ensemble,
+				Aggregation: aggregation,
+				Quorum:      quorum,
+				Steps:       steps,
+			},
+		},
+	}
+}
+
+func TestEnsembleAggregations(t *testing.T) {
+	model1 := modelServer(t, `{"predictions": [[0.5, 0.5], 4]}`, http.StatusOK, 0)
+	model2 := modelServer(t, `{"predictions": [[0.25, 0.75], 1]}`, http.StatusOK, 0)
+	model3 := modelServer(t, `{"predictions": [[0.75, 0.25], 1]}`, http.StatusOK, 0)
+
+	scenarios := map[string]struct {
+		aggregation v1alpha1.EnsembleAggregation
+		expected    string
+	}{
+		"majority vote": {
+			aggregation: v1alpha1.MajorityVoteAggregation,
+			expected:    `{"predictions": [[0.5, 0.5], 1]}`,
+		},
+		"mean": {
+			aggregation: v1alpha1.MeanAggregation,
+			expected:    `{"predictions": [[0.5, 0.5], 2]}`,
+		},
+		"weighted mean": {
+			aggregation: v1alpha1.WeightedMeanAggregation,
+			expected:    `{"predictions": [[0.5, 0.5], 2.5]}`,
+		},
+		"max confidence": {
+			aggregation: v1alpha1.MaxConfidenceAggregation,
+			expected:    `{"predictions": [[0.25, 0.75], 4]}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			graphSpec := ensembleGraph(scenario.aggregation, nil,
+				v1alpha1.InferenceStep{StepName: "model1", Weight: proto.Int64(2), InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1}},
+				v1alpha1.InferenceStep{StepName: "model2", Weight: proto.Int64(1), InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model2}},
+				v1alpha1.InferenceStep{StepName: "model3", Weight: proto.Int64(1), InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model3}},
+			)
+			res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"instances": [[1], [2]]}`)}, http.Header{})
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+			assert.JSONEq(t, scenario.expected, string(res.body))
+		})
+	}
+}
+
+func TestEnsembleFirstSuccessful(t *testing.T) {
+	failing := modelServer(t, `{"error": "unavailable"}`, http.StatusServiceUnavailable, 0)
+	slow := modelServer(t, `{"predictions": ["slow"]}`, http.StatusOK, time.Second)
+	fast := modelServer(t, `{"predictions": ["fast"]}`, http.StatusOK, 50*time.Millisecond)
+
+	graphSpec := ensembleGraph(v1alpha1.FirstSuccessfulAggregation, nil,
+		v1alpha1.InferenceStep{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: failing}},
+		v1alpha1.InferenceStep{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: slow}},
+		v1alpha1.InferenceStep{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: fast}},
+	)
+	start := time.Now()
+	res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"instances": [1]}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"predictions": ["fast"]}`, string(res.body))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestEnsembleQuorum(t *testing.T) {
+	model1 := modelServer(t, `{"predictions": [1]}`, http.StatusOK, 0)
+	model2 := modelServer(t, `{"predictions": [1]}`, http.StatusOK, 0)
+	failing := modelServer(t, `{"error": "unavailable"}`, http.StatusServiceUnavailable, 0)
+	slow := modelServer(t, `{"predictions": [0]}`, http.StatusOK, time.Second)
+
+	steps := []v1alpha1.InferenceStep{
+		{StepName: "model1", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model1}},
+		{StepName: "model2", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model2}},
+		{StepName: "failing", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: failing}},
+		{StepName: "slow", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: slow}, Timeout: "50ms"},
+	}
+
+	// without a quorum, the timeout of a step fails the node
+	_, statusCode, err := routeStep("root", ensembleGraph(v1alpha1.MergeAggregation, nil, steps...), payload{body: []byte(`{"instances": [1]}`)}, http.Header{})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+
+	res, statusCode, err := routeStep("root", ensembleGraph(v1alpha1.MergeAggregation, proto.Int32(2), steps...), payload{body: []byte(`{"instances": [1]}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"model1": {"predictions": [1]}, "model2": {"predictions": [1]}}`, string(res.body))
+
+	_, statusCode, err = routeStep("root", ensembleGraph(v1alpha1.MajorityVoteAggregation, proto.Int32(3), steps...), payload{body: []byte(`{"instances": [1]}`)}, http.Header{})
+	require.ErrorContains(t, err, "only 2 of the 4 steps of ensemble node root succeeded, 3 required")
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+
+	// the failure of a hard dependency decides the response of the node
+	steps[2].Dependency = v1alpha1.Hard
+	res, statusCode, err = routeStep("root", ensembleGraph(v1alpha1.MergeAggregation, proto.Int32(2), steps...), payload{body: []byte(`{"instances": [1]}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	assert.JSONEq(t, `{"error": "unavailable"}`, string(res.body))
+}
+
+func TestEnsemblePredictionErrors(t *testing.T) {
+	_, err := weightedMean([]interface{}{[]interface{}{1.0, 2.0}, []interface{}{1.0}}, []float64{1, 1})
+	require.ErrorContains(t, err, "different shapes")
+	_, err = weightedMean([]interface{}{"cat", "dog"}, []float64{1, 1})
+	require.ErrorContains(t, err, "not numeric")
+	_, err = maxConfidence([]interface{}{"cat"})
+	require.ErrorContains(t, err, "no confidence")
+}
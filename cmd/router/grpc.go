@@ -0,0 +1,801 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	inference "github.com/kserve/kserve/pkg/protocol/grpc/v2"
+)
+
+// The router talks the REST Open Inference Protocol internally. The requests and responses of
+// the gRPC steps and of the gRPC ingress are translated from and to REST at the edges, so that
+// the steps of a graph can use different protocols.
+
+// binaryDataSizeParameter is the tensor parameter giving the size of the data of a tensor sent as
+// binary data with the binary tensor extension of the REST protocol.
+const binaryDataSizeParameter = "binary_data_size"
+
+// restOnlyParameters are the parameters of the binary tensor extension, which have no meaning over gRPC.
+var restOnlyParameters = []string{binaryDataSizeParameter, "binary_data", "binary_data_output"}
+
+// grpcModelPath matches the path of the urls of the gRPC steps, which gives the model to call.
+var grpcModelPath = regexp.MustCompile(`^/v2/models/([^/]+)(?:/versions/([^/]+))?/infer$`)
+
+// grpcHttpStatusCodes maps the gRPC status codes to the HTTP status codes, and back.
+var grpcHttpStatusCodes = map[codes.Code]int{
+	codes.OK:                http.StatusOK,
+	codes.InvalidArgument:   http.StatusBadRequest,
+	codes.Unauthenticated:   http.StatusUnauthorized,
+	codes.PermissionDenied:  http.StatusForbidden,
+	codes.NotFound:          http.StatusNotFound,
+	codes.ResourceExhausted: http.StatusTooManyRequests,
+	codes.Internal:          http.StatusInternalServerError,
+	codes.Unimplemented:     http.StatusNotImplemented,
+	codes.Unavailable:       http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+}
+
+func httpStatusCode(code codes.Code) int {
+	if statusCode, ok := grpcHttpStatusCodes[code]; ok {
+		return statusCode
+	}
+	return http.StatusInternalServerError
+}
+
+func grpcStatusCode(statusCode int) codes.Code {
+	for code, httpCode := range grpcHttpStatusCodes {
+		if httpCode == statusCode {
+			return code
+		}
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return codes.FailedPrecondition
+	}
+	return codes.Unknown
+}
+
+// v2Tensor is a tensor of the REST Open Inference Protocol requests and responses.
+type v2Tensor struct {
+	Name       string                 `json:"name"`
+	Datatype   string                 `json:"datatype"`
+	Shape      []int64                `json:"shape"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Data       interface{}            `json:"data,omitempty"`
+}
+
+// v2RequestedOutput is an output requested by a REST Open Inference Protocol request.
+type v2RequestedOutput struct {
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type v2InferRequest struct {
+	Id         string                 `json:"id,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Inputs     []v2Tensor             `json:"inputs"`
+	Outputs    []v2RequestedOutput    `json:"outputs,omitempty"`
+}
+
+type v2InferResponse struct {
+	ModelName    string                 `json:"model_name,omitempty"`
+	ModelVersion string                 `json:"model_version,omitempty"`
+	Id           string                 `json:"id,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Outputs      []v2Tensor             `json:"outputs"`
+}
+
+// grpcTensor holds the fields shared by the input and output tensors of the gRPC messages.
+type grpcTensor interface {
+	GetName() string
+	GetDatatype() string
+	GetShape() []int64
+	GetParameters() map[string]*inference.InferParameter
+	GetContents() *inference.InferTensorContents
+}
+
+var (
+	grpcConnectionsMutex sync.Mutex
+	// grpcConnections holds the client connections to the gRPC steps, by scheme and host
+	grpcConnections = map[string]*grpc.ClientConn{}
+)
+
+// grpcConnection returns the client connection to the host of the url, using TLS for https urls,
+// unless the router is in the Istio mesh for the same reasons as callServiceWithTimeout.
+func grpcConnection(serviceUrl *url.URL) (*grpc.ClientConn, error) {
+	useTLS := serviceUrl.Scheme == "https"
+	if useTLS {
+		isInMesh, err := isInIstioMesh()
+		if err != nil {
+			return nil, err
+		}
+		useTLS = !isInMesh
+	}
+	host := serviceUrl.Host
+	if serviceUrl.Port() == "" {
+		port := "80"
+		if serviceUrl.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(serviceUrl.Hostname(), port)
+	}
+	key := fmt.Sprintf("%t/%s", useTLS, host)
+
+	grpcConnectionsMutex.Lock()
+	defer grpcConnectionsMutex.Unlock()
+	if conn, ok := grpcConnections[key]; ok {
+		return conn, nil
+	}
+	transportCredentials := insecure.NewCredentials()
+	if useTLS {
+		transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, err
+	}
+	grpcConnections[key] = conn
+	return conn, nil
+}
+
+// callGrpcServiceWithTimeout calls the ModelInfer method of a gRPC step, with the given timeout
+// or the router service client timeout when it is not set. The model is given by the path of the
+// service url, and the REST request and response are translated from and to gRPC.
+func callGrpcServiceWithTimeout(serviceUrl string, input payload, headers http.Header, timeout time.Duration) (payload, int, error) {
+	defer timeTrack(time.Now(), "step", serviceUrl)
+	log.Info("Entering callGrpcService", "url", serviceUrl)
+
+	parsedServiceUrl, err := url.Parse(serviceUrl)
+	if err != nil {
+		return payload{}, 500, err
+	}
+	modelPath := grpcModelPath.FindStringSubmatch(parsedServiceUrl.Path)
+	if modelPath == nil {
+		return payload{}, 500, fmt.Errorf("the url %s of the gRPC step does not have a /v2/models/<model>/infer path", serviceUrl)
+	}
+	request, err := grpcInferRequest(input)
+	if err != nil {
+		return payload{}, 500, errors.Wrapf(err, "failed to translate the request of gRPC service %s", serviceUrl)
+	}
+	request.ModelName, request.ModelVersion = modelPath[1], modelPath[2]
+
+	conn, err := grpcConnection(parsedServiceUrl)
+	if err != nil {
+		log.Error(err, "An error has occurred while connecting to gRPC service", "service", serviceUrl)
+		return payload{}, 500, err
+	}
+	md := metadata.MD{}
+	for h, values := range propagatedHeaders(headers) {
+		if !strings.EqualFold(h, InferenceHeaderContentLength) {
+			md.Append(h, values...)
+		}
+	}
+	for h, values := range traceHeaders(headers) {
+		md.Set(h, values...)
+	}
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	if timeout <= 0 && routerTimeouts != nil && routerTimeouts.ServiceClient != nil {
+		timeout = time.Duration(*routerTimeouts.ServiceClient) * time.Second
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	response, err := inference.NewGRPCInferenceServiceClient(conn).ModelInfer(ctx, request)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(err, "An error has occurred while calling gRPC service", "service", serviceUrl)
+			return payload{}, 500, err
+		}
+		log.Info("gRPC service returned an error", "service", serviceUrl, "code", st.Code(), "message", st.Message())
+		body, err := json.Marshal(map[string]string{"error": st.Message()})
+		return payload{body: body}, httpStatusCode(st.Code()), err
+	}
+	restResponse, err := restInferResponse(response)
+	if err != nil {
+		return payload{}, 500, errors.Wrapf(err, "failed to translate the response of gRPC service %s", serviceUrl)
+	}
+	return restResponse, 200, nil
+}
+
+// grpcIngressServer serves the gRPC Open Inference Protocol requests sent to the router, by
+// routing them through the graph as REST requests.
+type grpcIngressServer struct {
+	inference.UnimplementedGRPCInferenceServiceServer
+}
+
+func (s *grpcIngressServer) ServerLive(context.Context, *inference.ServerLiveRequest) (*inference.ServerLiveResponse, error) {
+	return &inference.ServerLiveResponse{Live: true}, nil
+}
+
+func (s *grpcIngressServer) ServerReady(context.Context, *inference.ServerReadyRequest) (*inference.ServerReadyResponse, error) {
+	return &inference.ServerReadyResponse{Ready: !isShuttingDown}, nil
+}
+
+func (s *grpcIngressServer) ModelReady(context.Context, *inference.ModelReadyRequest) (*inference.ModelReadyResponse, error) {
+	return &inference.ModelReadyResponse{Ready: !isShuttingDown}, nil
+}
+
+func (s *grpcIngressServer) ModelInfer(ctx context.Context, request *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+	input, err := restInferRequest(request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	headers := http.Header{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, values := range md {
+			for _, v := range values {
+				headers.Add(k, v)
+			}
+		}
+	}
+	headers.Del(InferenceHeaderContentLength)
+
+	response, statusCode, err := routeStep(v1alpha1.GraphRootNodeName, *inferenceGraph, input, headers)
+	if err != nil {
+		log.Error(err, "failed to process gRPC request")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !isSuccessFul(statusCode) {
+		message := gjson.GetBytes(response.jsonHeader(), "error").String()
+		if message == "" {
+			message = string(response.body)
+		}
+		return nil, status.Error(grpcStatusCode(statusCode), message)
+	}
+	grpcResponse, err := grpcInferResponse(response)
+	if err != nil {
+		log.Error(err, "failed to translate the response of the graph to gRPC")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if grpcResponse.ModelName == "" {
+		grpcResponse.ModelName, grpcResponse.ModelVersion = request.ModelName, request.ModelVersion
+	}
+	return grpcResponse, nil
+}
+
+// isGrpcRequest tells whether the request is a gRPC request.
+func isGrpcRequest(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
+// grpcStatusWriter sends the HTTP status of the responses rejecting a gRPC request, like the ones of the
+// authorization, as the gRPC status that the gRPC clients read, with the X-Forbidden-Reason as message.
+type grpcStatusWriter struct {
+	http.ResponseWriter
+}
+
+func (w grpcStatusWriter) WriteHeader(statusCode int) {
+	if isSuccessFul(statusCode) {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	message := strings.Join(w.Header().Values("X-Forbidden-Reason"), ": ")
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+	// a response with only headers is a valid gRPC response, whose headers hold the status
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(grpcStatusCode(statusCode))))
+	w.Header().Set("Grpc-Message", encodeGrpcMessage(message))
+	w.ResponseWriter.WriteHeader(http.StatusOK)
+}
+
+// encodeGrpcMessage percent-encodes the grpc-message header as the gRPC protocol requires.
+func encodeGrpcMessage(message string) string {
+	var encoded strings.Builder
+	for _, c := range []byte(message) {
+		if c >= ' ' && c <= '~' && c != '%' {
+			encoded.WriteByte(c)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+	return encoded.String()
+}
+
+// grpcHandler serves the gRPC requests with the gRPC server, and the other requests with the next handler.
+func grpcHandler(grpcServer *grpc.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGrpcRequest(r) {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// grpcInferRequest translates a REST request into a gRPC request, without the model name.
+func grpcInferRequest(restRequest payload) (*inference.ModelInferRequest, error) {
+	request := v2InferRequest{}
+	if err := decodeJSON(restRequest.jsonHeader(), &request); err != nil {
+		return nil, err
+	}
+	if len(request.Inputs) == 0 {
+		return nil, errors.New("the request has no Open Inference Protocol inputs")
+	}
+	parameters, err := grpcParameters(request.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	grpcRequest := &inference.ModelInferRequest{Id: request.Id, Parameters: parameters}
+	tensorParameters, contents, raw, err := grpcTensorData(request.Inputs, restRequest.binaryData())
+	if err != nil {
+		return nil, err
+	}
+	for i, input := range request.Inputs {
+		grpcRequest.Inputs = append(grpcRequest.Inputs, &inference.ModelInferRequest_InferInputTensor{
+			Name:       input.Name,
+			Datatype:   input.Datatype,
+			Shape:      input.Shape,
+			Parameters: tensorParameters[i],
+			Contents:   contents[i],
+		})
+	}
+	grpcRequest.RawInputContents = raw
+	for _, output := range request.Outputs {
+		parameters, err := grpcParameters(output.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		grpcRequest.Outputs = append(grpcRequest.Outputs, &inference.ModelInferRequest_InferRequestedOutputTensor{
+			Name:       output.Name,
+			Parameters: parameters,
+		})
+	}
+	return grpcRequest, nil
+}
+
+// grpcInferResponse translates a REST response into a gRPC response.
+func grpcInferResponse(restResponse payload) (*inference.ModelInferResponse, error) {
+	response := v2InferResponse{}
+	if err := decodeJSON(restResponse.jsonHeader(), &response); err != nil {
+		return nil, err
+	}
+	if len(response.Outputs) == 0 {
+		return nil, errors.New("the response has no Open Inference Protocol outputs")
+	}
+	parameters, err := grpcParameters(response.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	grpcResponse := &inference.ModelInferResponse{
+		ModelName:    response.ModelName,
+		ModelVersion: response.ModelVersion,
+		Id:           response.Id,
+		Parameters:   parameters,
+	}
+	tensorParameters, contents, raw, err := grpcTensorData(response.Outputs, restResponse.binaryData())
+	if err != nil {
+		return nil, err
+	}
+	for i, output := range response.Outputs {
+		grpcResponse.Outputs = append(grpcResponse.Outputs, &inference.ModelInferResponse_InferOutputTensor{
+			Name:       output.Name,
+			Datatype:   output.Datatype,
+			Shape:      output.Shape,
+			Parameters: tensorParameters[i],
+			Contents:   contents[i],
+		})
+	}
+	grpcResponse.RawOutputContents = raw
+	return grpcResponse, nil
+}
+
+// grpcTensorData returns the parameters and the data of the REST tensors as gRPC tensor contents,
+// or as raw contents for the tensors sent as binary data. gRPC does not allow mixing both.
+func grpcTensorData(tensors []v2Tensor, binaryData []byte) ([]map[string]*inference.InferParameter, []*inference.InferTensorContents, [][]byte, error) {
+	parameters := make([]map[string]*inference.InferParameter, len(tensors))
+	contents := make([]*inference.InferTensorContents, len(tensors))
+	var raw [][]byte
+	offset := 0
+	for i, tensor := range tensors {
+		var err error
+		if parameters[i], err = grpcParameters(tensor.Parameters); err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "invalid parameters of tensor %s", tensor.Name)
+		}
+		if size, ok := tensor.Parameters[binaryDataSizeParameter]; ok {
+			n, err := jsonInt(size)
+			if err != nil || n < 0 || offset+int(n) > len(binaryData) {
+				return nil, nil, nil, fmt.Errorf("invalid binary data size %v of tensor %s", size, tensor.Name)
+			}
+			raw = append(raw, binaryData[offset:offset+int(n)])
+			offset += int(n)
+			continue
+		}
+		if contents[i], err = tensorContents(tensor.Datatype, tensor.Data); err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "invalid data of tensor %s", tensor.Name)
+		}
+	}
+	if len(raw) > 0 && len(raw) != len(tensors) {
+		return nil, nil, nil, errors.New("gRPC does not support mixing tensors sent as binary data and tensors sent as JSON")
+	}
+	return parameters, contents, raw, nil
+}
+
+// restInferRequest translates a gRPC request into a REST request.
+func restInferRequest(request *inference.ModelInferRequest) (payload, error) {
+	inputs, binaryData, err := restTensors(request.GetInputs(), request.GetRawInputContents())
+	if err != nil {
+		return payload{}, err
+	}
+	restRequest := v2InferRequest{
+		Id:         request.GetId(),
+		Parameters: restParameters(request.GetParameters()),
+		Inputs:     inputs,
+	}
+	for _, output := range request.GetOutputs() {
+		restRequest.Outputs = append(restRequest.Outputs, v2RequestedOutput{
+			Name:       output.GetName(),
+			Parameters: restParameters(output.GetParameters()),
+		})
+	}
+	header, err := json.Marshal(restRequest)
+	if err != nil {
+		return payload{}, err
+	}
+	return binaryPayload(header, binaryData), nil
+}
+
+// restInferResponse translates a gRPC response into a REST response.
+func restInferResponse(response *inference.ModelInferResponse) (payload, error) {
+	outputs, binaryData, err := restTensors(response.GetOutputs(), response.GetRawOutputContents())
+	if err != nil {
+		return payload{}, err
+	}
+	header, err := json.Marshal(v2InferResponse{
+		ModelName:    response.GetModelName(),
+		ModelVersion: response.GetModelVersion(),
+		Id:           response.GetId(),
+		Parameters:   restParameters(response.GetParameters()),
+		Outputs:      outputs,
+	})
+	if err != nil {
+		return payload{}, err
+	}
+	return binaryPayload(header, binaryData), nil
+}
+
+// restTensors translates gRPC tensors into REST tensors. The raw contents are decoded into JSON
+// data, except for the datatypes which have no JSON representation, which are returned as binary data.
+func restTensors[T grpcTensor](tensors []T, raw [][]byte) ([]v2Tensor, []byte, error) {
+	if len(raw) > 0 && len(raw) != len(tensors) {
+		return nil, nil, fmt.Errorf("%d raw contents for %d tensors", len(raw), len(tensors))
+	}
+	restTensors := make([]v2Tensor, len(tensors))
+	var binaryData []byte
+	for i, tensor := range tensors {
+		restTensor := v2Tensor{
+			Name:       tensor.GetName(),
+			Datatype:   tensor.GetDatatype(),
+			Shape:      tensor.GetShape(),
+			Parameters: restParameters(tensor.GetParameters()),
+		}
+		if restTensor.Shape == nil {
+			restTensor.Shape = []int64{}
+		}
+		var data []interface{}
+		decoded := true
+		var err error
+		if len(raw) > 0 {
+			data, decoded, err = rawTensorData(restTensor.Datatype, raw[i])
+		} else {
+			data, err = contentsData(restTensor.Datatype, tensor.GetContents())
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid data of tensor %s", restTensor.Name)
+		}
+		if decoded {
+			restTensor.Data = data
+		} else {
+			if restTensor.Parameters == nil {
+				restTensor.Parameters = map[string]interface{}{}
+			}
+			restTensor.Parameters[binaryDataSizeParameter] = len(raw[i])
+			binaryData = append(binaryData, raw[i]...)
+		}
+		restTensors[i] = restTensor
+	}
+	return restTensors, binaryData, nil
+}
+
+// grpcParameters translates REST parameters into gRPC parameters, leaving out the parameters of the
+// binary tensor extension. gRPC parameters are booleans, integers or strings.
+func grpcParameters(parameters map[string]interface{}) (map[string]*inference.InferParameter, error) {
+	var grpcParameters map[string]*inference.InferParameter
+	for name, value := range parameters {
+		if isRestOnlyParameter(name) {
+			continue
+		}
+		parameter := &inference.InferParameter{}
+		switch v := value.(type) {
+		case bool:
+			parameter.ParameterChoice = &inference.InferParameter_BoolParam{BoolParam: v}
+		case string:
+			parameter.ParameterChoice = &inference.InferParameter_StringParam{StringParam: v}
+		case json.Number:
+			i, err := v.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s is not an integer", name)
+			}
+			parameter.ParameterChoice = &inference.InferParameter_Int64Param{Int64Param: i}
+		default:
+			return nil, fmt.Errorf("parameter %s is not a boolean, an integer or a string", name)
+		}
+		if grpcParameters == nil {
+			grpcParameters = map[string]*inference.InferParameter{}
+		}
+		grpcParameters[name] = parameter
+	}
+	return grpcParameters, nil
+}
+
+func isRestOnlyParameter(name string) bool {
+	for _, parameter := range restOnlyParameters {
+		if name == parameter {
+			return true
+		}
+	}
+	return false
+}
+
+func restParameters(parameters map[string]*inference.InferParameter) map[string]interface{} {
+	var restParameters map[string]interface{}
+	for name, parameter := range parameters {
+		var value interface{}
+		switch choice := parameter.GetParameterChoice().(type) {
+		case *inference.InferParameter_BoolParam:
+			value = choice.BoolParam
+		case *inference.InferParameter_Int64Param:
+			value = choice.Int64Param
+		case *inference.InferParameter_StringParam:
+			value = choice.StringParam
+		default:
+			continue
+		}
+		if restParameters == nil {
+			restParameters = map[string]interface{}{}
+		}
+		restParameters[name] = value
+	}
+	return restParameters
+}
+
+// flattenTensorData returns the elements of the data of a REST tensor, which may be nested
+// following the shape of the tensor, in row-major order.
+func flattenTensorData(data interface{}) []interface{} {
+	values, ok := data.([]interface{})
+	if !ok {
+		return []interface{}{data}
+	}
+	var flattened []interface{}
+	for _, value := range values {
+		if nested, ok := value.([]interface{}); ok {
+			flattened = append(flattened, flattenTensorData(nested)...)
+		} else {
+			flattened = append(flattened, value)
+		}
+	}
+	return flattened
+}
+
+func jsonInt(value interface{}) (int64, error) {
+	if n, ok := value.(json.Number); ok {
+		return n.Int64()
+	}
+	return 0, fmt.Errorf("%v is not an integer", value)
+}
+
+func jsonUint(value interface{}) (uint64, error) {
+	if n, ok := value.(json.Number); ok {
+		return strconv.ParseUint(n.String(), 10, 64)
+	}
+	return 0, fmt.Errorf("%v is not an unsigned integer", value)
+}
+
+func jsonFloat(value interface{}) (float64, error) {
+	if n, ok := value.(json.Number); ok {
+		return n.Float64()
+	}
+	return 0, fmt.Errorf("%v is not a number", value)
+}
+
+// tensorContents translates the data of a REST tensor into the gRPC tensor contents of its datatype.
+func tensorContents(datatype string, data interface{}) (*inference.InferTensorContents, error) {
+	contents := &inference.InferTensorContents{}
+	if data == nil {
+		return contents, nil
+	}
+	for _, value := range flattenTensorData(data) {
+		var err error
+		switch datatype {
+		case "BOOL":
+			b, ok := value.(bool)
+			if !ok {
+				err = fmt.Errorf("%v is not a boolean", value)
+			}
+			contents.BoolContents = append(contents.BoolContents, b)
+		case "INT8", "INT16", "INT32":
+			var i int64
+			if i, err = jsonInt(value); err == nil && (i < math.MinInt32 || i > math.MaxInt32) {
+				err = fmt.Errorf("%d overflows %s", i, datatype)
+			}
+			contents.IntContents = append(contents.IntContents, int32(i)) // #nosec G115
+		case "INT64":
+			var i int64
+			i, err = jsonInt(value)
+			contents.Int64Contents = append(contents.Int64Contents, i)
+		case "UINT8", "UINT16", "UINT32":
+			var u uint64
+			if u, err = jsonUint(value); err == nil && u > math.MaxUint32 {
+				err = fmt.Errorf("%d overflows %s", u, datatype)
+			}
+			contents.UintContents = append(contents.UintContents, uint32(u)) // #nosec G115
+		case "UINT64":
+			var u uint64
+			u, err = jsonUint(value)
+			contents.Uint64Contents = append(contents.Uint64Contents, u)
+		case "FP32":
+			var f float64
+			f, err = jsonFloat(value)
+			contents.Fp32Contents = append(contents.Fp32Contents, float32(f))
+		case "FP64":
+			var f float64
+			f, err = jsonFloat(value)
+			contents.Fp64Contents = append(contents.Fp64Contents, f)
+		case "BYTES":
+			s, ok := value.(string)
+			if !ok {
+				err = fmt.Errorf("%v is not a string", value)
+			}
+			contents.BytesContents = append(contents.BytesContents, []byte(s))
+		default:
+			return nil, fmt.Errorf("datatype %s can only be sent as binary data over gRPC", datatype)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+// contentsData translates the gRPC tensor contents of a datatype into the data of a REST tensor.
+func contentsData(datatype string, contents *inference.InferTensorContents) ([]interface{}, error) {
+	data := []interface{}{}
+	switch datatype {
+	case "BOOL":
+		for _, v := range contents.GetBoolContents() {
+			data = append(data, v)
+		}
+	case "INT8", "INT16", "INT32":
+		for _, v := range contents.GetIntContents() {
+			data = append(data, v)
+		}
+	case "INT64":
+		for _, v := range contents.GetInt64Contents() {
+			data = append(data, v)
+		}
+	case "UINT8", "UINT16", "UINT32":
+		for _, v := range contents.GetUintContents() {
+			data = append(data, v)
+		}
+	case "UINT64":
+		for _, v := range contents.GetUint64Contents() {
+			data = append(data, v)
+		}
+	case "FP32":
+		for _, v := range contents.GetFp32Contents() {
+			data = append(data, v)
+		}
+	case "FP64":
+		for _, v := range contents.GetFp64Contents() {
+			data = append(data, v)
+		}
+	case "BYTES":
+		for _, v := range contents.GetBytesContents() {
+			data = append(data, string(v))
+		}
+	default:
+		return nil, fmt.Errorf("datatype %s has no gRPC tensor contents", datatype)
+	}
+	return data, nil
+}
+
+// rawTensorData decodes the raw contents of a tensor, which are little-endian, and returns false
+// for the datatypes which have no JSON representation.
+func rawTensorData(datatype string, raw []byte) ([]interface{}, bool, error) {
+	data := []interface{}{}
+	if datatype == "BYTES" {
+		// every element is prefixed by its length on 4 bytes
+		for len(raw) > 0 {
+			if len(raw) < 4 || uint64(len(raw)-4) < uint64(binary.LittleEndian.Uint32(raw)) {
+				return nil, false, errors.New("truncated BYTES element")
+			}
+			length := int(binary.LittleEndian.Uint32(raw))
+			data = append(data, string(raw[4:4+length]))
+			raw = raw[4+length:]
+		}
+		return data, true, nil
+	}
+	sizes := map[string]int{
+		"BOOL": 1, "INT8": 1, "UINT8": 1, "INT16": 2, "UINT16": 2, "INT32": 4, "UINT32": 4,
+		"INT64": 8, "UINT64": 8, "FP32": 4, "FP64": 8,
+	}
+	size, ok := sizes[datatype]
+	if !ok {
+		return nil, false, nil
+	}
+	if len(raw)%size != 0 {
+		return nil, false, fmt.Errorf("%d bytes are not a whole number of %s elements", len(raw), datatype)
+	}
+	for offset := 0; offset < len(raw); offset += size {
+		element := raw[offset : offset+size]
+		var value interface{}
+		switch datatype {
+		case "BOOL":
+			value = element[0] != 0
+		case "INT8":
+			value = int8(element[0]) // #nosec G115
+		case "UINT8":
+			value = element[0]
+		case "INT16":
+			value = int16(binary.LittleEndian.Uint16(element)) // #nosec G115
+		case "UINT16":
+			value = binary.LittleEndian.Uint16(element)
+		case "INT32":
+			value = int32(binary.LittleEndian.Uint32(element)) // #nosec G115
+		case "UINT32":
+			value = binary.LittleEndian.Uint32(element)
+		case "INT64":
+			value = int64(binary.LittleEndian.Uint64(element)) // #nosec G115
+		case "UINT64":
+			value = binary.LittleEndian.Uint64(element)
+		case "FP32":
+			value = math.Float32frombits(binary.LittleEndian.Uint32(element))
+		case "FP64":
+			value = math.Float64frombits(binary.LittleEndian.Uint64(element))
+		}
+		data = append(data, value)
+	}
+	return data, true, nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	inference "github.com/kserve/kserve/pkg/protocol/grpc/v2"
+)
+
+type fakeInferenceServer struct {
+	inference.UnimplementedGRPCInferenceServiceServer
+	modelInfer func(ctx context.Context, request *inference.ModelInferRequest) (*inference.ModelInferResponse, error)
+}
+
+func (s *fakeInferenceServer) ModelInfer(ctx context.Context, request *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+	return s.modelInfer(ctx, request)
+}
+
+// grpcModelServer starts an in-process gRPC model server and returns its address.
+func grpcModelServer(t *testing.T, modelInfer func(ctx context.Context, request *inference.ModelInferRequest) (*inference.ModelInferResponse, error)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	inference.RegisterGRPCInferenceServiceServer(server, &fakeInferenceServer{modelInfer: modelInfer})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func fp32Bytes(values ...float32) []byte {
+	raw := make([]byte, 0, 4*len(values))
+	for _, v := range values {
+		raw = binary.LittleEndian.AppendUint32(raw, math.Float32bits(v))
+	}
+	return raw
+}
+
+func TestGrpcStepInSequence(t *testing.T) {
+	preprocess := modelServer(t, `{"outputs": [{"name": "OUTPUT0", "datatype": "FP32", "shape": [1, 2], "data": [0.5, 0.25]}]}`, http.StatusOK, 0)
+
+	var modelRequest *inference.ModelInferRequest
+	var modelMetadata metadata.MD
+	model := grpcModelServer(t, func(ctx context.Context, request *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+		modelRequest = request
+		modelMetadata, _ = metadata.FromIncomingContext(ctx)
+		return &inference.ModelInferResponse{
+			ModelName: request.ModelName,
+			Outputs: []*inference.ModelInferResponse_InferOutputTensor{
+				{Name: "probabilities", Datatype: "FP32", Shape: []int64{1, 2}},
+				{Name: "label", Datatype: "BYTES", Shape: []int64{1}},
+			},
+			RawOutputContents: [][]byte{fp32Bytes(0.25, 0.75), append([]byte{3, 0, 0, 0}, "cat"...)},
+		}, nil
+	})
+
+	previous := compiledHeaderPatterns
+	defer func() { compiledHeaderPatterns = previous }()
+	compiledHeaderPatterns = []*regexp.Regexp{regexp.MustCompile("Test-Header-.*")}
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "preprocess",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: preprocess},
+					},
+					{
+						StepName: "classifier",
+						InferenceTarget: v1alpha1.InferenceTarget{
+							ServiceURL: "http://" + model + "/v2/models/classifier/versions/2/infer",
+							Protocol:   constants.ProtocolGRPCV2,
+						},
+						Data:               "$response",
+						MapOutputsToInputs: true,
+						TensorMapping:      map[string]string{"OUTPUT0": "input-0"},
+					},
+				},
+			},
+		},
+	}
+	headers := http.Header{"Test-Header-Key": {"value"}, "Other-Header": {"other"}}
+	res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"inputs": [{"name": "raw", "datatype": "BYTES", "shape": [1], "data": ["x"]}]}`)}, headers)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"model_name": "classifier", "outputs": [`+
+		`{"name": "probabilities", "datatype": "FP32", "shape": [1, 2], "data": [0.25, 0.75]},`+
+		`{"name": "label", "datatype": "BYTES", "shape": [1], "data": ["cat"]}]}`, string(res.body))
+
+	require.NotNil(t, modelRequest)
+	assert.Equal(t, "classifier", modelRequest.ModelName)
+	assert.Equal(t, "2", modelRequest.ModelVersion)
+	require.Len(t, modelRequest.Inputs, 1)
+	assert.Equal(t, "input-0", modelRequest.Inputs[0].Name)
+	assert.Equal(t, []int64{1, 2}, modelRequest.Inputs[0].Shape)
+	assert.Equal(t, []float32{0.5, 0.25}, modelRequest.Inputs[0].Contents.Fp32Contents)
+	assert.Equal(t, []string{"value"}, modelMetadata.Get("test-header-key"))
+	assert.Empty(t, modelMetadata.Get("other-header"))
+}
+
+func TestGrpcStepErrors(t *testing.T) {
+	model := grpcModelServer(t, func(context.Context, *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+		return nil, status.Error(codes.NotFound, "unknown model")
+	})
+	input := []byte(`{"inputs": [{"name": "x", "datatype": "INT32", "shape": [1], "data": [1]}]}`)
+
+	res, statusCode, err := callGrpcServiceWithTimeout("http://"+model+"/v2/models/missing/infer", payload{body: input}, http.Header{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.JSONEq(t, `{"error": "unknown model"}`, string(res.body))
+
+	_, _, err = callGrpcServiceWithTimeout("http://"+model+"/predict", payload{body: input}, http.Header{}, 0)
+	require.ErrorContains(t, err, "does not have a /v2/models/<model>/infer path")
+
+	_, _, err = callGrpcServiceWithTimeout("http://"+model+"/v2/models/missing/infer", payload{body: []byte(`{"instances": [1]}`)}, http.Header{}, 0)
+	require.ErrorContains(t, err, "no Open Inference Protocol inputs")
+}
+
+func TestGrpcIngress(t *testing.T) {
+	var stepRequest []byte
+	statusCode := http.StatusOK
+	step := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		stepRequest, _ = io.ReadAll(req.Body)
+		rw.WriteHeader(statusCode)
+		if statusCode != http.StatusOK {
+			_, _ = rw.Write([]byte(`{"error": "overloaded"}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"model_name": "echo", "outputs": [{"name": "OUTPUT0", "datatype": "INT64", "shape": [2], "data": [1, 2]}]}`))
+	}))
+	defer step.Close()
+
+	previous := inferenceGraph
+	defer func() { inferenceGraph = previous }()
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "echo", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: step.URL}},
+				},
+			},
+		},
+	}
+
+	grpcServer := grpc.NewServer()
+	inference.RegisterGRPCInferenceServiceServer(grpcServer, &grpcIngressServer{})
+	router := httptest.NewUnstartedServer(grpcHandler(grpcServer, http.HandlerFunc(graphHandler)))
+	router.Config.Protocols = new(http.Protocols)
+	router.Config.Protocols.SetHTTP1(true)
+	router.Config.Protocols.SetUnencryptedHTTP2(true)
+	router.Start()
+	defer router.Close()
+
+	conn, err := grpc.NewClient(router.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := inference.NewGRPCInferenceServiceClient(conn)
+
+	ready, err := client.ServerReady(context.Background(), &inference.ServerReadyRequest{})
+	require.NoError(t, err)
+	assert.True(t, ready.Ready)
+
+	request := &inference.ModelInferRequest{
+		ModelName: "graph",
+		Inputs: []*inference.ModelInferRequest_InferInputTensor{
+			{Name: "x", Datatype: "INT32", Shape: []int64{2}, Contents: &inference.InferTensorContents{IntContents: []int32{3, 4}}},
+		},
+	}
+	response, err := client.ModelInfer(context.Background(), request)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"inputs": [{"name": "x", "datatype": "INT32", "shape": [2], "data": [3, 4]}]}`, string(stepRequest))
+	assert.Equal(t, "echo", response.ModelName)
+	require.Len(t, response.Outputs, 1)
+	assert.Equal(t, "OUTPUT0", response.Outputs[0].Name)
+	assert.Equal(t, []int64{2}, response.Outputs[0].Shape)
+	assert.Equal(t, []int64{1, 2}, response.Outputs[0].Contents.Int64Contents)
+
+	statusCode = http.StatusServiceUnavailable
+	_, err = client.ModelInfer(context.Background(), request)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "overloaded", status.Convert(err).Message())
+
+	// the REST requests are still served by the graph handler
+	res, err := http.Post(router.URL, "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+}
+
+func TestGrpcAuthRejections(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	inference.RegisterGRPCInferenceServiceServer(grpcServer, &grpcIngressServer{})
+	handler := authMiddleware(grpcHandler(grpcServer, http.HandlerFunc(graphHandler)))
+	router := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/inference.GRPCInferenceService/ServerReady" {
+			// reject the requests without credentials, as the authorization does
+			findBearerToken(grpcStatusWriter{w}, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	router.Config.Protocols = new(http.Protocols)
+	router.Config.Protocols.SetHTTP1(true)
+	router.Config.Protocols.SetUnencryptedHTTP2(true)
+	router.Start()
+	defer router.Close()
+
+	conn, err := grpc.NewClient(router.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := inference.NewGRPCInferenceServiceClient(conn)
+
+	_, err = client.ServerReady(context.Background(), &inference.ServerReadyRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "No credentials were provided", status.Convert(err).Message())
+
+	// the router does not run in a cluster, so the authorization fails
+	_, err = client.ModelInfer(context.Background(), &inference.ModelInferRequest{ModelName: "graph"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "Internal Server Error", status.Convert(err).Message())
+
+	// the REST requests are still rejected with an HTTP status
+	res, err := http.Post(router.URL, "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func TestEncodeGrpcMessage(t *testing.T) {
+	assert.Equal(t, "Access to the InferenceGraph is not allowed", encodeGrpcMessage("Access to the InferenceGraph is not allowed"))
+	assert.Equal(t, "100%25 r%C3%A9ject%0A", encodeGrpcMessage("100% réject\n"))
+}
+
+func TestGrpcTensorTranslation(t *testing.T) {
+	// the datatypes without a JSON representation are kept as binary data
+	fp16 := []byte{0x00, 0x3c, 0x00, 0x40}
+	response, err := restInferResponse(&inference.ModelInferResponse{
+		Outputs: []*inference.ModelInferResponse_InferOutputTensor{
+			{Name: "half", Datatype: "FP16", Shape: []int64{2}},
+			{Name: "flags", Datatype: "BOOL", Shape: []int64{2}},
+		},
+		RawOutputContents: [][]byte{fp16, {1, 0}},
+	})
+	require.NoError(t, err)
+	require.True(t, response.isBinary())
+	assert.JSONEq(t, `{"outputs": [`+
+		`{"name": "half", "datatype": "FP16", "shape": [2], "parameters": {"binary_data_size": 4}},`+
+		`{"name": "flags", "datatype": "BOOL", "shape": [2], "data": [true, false]}]}`, string(response.jsonHeader()))
+	assert.Equal(t, fp16, response.binaryData())
+
+	// binary data is sent as raw contents, and nested data is flattened
+	request, err := grpcInferRequest(binaryPayload([]byte(`{"inputs": [{"name": "half", "datatype": "FP16", "shape": [2], "parameters": {"binary_data_size": 4}}]}`), fp16))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{fp16}, request.RawInputContents)
+	assert.Empty(t, request.Inputs[0].Parameters)
+	request, err = grpcInferRequest(payload{body: []byte(`{"id": "1", "parameters": {"priority": 2}, "inputs": [{"name": "x", "datatype": "UINT64", "shape": [2, 2], "data": [[1, 2], [3, 18446744073709551615]]}]}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "1", request.Id)
+	assert.Equal(t, int64(2), request.Parameters["priority"].GetInt64Param())
+	assert.Equal(t, []uint64{1, 2, 3, math.MaxUint64}, request.Inputs[0].Contents.Uint64Contents)
+
+	_, err = grpcInferRequest(binaryPayload([]byte(`{"inputs": [`+
+		`{"name": "half", "datatype": "FP16", "shape": [2], "parameters": {"binary_data_size": 4}},`+
+		`{"name": "x", "datatype": "INT32", "shape": [1], "data": [1]}]}`), fp16))
+	require.ErrorContains(t, err, "mixing tensors")
+	_, err = grpcInferRequest(payload{body: []byte(`{"inputs": [{"name": "x", "datatype": "FP32", "shape": [1], "data": ["a"]}]}`)})
+	require.ErrorContains(t, err, "invalid data of tensor x")
+}
@@ -19,12 +19,10 @@ package main
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
 	"encoding/json"
 	goerrors "errors"
 	"fmt"
 	"io"
-	"math/big"
 	"net/http"
 	"net/url"
 	"os"
@@ -36,8 +34,10 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
-	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
 	authnv1 "k8s.io/api/authentication/v1"
 	authzv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,6 +48,9 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/inferencegraph/condition"
+	"github.com/kserve/kserve/pkg/inferencegraph/template"
+	inference "github.com/kserve/kserve/pkg/protocol/grpc/v2"
 )
 
 // _isInMesh is an auxiliary global variable for isInIstioMesh function.
@@ -96,17 +99,25 @@ func isInIstioMesh() (bool, error) {
 	return *_isInMesh, err
 }
 
-func callService(serviceUrl string, input []byte, headers http.Header) ([]byte, int, error) {
+func callService(serviceUrl string, input payload, headers http.Header) (payload, int, error) {
+	return callServiceWithTimeout(serviceUrl, input, headers, 0, nil)
+}
+
+// callServiceWithTimeout calls the service with the given timeout, or with the router service
+// client timeout when it is not set. The successful server-sent events responses are forwarded
+// to the client as they arrive when the response stream is set, in which case the timeout
+// applies to the wait for every chunk of the response.
+func callServiceWithTimeout(serviceUrl string, input payload, headers http.Header, timeout time.Duration, stream *responseStream) (payload, int, error) {
 	defer timeTrack(time.Now(), "step", serviceUrl)
 	log.Info("Entering callService", "url", serviceUrl)
 
 	parsedServiceUrl, parseServiceUrlErr := url.Parse(serviceUrl)
 	if parseServiceUrlErr != nil {
-		return nil, 500, parseServiceUrlErr
+		return payload{}, 500, parseServiceUrlErr
 	}
 	if parsedServiceUrl.Scheme == "https" {
 		if isInMesh, isInMeshErr := isInIstioMesh(); isInMeshErr != nil {
-			return nil, 500, isInMeshErr
+			return payload{}, 500, isInMeshErr
 		} else if isInMesh {
 			// In this branch, it has been resolved that the Inference Graph is
 			// part of the Istio mesh. In this case, even if the target service
@@ -126,43 +137,53 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 		}
 	}
 
-	req, err := http.NewRequest(http.MethodPost, serviceUrl, bytes.NewBuffer(input))
+	req, err := http.NewRequest(http.MethodPost, serviceUrl, bytes.NewBuffer(input.body))
 	if err != nil {
 		log.Error(err, "An error occurred while preparing request object with serviceUrl.", "serviceUrl", serviceUrl)
-		return nil, 500, err
+		return payload{}, 500, err
 	}
 
-	// To avoid headers matched more than one time which will lead to duplication of header values
-	matchedHeaders := map[string]bool{}
-	var headersToPropagate []string
-	for _, p := range compiledHeaderPatterns {
-		for h, values := range headers {
-			if _, ok := matchedHeaders[h]; !ok && p.MatchString(h) {
-				matchedHeaders[h] = true
-				headersToPropagate = append(headersToPropagate, h)
-				for _, v := range values {
-					req.Header.Add(h, v)
-				}
-			}
+	propagated := propagatedHeaders(headers)
+	headersToPropagate := make([]string, 0, len(propagated))
+	for h, values := range propagated {
+		headersToPropagate = append(headersToPropagate, h)
+		for _, v := range values {
+			req.Header.Add(h, v)
 		}
 	}
 	log.Info("These headers will be propagated by the router to all the steps", "headers", headersToPropagate)
-	if val := req.Header.Get("Content-Type"); val == "" {
+	for h, values := range traceHeaders(headers) {
+		req.Header[h] = values
+	}
+	// the length of the JSON header of binary tensor payloads is set from the payload sent to the step,
+	// which may differ from the payload received by the router
+	req.Header.Del(InferenceHeaderContentLength)
+	if input.isBinary() {
+		req.Header.Set(InferenceHeaderContentLength, strconv.Itoa(input.headerLength))
+		if val := req.Header.Get("Content-Type"); val == "" {
+			req.Header.Add("Content-Type", "application/octet-stream")
+		}
+	} else if val := req.Header.Get("Content-Type"); val == "" {
 		req.Header.Add("Content-Type", "application/json")
 	}
 
-	var client *http.Client
-	if routerTimeouts == nil || routerTimeouts.ServiceClient == nil {
-		client = http.DefaultClient
-	} else {
-		client = &http.Client{
-			Timeout: time.Duration(*routerTimeouts.ServiceClient) * time.Second,
-		}
+	if timeout <= 0 && routerTimeouts != nil && routerTimeouts.ServiceClient != nil {
+		timeout = time.Duration(*routerTimeouts.ServiceClient) * time.Second
+	}
+	// The timeout is enforced with the context of the request rather than the client timeout,
+	// so that it can be extended while a streamed response keeps arriving
+	var deadline *time.Timer
+	if timeout > 0 {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		deadline = time.AfterFunc(timeout, cancel)
+		defer deadline.Stop()
+		req = req.WithContext(ctx)
 	}
-	resp, err := client.Do(req)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		log.Error(err, "An error has occurred while calling service", "service", serviceUrl)
-		return nil, 500, err
+		return payload{}, 500, err
 	}
 
 	defer func() {
@@ -174,39 +195,66 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 		}
 	}()
 
+	if stream != nil && !stream.started() && isSuccessFul(resp.StatusCode) && isStreamingResponse(resp) {
+		log.Info("Streaming the response of the service", "service", serviceUrl)
+		// a streamed response is given the timeout to send every chunk instead of the whole response
+		body := io.Reader(resp.Body)
+		if deadline != nil {
+			body = &idleTimeoutReader{reader: resp.Body, timer: deadline, timeout: timeout}
+		}
+		if err := stream.forward(resp.StatusCode, resp.Header, body); err != nil {
+			log.Error(err, "An error has occurred while streaming the response", "service", serviceUrl)
+			return payload{}, resp.StatusCode, err
+		}
+		return payload{}, resp.StatusCode, nil
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Error(err, "Error while reading the response")
 	}
-	return body, resp.StatusCode, err
+	// the response is a binary tensor payload when the step gives the length of its JSON header
+	return newPayload(body, resp.Header), resp.StatusCode, err
 }
 
-func pickupRoute(routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
-	randomNumber, err := rand.Int(rand.Reader, big.NewInt(100))
-	if err != nil {
-		panic(err)
-	}
-	point := int(randomNumber.Int64())
-	end := 0
-	for _, route := range routes {
-		end += int(*route.Weight)
-		if point < end {
-			return &route
+// propagatedHeaders returns the headers matching the patterns of the headers the router propagates to the steps.
+func propagatedHeaders(headers http.Header) http.Header {
+	propagated := http.Header{}
+	for _, p := range compiledHeaderPatterns {
+		for h, values := range headers {
+			// To avoid headers matched more than one time which will lead to duplication of header values
+			if _, ok := propagated[h]; !ok && p.MatchString(h) {
+				propagated[h] = values
+			}
 		}
 	}
-	return nil
+	return propagated
 }
 
-func pickupRouteByCondition(input []byte, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
-	if !gjson.ValidBytes(input) {
-		return nil
-	}
-	for _, route := range routes {
-		if gjson.GetBytes(input, route.Condition).Exists() {
-			return &route
+// pickupRouteByCondition returns the first route whose condition matches, or the route without a
+// condition when none matches. The conditions which cannot be evaluated do not match.
+func pickupRouteByCondition(input payload, headers http.Header, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
+	// the conditions of binary tensor payloads are matched against their JSON header
+	conditionInput := &condition.Input{Body: input.jsonHeader(), Headers: headers}
+	var defaultRoute *v1alpha1.InferenceStep
+	for i := range routes {
+		route := &routes[i]
+		if route.Condition == "" {
+			defaultRoute = route
+			continue
+		}
+		c, err := compileCondition(route.Condition, condition.SwitchScope)
+		if err != nil {
+			log.Error(err, "invalid condition", "stepName", route.StepName)
+			continue
+		}
+		if matched, err := c.Match(conditionInput); err != nil {
+			log.Info("Failed to evaluate the condition", "stepName", route.StepName, "reason", err.Error())
+		} else if matched {
+			return route
 		}
 	}
-	return nil
+	return defaultRoute
 }
 
 func timeTrack(start time.Time, nodeOrStep string, name string) {
@@ -214,29 +262,36 @@ func timeTrack(start time.Time, nodeOrStep string, name string) {
 	log.Info("elapsed time", nodeOrStep, name, "time", elapsed)
 }
 
-type EnsembleStepOutput struct {
-	StepResponse   map[string]interface{}
-	StepStatusCode int
-}
-
 // See if reviewer suggests a better name for this function
-func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func handleSplitterORSwitchNode(nodeName string, route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, stream *responseStream) (payload, int, error) {
 	var statusCode int
-	var responseBytes []byte
+	var response payload
 	var err error
 	stepType := "serviceUrl"
 	if route.NodeName != "" {
 		stepType = "node"
 	}
 	log.Info("Starting execution of step", "type", stepType, "stepName", route.StepName)
-	if responseBytes, statusCode, err = executeStep(route, graph, input, headers); err != nil {
-		return nil, 500, err
+	ctx := template.Context{Request: input.body, Response: input.body}
+	if response, statusCode, err = executeNodeStep(nodeName, route, graph, input, headers, ctx, stream); err != nil {
+		return payload{}, 500, err
 	}
 
 	if route.Dependency == v1alpha1.Hard && !isSuccessFul(statusCode) {
 		log.Info("This step is a hard dependency and it is unsuccessful", "stepName", route.StepName, "statusCode", statusCode)
 	}
-	return responseBytes, statusCode, nil
+	return response, statusCode, nil
+}
+
+// executeNodeStep executes the step of the node within the span of the step.
+func executeNodeStep(nodeName string, step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, request payload, headers http.Header, ctx template.Context, stream *responseStream) (payload, int, error) {
+	span, headers := startSpan(headers, "step "+stepLabel(step),
+		attribute.String("inferencegraph.node", nodeName), attribute.String("inferencegraph.step", stepLabel(step)))
+	start := time.Now()
+	response, statusCode, err := executeTemplatedStep(nodeName, step, graph, request, headers, ctx, stream)
+	endSpan(span, statusCode, err)
+	observeStep(nodeName, step, statusCode, time.Since(start))
+	return response, statusCode, err
 }
 
 type sequenceReqRes struct {
@@ -244,86 +299,60 @@ type sequenceReqRes struct {
 	Instances   []interface{} `json:"instances,omitempty"`
 }
 
-func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header) (payload, int, error) {
+	return routeStepToStream(nodeName, graph, input, headers, nil)
+}
+
+// routeStepToStream routes the request to the node, streaming the response of the node to the
+// client when the response stream is set and the step producing the response streams it.
+func routeStepToStream(nodeName string, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, stream *responseStream) (payload, int, error) {
 	defer timeTrack(time.Now(), "node", nodeName)
+	routerType := graph.Nodes[nodeName].RouterType
+	span, headers := startSpan(headers, "node "+nodeName,
+		attribute.String("inferencegraph.node", nodeName), attribute.String("inferencegraph.router_type", string(routerType)))
+	start := time.Now()
+	response, statusCode, err := routeNode(nodeName, graph, input, headers, stream)
+	endSpan(span, statusCode, err)
+	observeNode(nodeName, routerType, statusCode, time.Since(start))
+	return response, statusCode, err
+}
+
+func routeNode(nodeName string, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, stream *responseStream) (payload, int, error) {
 	currentNode := graph.Nodes[nodeName]
 
 	if currentNode.RouterType == v1alpha1.Splitter {
-		route := pickupRoute(currentNode.Steps)
-		if route == nil {
+		i := pickupSplitterRoute(nodeName, currentNode, input, headers)
+		if i < 0 {
 			err := errors.New("no route was selected by the splitter")
 			log.Error(err, "failed to pick a route", "nodeName", nodeName)
-			return nil, 500, err
+			return payload{}, 500, err
 		}
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		route := &currentNode.Steps[i]
+		splitterBranches.WithLabelValues(nodeName, stepLabel(route)).Inc()
+		stream.addHeader(constants.RouterSplitterStepHeader, nodeName+"="+splitterBranch(currentNode.Steps, i))
+		return handleSplitterORSwitchNode(nodeName, route, graph, input, headers, stream)
 	}
 	if currentNode.RouterType == v1alpha1.Switch {
 		var err error
-		route := pickupRouteByCondition(input, currentNode.Steps)
+		route := pickupRouteByCondition(input, headers, currentNode.Steps)
 		if route == nil {
 			errorMessage := "None of the routes matched with the switch condition"
 			err = errors.New(errorMessage)
 			log.Error(err, errorMessage)
-			return nil, 404, err
+			switchNoMatches.WithLabelValues(nodeName).Inc()
+			return payload{}, 404, err
 		}
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		return handleSplitterORSwitchNode(nodeName, route, graph, input, headers, stream)
 	}
 	if currentNode.RouterType == v1alpha1.Ensemble {
-		ensembleRes := make([]chan EnsembleStepOutput, len(currentNode.Steps))
-		errChan := make(chan error)
-		for i := range currentNode.Steps {
-			step := &currentNode.Steps[i]
-			stepType := "serviceUrl"
-			if step.NodeName != "" {
-				stepType = "node"
-			}
-			log.Info("Starting execution of step", "type", stepType, "stepName", step.StepName)
-			resultChan := make(chan EnsembleStepOutput)
-			ensembleRes[i] = resultChan
-			go func() {
-				output, statusCode, err := executeStep(step, graph, input, headers)
-				if err == nil {
-					var res map[string]interface{}
-					if err = json.Unmarshal(output, &res); err == nil {
-						resultChan <- EnsembleStepOutput{
-							StepResponse:   res,
-							StepStatusCode: statusCode,
-						}
-						return
-					}
-				}
-				errChan <- err
-			}()
-		}
-		// merge responses from parallel steps
-		response := map[string]interface{}{}
-		ensembleStepOutput := EnsembleStepOutput{}
-		for i, resultChan := range ensembleRes {
-			key := currentNode.Steps[i].StepName
-			if key == "" {
-				key = strconv.Itoa(i) // Use index if no step name
-			}
-			select {
-			case ensembleStepOutput = <-resultChan:
-				if !isSuccessFul(ensembleStepOutput.StepStatusCode) && currentNode.Steps[i].Dependency == v1alpha1.Hard {
-					log.Info("This step is a hard dependency and it is unsuccessful", "stepName", currentNode.Steps[i].StepName, "statusCode", ensembleStepOutput.StepStatusCode)
-					stepResponse, _ := json.Marshal(ensembleStepOutput.StepResponse) // TODO check if you need err handling for Marshalling
-					return stepResponse, ensembleStepOutput.StepStatusCode, nil      // First failed hard dependency will decide the response and response code for ensemble node
-				} else {
-					response[key] = ensembleStepOutput.StepResponse
-				}
-			case err := <-errChan:
-				return nil, 500, err
-			}
-		}
-		// return json.Marshal(response)
-		combinedResponse, _ := json.Marshal(response) // TODO check if you need err handling for Marshalling
-		return combinedResponse, 200, nil
+		return routeEnsemble(nodeName, currentNode, graph, input, headers)
 	}
 	if currentNode.RouterType == v1alpha1.Sequence {
 		var statusCode int
-		var responseBytes []byte
+		var response payload
 		var err error
+		// the outputs of the named steps, for the step templates
+		stepOutputs := map[string][]byte{}
 		for i := range currentNode.Steps {
 			step := &currentNode.Steps[i]
 			stepType := "serviceUrl"
@@ -334,33 +363,66 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 
 			request := input
 			if step.Data == "$response" && i > 0 {
-				request = responseBytes
+				request = response
 			}
 
 			if step.MapPredictionsToInstances {
 				decoded := sequenceReqRes{}
-				err = json.Unmarshal(request, &decoded)
+				err = json.Unmarshal(request.body, &decoded)
 
 				// If unmarshaling succeeds and Predictions is non-empty,
 				// move Predictions to Instances and re-marshal the request.
 				if err == nil && len(decoded.Predictions) > 0 {
 					decoded.Instances = decoded.Predictions
 					decoded.Predictions = []interface{}{}
-					request, _ = json.Marshal(decoded) // TODO check if you need err handling for Marshalling
+					request.body, _ = json.Marshal(decoded) // TODO check if you need err handling for Marshalling
+				}
+			}
+
+			// the request is sent as it is when it has no outputs to map
+			if step.MapOutputsToInputs {
+				if mapped, mapErr := mapOutputsToInputs(request, step.TensorMapping); mapErr == nil {
+					request = mapped
+				} else {
+					log.Info("Not mapping the outputs to inputs", "stepName", step.StepName, "reason", mapErr.Error())
 				}
 			}
 
 			if step.Condition != "" {
-				if !gjson.ValidBytes(responseBytes) {
-					return nil, 500, errors.New("invalid response")
+				previous := input
+				if i > 0 {
+					previous = response
+				}
+				c, err := compileCondition(step.Condition, condition.SequenceScope)
+				if err != nil {
+					return payload{}, 500, errors.Wrapf(err, "invalid condition of step %q", step.StepName)
+				}
+				matched, err := c.Match(&condition.Input{Body: input.jsonHeader(), Headers: headers, Response: previous.jsonHeader()})
+				if err != nil {
+					if !c.IsExpression() {
+						return payload{}, 500, errors.New("invalid response")
+					}
+					log.Info("Failed to evaluate the condition", "stepName", step.StepName, "reason", err.Error())
 				}
 				// if the condition does not match for the step in the sequence we stop and return the response
-				if !gjson.GetBytes(responseBytes, step.Condition).Exists() {
-					return responseBytes, 200, nil
+				if !matched {
+					return response, 200, nil
 				}
 			}
-			if responseBytes, statusCode, err = executeStep(step, graph, request, headers); err != nil {
-				return nil, 500, err
+			ctx := template.Context{Request: input.body, Response: input.body, Steps: stepOutputs}
+			if i > 0 {
+				ctx.Response = response.body
+			}
+			// only the response of the last step is returned as is, the others are fed to the next steps
+			var stepStream *responseStream
+			if i == len(currentNode.Steps)-1 {
+				stepStream = stream
+			}
+			if response, statusCode, err = executeNodeStep(nodeName, step, graph, request, headers, ctx, stepStream); err != nil {
+				return payload{}, 500, err
+			}
+			if step.StepName != "" {
+				stepOutputs[step.StepName] = response.body
 			}
 			/*
 			   Only if a step is a hard dependency, we will check for its success.
@@ -369,15 +431,15 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 				if !isSuccessFul(statusCode) {
 					log.Info("This step is a hard dependency and it is unsuccessful", "stepName", step.StepName, "statusCode", statusCode)
 					// Stop the execution of sequence right away if step is a hard dependency and is unsuccessful
-					return responseBytes, statusCode, nil
+					return response, statusCode, nil
 				}
 			}
 		}
 
-		return responseBytes, statusCode, nil
+		return response, statusCode, nil
 	}
 	log.Error(nil, "invalid route type", "type", currentNode.RouterType)
-	return nil, 500, fmt.Errorf("invalid route type: %v", currentNode.RouterType)
+	return payload{}, 500, fmt.Errorf("invalid route type: %v", currentNode.RouterType)
 }
 
 func isSuccessFul(statusCode int) bool {
@@ -387,12 +449,26 @@ func isSuccessFul(statusCode int) bool {
 	return false
 }
 
-func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
-	if step.NodeName != "" {
+func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, stream *responseStream) (payload, int, error) {
+	// the timeout is validated by the webhook
+	timeout, _ := time.ParseDuration(step.Timeout)
+	response, statusCode, err := executeStepWithRetries(step, graph, input, headers, timeout, stream)
+	if step.Fallback != nil && !stream.started() && (err != nil || !isSuccessFul(statusCode)) {
+		log.Info("Step failed, running its fallback", "stepName", step.StepName, "statusCode", statusCode, "error", err)
+		return executeTarget(step.Fallback, graph, input, headers, timeout, stream)
+	}
+	return response, statusCode, err
+}
+
+func executeTarget(target *v1alpha1.InferenceTarget, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, timeout time.Duration, stream *responseStream) (payload, int, error) {
+	if target.NodeName != "" {
 		// when nodeName is specified make a recursive call for routing to next step
-		return routeStep(step.NodeName, graph, input, headers)
+		return routeStepWithTimeout(target.NodeName, graph, input, headers, timeout, stream)
 	}
-	return callService(step.ServiceURL, input, headers)
+	if target.Protocol == constants.ProtocolGRPCV2 {
+		return callGrpcServiceWithTimeout(target.ServiceURL, input, headers, timeout)
+	}
+	return callServiceWithTimeout(target.ServiceURL, input, headers, timeout, stream)
 }
 
 func prepareErrorResponse(err error, errorMessage string) []byte {
@@ -409,7 +485,21 @@ func prepareErrorResponse(err error, errorMessage string) []byte {
 
 func graphHandler(w http.ResponseWriter, req *http.Request) {
 	inputBytes, _ := io.ReadAll(req.Body)
-	if response, statusCode, err := routeStep(v1alpha1.GraphRootNodeName, *inferenceGraph, inputBytes, req.Header); err != nil {
+	stream := &responseStream{w: w}
+	if routerTimeouts != nil && routerTimeouts.ServerWrite != nil {
+		stream.writeTimeout = time.Duration(*routerTimeouts.ServerWrite) * time.Second
+	}
+	// the request is a binary tensor payload when the client gives the length of its JSON header
+	input := newPayload(inputBytes, req.Header)
+	response, statusCode, err := routeStepToStream(v1alpha1.GraphRootNodeName, *inferenceGraph, input, req.Header, stream)
+	if stream.started() {
+		// the response has already been sent to the client
+		if err != nil {
+			log.Error(err, "failed to stream the response")
+		}
+		return
+	}
+	if err != nil {
 		log.Error(err, "failed to process request")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(statusCode)
@@ -417,11 +507,14 @@ func graphHandler(w http.ResponseWriter, req *http.Request) {
 			log.Error(err, "failed to write graphHandler response")
 		}
 	} else {
-		if json.Valid(response) {
+		if response.isBinary() {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set(InferenceHeaderContentLength, strconv.Itoa(response.headerLength))
+		} else if json.Valid(response.body) {
 			w.Header().Set("Content-Type", "application/json")
 		}
 		w.WriteHeader(statusCode)
-		if _, err := w.Write(response); err != nil {
+		if _, err := w.Write(response.body); err != nil {
 			log.Error(err, "failed to write graphHandler response")
 		}
 	}
@@ -485,6 +578,8 @@ var (
 	enableAuthFlag                                      = flag.Bool("enable-auth", false, "protect the inference graph with authorization")
 	graphName                                           = flag.String("inferencegraph-name", "", "the name of the associated inference graph Kubernetes resource")
 	jsonGraph                                           = flag.String("graph-json", "", "serialized json graph def")
+	enableTracingFlag                                   = flag.Bool("enable-tracing", false, "export the trace spans of the nodes and steps, as configured by the OTEL_* env vars")
+	enableMetricsFlag                                   = flag.Bool("enable-metrics", false, "expose the Prometheus metrics of the nodes and steps on their own port")
 	inferenceGraph         *v1alpha1.InferenceGraphSpec = nil
 	compiledHeaderPatterns []*regexp.Regexp
 	isShuttingDown                                               = false
@@ -610,13 +705,19 @@ func checkRequestIsAuthorized(ctx context.Context, w http.ResponseWriter, _ *htt
 // It expects that a Bearer token is provided in the request in the standard HTTP Authorization
 // header. The token is verified against Kubernetes using the TokenReview and SubjectAccessReview APIs.
 // If the token is valid and has enough privileges, the handler provided in the `next` argument is run.
-// Otherwise, `next` is not invoked and the reason for the rejection is sent in response headers.
+// Otherwise, `next` is not invoked and the reason for the rejection is sent in response headers,
+// or as the gRPC status of the gRPC requests.
 func authMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rejectWriter := w
+		if isGrpcRequest(r) {
+			rejectWriter = grpcStatusWriter{w}
+		}
+
 		k8sConfig, k8sConfigErr := rest.InClusterConfig()
 		if k8sConfigErr != nil {
 			log.Error(k8sConfigErr, "failed to create rest configuration to connect to Kubernetes API")
-			w.WriteHeader(http.StatusInternalServerError)
+			rejectWriter.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
@@ -626,17 +727,17 @@ func authMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		token := findBearerToken(w, r)
+		token := findBearerToken(rejectWriter, r)
 		if len(token) == 0 {
 			return
 		}
 
-		tokenReviewResult := validateTokenIsAuthenticated(r.Context(), w, token, clientset)
+		tokenReviewResult := validateTokenIsAuthenticated(r.Context(), rejectWriter, token, clientset)
 		if tokenReviewResult == nil {
 			return
 		}
 
-		isAuthorized := checkRequestIsAuthorized(r.Context(), w, r, tokenReviewResult, clientset)
+		isAuthorized := checkRequestIsAuthorized(r.Context(), rejectWriter, r, tokenReviewResult, clientset)
 		if isAuthorized {
 			next.ServeHTTP(w, r)
 		}
@@ -664,8 +765,26 @@ func main() {
 	}
 	initTimeouts(*inferenceGraph)
 
+	if *enableTracingFlag {
+		shutdownTracing, err := initTracing(context.Background())
+		if err != nil {
+			log.Error(err, "failed to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				log.Error(err, "Failed to flush the trace spans")
+			}
+		}()
+		log.Info("This Router has tracing enabled")
+	}
+
+	// the gRPC requests are served on the same port as the REST requests
+	grpcServer := grpc.NewServer()
+	inference.RegisterGRPCInferenceServiceServer(grpcServer, &grpcIngressServer{})
+
 	var entrypointHandler http.Handler
-	entrypointHandler = http.HandlerFunc(graphHandler)
+	entrypointHandler = grpcHandler(grpcServer, http.HandlerFunc(graphHandler))
 	if *enableAuthFlag {
 		entrypointHandler = authMiddleware(entrypointHandler)
 		log.Info("This Router has authorization enabled")
@@ -674,13 +793,28 @@ func main() {
 	http.HandleFunc(constants.RouterReadinessEndpoint, readyHandler)
 	http.Handle("/", entrypointHandler)
 
+	if *enableMetricsFlag {
+		metricsServer := newMetricsServer()
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error(err, fmt.Sprintf("Failed to serve the metrics on address %v", metricsServer.Addr))
+				os.Exit(1)
+			}
+		}()
+	}
+
 	server := &http.Server{
 		Addr:         ":" + strconv.Itoa(constants.RouterPort),
 		Handler:      nil,                                                      // default server mux
 		ReadTimeout:  time.Duration(*routerTimeouts.ServerRead) * time.Second,  // set the maximum duration for reading the entire request, including the body
 		WriteTimeout: time.Duration(*routerTimeouts.ServerWrite) * time.Second, // set the maximum duration before timing out writes of the response
 		IdleTimeout:  time.Duration(*routerTimeouts.ServerIdle) * time.Second,  // set the maximum amount of time to wait for the next request when keep-alives are enabled
+		Protocols:    new(http.Protocols),
 	}
+	// gRPC needs HTTP/2, which the clients use without TLS by prior knowledge
+	server.Protocols.SetHTTP1(true)
+	server.Protocols.SetHTTP2(true)
+	server.Protocols.SetUnencryptedHTTP2(true)
 
 	go func() {
 		if *enableTlsFlag {
@@ -698,6 +832,18 @@ func main() {
 	handleSignals(server)
 }
 
+// newMetricsServer returns the server of the Prometheus metrics, on its own port so that they are
+// neither behind the authorization of the graph nor exposed by the router service.
+func newMetricsServer() *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(constants.DefaultPrometheusPath, promhttp.Handler())
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(constants.RouterMetricsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: time.Duration(*routerTimeouts.ServerRead) * time.Second,
+	}
+}
+
 func handleSignals(server *http.Server) {
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
 
@@ -143,12 +143,12 @@ func TestSimpleModelChainer(t *testing.T) {
 		"Authorization": {"Bearer Token"},
 	}
 
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -233,12 +233,12 @@ func TestSimpleModelEnsemble(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -445,12 +445,12 @@ func TestInferenceGraphWithCondition(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -541,7 +541,7 @@ func TestInferenceGraphSequenceWithUnmetCondition(t *testing.T) {
 	}
 	jsonBytes, _ := json.Marshal(input)
 	headers := http.Header{}
-	res, statusCode, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, statusCode, err := routeStep("root", graphSpec, payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
@@ -550,7 +550,7 @@ func TestInferenceGraphSequenceWithUnmetCondition(t *testing.T) {
 	assert.Equal(t, http.StatusOK, statusCode)
 
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -615,12 +615,12 @@ func TestCallServiceWhenNoneHeadersToPropagateIsEmpty(t *testing.T) {
 	}
 	// Propagating no header
 	compiledHeaderPatterns = []*regexp.Regexp{}
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("callService failed: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -681,11 +681,11 @@ func TestCallServiceWhen1HeaderToPropagate(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	require.NoError(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), payload{body: jsonBytes}, headers)
 	require.NoError(t, err)
 
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	require.NoError(t, err)
 
 	expectedResponse := map[string]interface{}{
@@ -746,12 +746,12 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	require.NoError(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("callService failed: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -766,7 +766,7 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 
 func TestMalformedURL(t *testing.T) {
 	malformedURL := "http://single-1.default.{$your-domain}/switch"
-	_, response, err := callService(malformedURL, []byte{}, http.Header{})
+	_, response, err := callService(malformedURL, payload{body: []byte{}}, http.Header{})
 	require.Error(t, err)
 	require.Equal(t, 500, response)
 }
@@ -823,12 +823,12 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingPatterns(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	require.NoError(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("callService failed: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -895,12 +895,12 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingInvalidPattern(t *testing
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	require.Error(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(model1Url.String(), payload{body: jsonBytes}, headers)
 	if err != nil {
 		t.Fatalf("callService failed: %v", err)
 	}
 	var response map[string]interface{}
-	err = json.Unmarshal(res, &response)
+	err = json.Unmarshal(res.body, &response)
 	if err != nil {
 		t.Fatalf("json.Unmarshal failed: %v", err)
 	}
@@ -1056,7 +1056,7 @@ func TestServerTimeout(t *testing.T) {
 	}
 }
 
-func TestPickupRouteNeverReturnsNil(t *testing.T) {
+func TestRandomRouteNeverReturnsNoRoute(t *testing.T) {
 	w1, w2 := int64(20), int64(80)
 	routes := []v1alpha1.InferenceStep{
 		{
@@ -1076,12 +1076,12 @@ func TestPickupRouteNeverReturnsNil(t *testing.T) {
 	}
 
 	for i := range 10000 {
-		route := pickupRoute(routes)
-		require.NotNil(t, route, "pickupRoute returned nil on iteration %d", i)
+		route := randomRoute(routes)
+		require.GreaterOrEqual(t, route, 0, "randomRoute returned no route on iteration %d", i)
 	}
 }
 
-func TestPickupRouteAlwaysReturnsRouteForAllRandValues(t *testing.T) {
+func TestRandomRouteAlwaysReturnsRouteForAllRandValues(t *testing.T) {
 	w1, w2 := int64(30), int64(70)
 	routes := []v1alpha1.InferenceStep{
 		{
@@ -1101,8 +1101,8 @@ func TestPickupRouteAlwaysReturnsRouteForAllRandValues(t *testing.T) {
 	}
 
 	for i := range 10000 {
-		route := pickupRoute(routes)
-		require.NotNil(t, route, "pickupRoute returned nil on iteration %d", i)
+		route := randomRoute(routes)
+		require.GreaterOrEqual(t, route, 0, "randomRoute returned no route on iteration %d", i)
 	}
 }
 
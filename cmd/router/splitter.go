@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/rand"
+	"hash/fnv"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// pickupSplitterRoute returns the index of the step of the Splitter node which the request is
+// routed to: the step pinned by the splitter step header, or else the step chosen by the hash of
+// the sticky key of the request, or else a random step, or -1 when no step is chosen.
+func pickupSplitterRoute(nodeName string, node v1alpha1.InferenceRouter, input payload, headers http.Header) int {
+	if i, ok := pinnedRoute(nodeName, node.Steps, headers); ok {
+		log.Info("Routing the request to the pinned step", "nodeName", nodeName, "step", splitterBranch(node.Steps, i))
+		return i
+	}
+	if key := stickyKey(node.Sticky, input, headers); key != "" {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(key))
+		return routeAt(node.Steps, int(hash.Sum64()%100)) // #nosec G115
+	}
+	return randomRoute(node.Steps)
+}
+
+// pinnedRoute returns the index of the step of the node pinned by the splitter step header.
+func pinnedRoute(nodeName string, routes []v1alpha1.InferenceStep, headers http.Header) (int, bool) {
+	for _, value := range headers.Values(constants.RouterSplitterStepHeader) {
+		for _, pin := range strings.Split(value, ",") {
+			node, branch, found := strings.Cut(strings.TrimSpace(pin), "=")
+			if !found || node != nodeName {
+				continue
+			}
+			for i := range routes {
+				if splitterBranch(routes, i) == branch {
+					return i, true
+				}
+			}
+		}
+	}
+	return -1, false
+}
+
+// stickyKey returns the key of the request which the node routes consistently, or an empty
+// string when the node is not sticky or the request has no key.
+func stickyKey(sticky *v1alpha1.SplitterStickiness, input payload, headers http.Header) string {
+	switch {
+	case sticky == nil:
+		return ""
+	case sticky.Header != "":
+		return headers.Get(sticky.Header)
+	default:
+		// the field of binary tensor payloads is read from their JSON header
+		return gjson.GetBytes(input.jsonHeader(), sticky.Field).String()
+	}
+}
+
+func randomRoute(routes []v1alpha1.InferenceStep) int {
+	randomNumber, err := rand.Int(rand.Reader, big.NewInt(100))
+	if err != nil {
+		panic(err)
+	}
+	return routeAt(routes, int(randomNumber.Int64()))
+}
+
+// routeAt returns the index of the step whose range of weights contains the point, between 0 and 99.
+func routeAt(routes []v1alpha1.InferenceStep, point int) int {
+	end := 0
+	for i, route := range routes {
+		end += int(*route.Weight)
+		if point < end {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitterBranch names the step of a Splitter node in the splitter step header, by its name or
+// else by its index.
+func splitterBranch(routes []v1alpha1.InferenceStep, i int) string {
+	if routes[i].StepName != "" {
+		return routes[i].StepName
+	}
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// splitterRouter returns a router serving a Splitter root node, which routes evenly to the
+// stable and canary steps and to an unnamed step.
+func splitterRouter(t *testing.T, sticky *v1alpha1.SplitterStickiness) string {
+	previous := inferenceGraph
+	t.Cleanup(func() { inferenceGraph = previous })
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Splitter,
+				Sticky:     sticky,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "stable",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: modelServer(t, `{"predictions": ["stable"]}`, http.StatusOK, 0)},
+						Weight:          ptr.To(int64(34)),
+					},
+					{
+						StepName:        "canary",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: modelServer(t, `{"predictions": ["canary"]}`, http.StatusOK, 0)},
+						Weight:          ptr.To(int64(33)),
+					},
+					{
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: modelServer(t, `{"predictions": ["unnamed"]}`, http.StatusOK, 0)},
+						Weight:          ptr.To(int64(33)),
+					},
+				},
+			},
+		},
+	}
+	router := httptest.NewServer(http.HandlerFunc(graphHandler))
+	t.Cleanup(router.Close)
+	return router.URL
+}
+
+// postToSplitter returns the chosen step header and the response of the router.
+func postToSplitter(t *testing.T, routerUrl string, body string, headers http.Header) (string, string) {
+	req, err := http.NewRequest(http.MethodPost, routerUrl, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header = headers
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	response, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	return res.Header.Get(constants.RouterSplitterStepHeader), string(response)
+}
+
+func TestStickySplitter(t *testing.T) {
+	scenarios := map[string]struct {
+		sticky  *v1alpha1.SplitterStickiness
+		request func(user int) (string, http.Header)
+	}{
+		"header key": {
+			sticky: &v1alpha1.SplitterStickiness{Header: "X-User-Id"},
+			request: func(user int) (string, http.Header) {
+				return `{}`, http.Header{"X-User-Id": {fmt.Sprintf("user-%d", user)}}
+			},
+		},
+		"body field key": {
+			sticky: &v1alpha1.SplitterStickiness{Field: "user.id"},
+			request: func(user int) (string, http.Header) {
+				return fmt.Sprintf(`{"user": {"id": %d}}`, user), http.Header{}
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			routerUrl := splitterRouter(t, scenario.sticky)
+			responses := map[string]string{
+				"root=stable": `{"predictions": ["stable"]}`,
+				"root=canary": `{"predictions": ["canary"]}`,
+				"root=2":      `{"predictions": ["unnamed"]}`,
+			}
+			chosen := map[string]int{}
+			for user := range 30 {
+				body, headers := scenario.request(user)
+				step, response := postToSplitter(t, routerUrl, body, headers)
+				assert.JSONEq(t, responses[step], response)
+				// the requests with the same key are routed to the same step
+				for range 3 {
+					again, _ := postToSplitter(t, routerUrl, body, headers)
+					assert.Equal(t, step, again)
+				}
+				chosen[step]++
+			}
+			assert.Len(t, chosen, 3)
+		})
+	}
+}
+
+func TestPinnedSplitterStep(t *testing.T) {
+	routerUrl := splitterRouter(t, &v1alpha1.SplitterStickiness{Header: "X-User-Id"})
+	for user := range 10 {
+		headers := http.Header{
+			"X-User-Id":                        {fmt.Sprintf("user-%d", user)},
+			constants.RouterSplitterStepHeader: {"other=stable, root=canary"},
+		}
+		step, response := postToSplitter(t, routerUrl, `{}`, headers)
+		assert.Equal(t, "root=canary", step)
+		assert.JSONEq(t, `{"predictions": ["canary"]}`, response)
+	}
+
+	// the unnamed steps are pinned by their index
+	step, response := postToSplitter(t, routerUrl, `{}`, http.Header{constants.RouterSplitterStepHeader: {"root=2"}})
+	assert.Equal(t, "root=2", step)
+	assert.JSONEq(t, `{"predictions": ["unnamed"]}`, response)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	defaultStepRetryBackoff = 100 * time.Millisecond
+	maxStepRetryBackoff     = 10 * time.Second
+)
+
+// defaultRetryableStatusCodes are retried when a step retry policy does not list its own status codes
+var defaultRetryableStatusCodes = []int32{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+type stepResult struct {
+	response   payload
+	statusCode int
+	err        error
+}
+
+// executeStepWithRetries runs the step target until it succeeds or its retry policy is exhausted.
+// The failed calls are always retried, and the responses only when their status code is retryable.
+// The wait between two attempts starts from the policy backoff and doubles with every retry.
+func executeStepWithRetries(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, timeout time.Duration, stream *responseStream) (payload, int, error) {
+	attempts := 1
+	backoff := defaultStepRetryBackoff
+	retryableStatusCodes := defaultRetryableStatusCodes
+	if retry := step.Retry; retry != nil {
+		if retry.Attempts != nil {
+			attempts = int(*retry.Attempts)
+		}
+		// the backoff is validated by the webhook
+		if d, err := time.ParseDuration(retry.Backoff); err == nil && d > 0 {
+			backoff = d
+		}
+		if len(retry.RetryableStatusCodes) > 0 {
+			retryableStatusCodes = retry.RetryableStatusCodes
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		response, statusCode, err := executeTarget(&step.InferenceTarget, graph, input, headers, timeout, stream)
+		// a response which has started to be streamed cannot be retried
+		if attempt >= attempts || stream.started() || (err == nil && !slices.Contains(retryableStatusCodes, int32(statusCode))) { // #nosec G115
+			return response, statusCode, err
+		}
+		log.Info("Retrying step", "stepName", step.StepName, "attempt", attempt, "statusCode", statusCode, "error", err, "backoff", backoff)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxStepRetryBackoff)
+	}
+}
+
+// routeStepWithTimeout routes the request to the node, and gives up on the node once the
+// timeout has passed, when it is set. The nodes with a timeout do not stream their response,
+// which could otherwise still be written after the node has been given up.
+func routeStepWithTimeout(nodeName string, graph v1alpha1.InferenceGraphSpec, input payload, headers http.Header, timeout time.Duration, stream *responseStream) (payload, int, error) {
+	if timeout <= 0 {
+		return routeStepToStream(nodeName, graph, input, headers, stream)
+	}
+	resultChan := make(chan stepResult, 1)
+	go func() {
+		response, statusCode, err := routeStep(nodeName, graph, input, headers)
+		resultChan <- stepResult{response, statusCode, err}
+	}()
+	select {
+	case result := <-resultChan:
+		return result.response, result.statusCode, result.err
+	case <-time.After(timeout):
+		err := fmt.Errorf("node %s timed out after %s", nodeName, timeout)
+		log.Error(err, "step timed out", "nodeName", nodeName)
+		return payload{}, http.StatusGatewayTimeout, err
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestStepRetriedOnRetryableStatus(t *testing.T) {
+	var calls atomic.Int32
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) < 3 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions":[1]}`))
+	}))
+	defer model.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "model",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+						Retry: &v1alpha1.InferenceStepRetry{
+							Attempts: proto.Int32(3),
+							Backoff:  "1ms",
+						},
+					},
+				},
+			},
+		},
+	}
+	res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"instances":[1]}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"predictions":[1]}`, string(res.body))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestStepNotRetriedOnOtherStatus(t *testing.T) {
+	var calls atomic.Int32
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		rw.WriteHeader(http.StatusBadRequest)
+	}))
+	defer model.Close()
+
+	step := &v1alpha1.InferenceStep{
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Retry: &v1alpha1.InferenceStepRetry{
+			Attempts: proto.Int32(3),
+			Backoff:  "1ms",
+		},
+	}
+	_, statusCode, err := executeStep(step, v1alpha1.InferenceGraphSpec{}, payload{body: []byte(`{}`)}, http.Header{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// the status code is retryable when listed by the retry policy
+	step.Retry.RetryableStatusCodes = []int32{http.StatusBadRequest}
+	_, statusCode, err = executeStep(step, v1alpha1.InferenceGraphSpec{}, payload{body: []byte(`{}`)}, http.Header{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestStepFallback(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"predictions":["fallback"]}`))
+	}))
+	defer fallback.Close()
+
+	scenarios := map[string]*v1alpha1.InferenceTarget{
+		"service": {ServiceURL: fallback.URL},
+		"node":    {NodeName: "fallback"},
+	}
+	for name, target := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			graphSpec := v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{
+					"root": {
+						RouterType: v1alpha1.Ensemble,
+						Steps: []v1alpha1.InferenceStep{
+							{
+								StepName:        "model",
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: failing.URL},
+								Fallback:        target,
+							},
+						},
+					},
+					"fallback": {
+						RouterType: v1alpha1.Sequence,
+						Steps: []v1alpha1.InferenceStep{
+							{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: fallback.URL}},
+						},
+					},
+				},
+			}
+			res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"instances":[1]}`)}, http.Header{})
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+			assert.JSONEq(t, `{"model":{"predictions":["fallback"]}}`, string(res.body))
+		})
+	}
+}
+
+func TestStepTimeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-req.Context().Done():
+		}
+		_, _ = rw.Write([]byte(`{"predictions":["slow"]}`))
+	}))
+	defer slow.Close()
+
+	scenarios := map[string]v1alpha1.InferenceTarget{
+		"service": {ServiceURL: slow.URL},
+		"node":    {NodeName: "slow"},
+	}
+	for name, target := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			graphSpec := v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{
+					"root": {
+						RouterType: v1alpha1.Sequence,
+						Steps: []v1alpha1.InferenceStep{
+							{
+								StepName:        "model",
+								InferenceTarget: target,
+								Timeout:         "50ms",
+							},
+						},
+					},
+					"slow": {
+						RouterType: v1alpha1.Sequence,
+						Steps: []v1alpha1.InferenceStep{
+							{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: slow.URL}},
+						},
+					},
+				},
+			}
+			start := time.Now()
+			_, _, err := routeStep("root", graphSpec, payload{body: []byte(`{"instances":[1]}`)}, http.Header{})
+			require.Error(t, err)
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
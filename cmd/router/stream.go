@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// streamedHeaders are the headers of a streaming step response which are passed on to the client.
+var streamedHeaders = []string{"Content-Type", "Cache-Control"}
+
+// responseStream lets the step producing the response of the graph stream it to the client as
+// it arrives, instead of returning it to the router once it is complete. It is only passed down
+// to the steps whose response is returned as is, which are the last step of a Sequence node and
+// the chosen step of a Splitter or Switch node.
+type responseStream struct {
+	w http.ResponseWriter
+	// writeTimeout is the write timeout of the server, which a stream is given to write every chunk
+	// instead of the whole response. A stream without a timeout clears the deadline of the server.
+	writeTimeout time.Duration
+	streamed     bool
+}
+
+// started returns whether a response has been streamed to the client, after which the router
+// can no longer answer the client.
+func (s *responseStream) started() bool {
+	return s != nil && s.streamed
+}
+
+// addHeader adds the header to the response of the client, unless it has already been sent.
+func (s *responseStream) addHeader(name, value string) {
+	if s != nil && !s.streamed {
+		s.w.Header().Add(name, value)
+	}
+}
+
+// isStreamingResponse returns whether the response is streamed as server-sent events. The other
+// responses are buffered, even when they are chunked, since they may be the input of another step.
+func isStreamingResponse(resp *http.Response) bool {
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+}
+
+// idleTimeoutReader extends the timer of the request by the timeout every time a chunk of its
+// response is read, so that a stream is only cut off when the service stops sending it.
+type idleTimeoutReader struct {
+	reader  io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+// extendWriteDeadline gives the stream the write timeout from now, since the write deadline of the
+// server would otherwise cut off the streams which last longer than it.
+func (s *responseStream) extendWriteDeadline(controller *http.ResponseController) error {
+	deadline := time.Time{}
+	if s.writeTimeout > 0 {
+		deadline = time.Now().Add(s.writeTimeout)
+	}
+	if err := controller.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// forward copies the response to the client, flushing every chunk as soon as it is read.
+func (s *responseStream) forward(statusCode int, header http.Header, body io.Reader) error {
+	s.streamed = true
+	for _, h := range streamedHeaders {
+		if v := header.Get(h); v != "" {
+			s.w.Header().Set(h, v)
+		}
+	}
+	controller := http.NewResponseController(s.w)
+	if err := s.extendWriteDeadline(controller); err != nil {
+		return err
+	}
+	s.w.WriteHeader(statusCode)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if deadlineErr := s.extendWriteDeadline(controller); deadlineErr != nil {
+				return deadlineErr
+			}
+			if _, writeErr := s.w.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+			if flushErr := controller.Flush(); flushErr != nil && !errors.Is(flushErr, http.ErrNotSupported) {
+				return flushErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// eventStreamServer returns a service streaming the events as server-sent events, waiting for
+// the release channel before sending every event after the first one.
+func eventStreamServer(t *testing.T, release <-chan struct{}, events ...string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.Header().Set("Cache-Control", "no-cache")
+		for i, event := range events {
+			if i > 0 && release != nil {
+				<-release
+			}
+			_, _ = fmt.Fprintf(rw, "data: %s\n\n", event)
+			rw.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestStreamingLastSequenceStep(t *testing.T) {
+	preprocess := modelServer(t, `{"instances": [1]}`, http.StatusOK, 0)
+	release := make(chan struct{})
+	llm := eventStreamServer(t, release, `{"token": "Hello"}`, `{"token": "world"}`)
+
+	previous := inferenceGraph
+	defer func() { inferenceGraph = previous }()
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "preprocess", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: preprocess}},
+					{StepName: "llm", InferenceTarget: v1alpha1.InferenceTarget{NodeName: "llm"}, Data: "$response"},
+				},
+			},
+			"llm": {
+				RouterType: v1alpha1.Switch,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "generate", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: llm}, Condition: "instances"},
+				},
+			},
+		},
+	}
+	router := httptest.NewServer(http.HandlerFunc(graphHandler))
+	defer router.Close()
+
+	res, err := http.Post(router.URL, "application/json", strings.NewReader(`{"prompt": "Hi"}`))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", res.Header.Get("Cache-Control"))
+
+	// the first event reaches the client while the service holds back the second one
+	reader := bufio.NewReader(res.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "data: {\"token\": \"Hello\"}\n", line)
+	close(release)
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "\ndata: {\"token\": \"world\"}\n\n", string(rest))
+}
+
+func TestStreamingIntermediateStepIsBuffered(t *testing.T) {
+	llm := eventStreamServer(t, nil, "first", "second")
+	var postprocessRequest []byte
+	postprocess := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		postprocessRequest, _ = io.ReadAll(req.Body)
+		_, _ = rw.Write([]byte(`{"predictions": ["done"]}`))
+	}))
+	defer postprocess.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "llm", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: llm}},
+					{StepName: "postprocess", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: postprocess.URL}, Data: "$response"},
+				},
+			},
+		},
+	}
+	recorder := httptest.NewRecorder()
+	stream := &responseStream{w: recorder}
+	res, statusCode, err := routeStepToStream("root", graphSpec, payload{body: []byte(`{}`)}, http.Header{}, stream)
+	require.NoError(t, err)
+	assert.False(t, stream.started())
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"predictions": ["done"]}`, string(res.body))
+	assert.Equal(t, "data: first\n\ndata: second\n\n", string(postprocessRequest))
+}
+
+func TestChunkedResponseIsBuffered(t *testing.T) {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		// flushing before the end sends the response in chunks of unknown total length
+		_, _ = rw.Write([]byte(`{"predictions": `))
+		rw.(http.Flusher).Flush()
+		_, _ = rw.Write([]byte(`[1]}`))
+	}))
+	defer model.Close()
+
+	recorder := httptest.NewRecorder()
+	stream := &responseStream{w: recorder}
+	res, statusCode, err := callServiceWithTimeout(model.URL, payload{body: []byte(`{}`)}, http.Header{}, 0, stream)
+	require.NoError(t, err)
+	assert.False(t, stream.started())
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"predictions": [1]}`, string(res.body))
+}
+
+func TestStreamingTimeout(t *testing.T) {
+	// the service sends an event every interval, and stops sending them after the stalled event
+	streamServer := func(interval time.Duration, stalled int) string {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Content-Type", "text/event-stream")
+			for i := range 4 {
+				if i == stalled {
+					<-req.Context().Done()
+					return
+				}
+				_, _ = fmt.Fprintf(rw, "data: %d\n\n", i)
+				rw.(http.Flusher).Flush()
+				time.Sleep(interval)
+			}
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+
+	// the stream lasts longer than the timeout, but every event arrives within it
+	recorder := httptest.NewRecorder()
+	_, statusCode, err := callServiceWithTimeout(streamServer(50*time.Millisecond, -1), payload{body: []byte(`{}`)}, http.Header{}, 200*time.Millisecond, &responseStream{w: recorder})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "data: 0\n\ndata: 1\n\ndata: 2\n\ndata: 3\n\n", recorder.Body.String())
+
+	// the stream is cut off once the service stops sending it for longer than the timeout
+	recorder = httptest.NewRecorder()
+	_, _, err = callServiceWithTimeout(streamServer(0, 1), payload{body: []byte(`{}`)}, http.Header{}, 100*time.Millisecond, &responseStream{w: recorder})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "data: 0\n\n", recorder.Body.String())
+}
+
+func TestStreamingOutlastsServerWriteTimeout(t *testing.T) {
+	// the service sends an event every 50ms, for longer than the write timeout of the router
+	llm := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		for i := range 6 {
+			_, _ = fmt.Fprintf(rw, "data: %d\n\n", i)
+			rw.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer llm.Close()
+
+	router := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		stream := &responseStream{w: w, writeTimeout: 100 * time.Millisecond}
+		_, _, err := callServiceWithTimeout(llm.URL, payload{body: []byte(`{}`)}, http.Header{}, time.Second, stream)
+		assert.NoError(t, err)
+	}))
+	router.Config.WriteTimeout = 100 * time.Millisecond
+	router.Start()
+	defer router.Close()
+
+	resp, err := http.Post(router.URL, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "data: 0\n\ndata: 1\n\ndata: 2\n\ndata: 3\n\ndata: 4\n\ndata: 5\n\n", string(body))
+}
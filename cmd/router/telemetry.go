@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const tracerName = "github.com/kserve/kserve/cmd/router"
+
+var (
+	// tracer creates the spans of the nodes and steps, it is nil when tracing is disabled
+	tracer trace.Tracer
+	// propagator reads and writes the W3C trace context of the requests
+	propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	nodeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_inferencegraph_node_duration_seconds",
+		Help:    "Time taken by a node of the inference graph to respond, by status code",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"node", "router_type", "status_code"})
+	stepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_inferencegraph_step_duration_seconds",
+		Help:    "Time taken by a step of a node of the inference graph to respond, by status code",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"node", "step", "status_code"})
+	splitterBranches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_inferencegraph_splitter_branch_total",
+		Help: "Number of requests a Splitter node routed to each of its steps",
+	}, []string{"node", "step"})
+	switchNoMatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_inferencegraph_switch_no_match_total",
+		Help: "Number of requests a Switch node could not route because none of its steps matched",
+	}, []string{"node"})
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_inferencegraph_step_cache_hits_total",
+		Help: "Number of requests of a cached step answered with a cached response",
+	}, []string{"node", "step"})
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_inferencegraph_step_cache_misses_total",
+		Help: "Number of requests of a cached step with no cached response",
+	}, []string{"node", "step"})
+)
+
+func init() {
+	prometheus.MustRegister(nodeDuration, stepDuration, splitterBranches, switchNoMatches, cacheHits, cacheMisses)
+}
+
+// initTracing sets up the export of the spans, which is configured with the standard OTEL_* env vars,
+// and returns the function flushing the remaining spans on shutdown.
+func initTracing(ctx context.Context) (func(context.Context) error, error) {
+	switch exporter := os.Getenv("OTEL_TRACES_EXPORTER"); exporter {
+	case "", "otlp":
+	case "none":
+		log.Info("Tracing is enabled but the traces exporter is none, no span is exported")
+		return func(context.Context) error { return nil }, nil
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter %q, only otlp is supported", exporter)
+	}
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the sampler and the resource attributes are read from the env vars by the provider
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	tracer = provider.Tracer(tracerName)
+	return provider.Shutdown, nil
+}
+
+// startSpan starts a span as a child of the trace context carried by the headers, and returns it
+// with a copy of the headers carrying the context of the new span, for the calls made within it.
+func startSpan(headers http.Header, name string, attributes ...attribute.KeyValue) (trace.Span, http.Header) {
+	if tracer == nil {
+		return noop.Span{}, headers
+	}
+	ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(headers))
+	ctx, span := tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+	headers = headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(headers))
+	return span, headers
+}
+
+// endSpan records the status code and the error of the node or step on its span and ends it.
+func endSpan(span trace.Span, statusCode int, err error) {
+	span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+	span.End()
+}
+
+// traceHeaders returns the W3C trace context headers carried by the headers, which are sent to
+// every service called by the router whether they match the propagated header patterns or not.
+func traceHeaders(headers http.Header) http.Header {
+	traced := http.Header{}
+	if tracer == nil {
+		return traced
+	}
+	ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(headers))
+	propagator.Inject(ctx, propagation.HeaderCarrier(traced))
+	return traced
+}
+
+// stepLabel names the step in the spans and metrics, by its name or else by its target.
+func stepLabel(step *v1alpha1.InferenceStep) string {
+	switch {
+	case step.StepName != "":
+		return step.StepName
+	case step.NodeName != "":
+		return step.NodeName
+	case step.ServiceName != "":
+		return step.ServiceName
+	default:
+		return step.ServiceURL
+	}
+}
+
+func observeNode(nodeName string, routerType v1alpha1.InferenceRouterType, statusCode int, elapsed time.Duration) {
+	nodeDuration.WithLabelValues(nodeName, string(routerType), strconv.Itoa(statusCode)).Observe(elapsed.Seconds())
+}
+
+func observeStep(nodeName string, step *v1alpha1.InferenceStep, statusCode int, elapsed time.Duration) {
+	stepDuration.WithLabelValues(nodeName, stepLabel(step), strconv.Itoa(statusCode)).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestTracingPropagatesTraceContext(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := tracer
+	defer func() { tracer = previous }()
+	tracer = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)).Tracer(tracerName)
+
+	received := map[string]string{}
+	service := func(name string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			received[name] = req.Header.Get("Traceparent")
+			_, _ = rw.Write([]byte(`{"predictions": [1]}`))
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "first", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: service("first")}},
+					{StepName: "second", InferenceTarget: v1alpha1.InferenceTarget{NodeName: "child"}, Data: "$response"},
+				},
+			},
+			"child": {
+				RouterType: v1alpha1.Switch,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "classify", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: service("classify")}},
+				},
+			},
+		},
+	}
+	traceID, clientSpanID := "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	headers := http.Header{"Traceparent": {fmt.Sprintf("00-%s-%s-01", traceID, clientSpanID)}}
+	_, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{}`)}, headers)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		assert.Equal(t, traceID, span.SpanContext.TraceID().String())
+		spans[span.Name] = span
+	}
+	require.Len(t, spans, 5)
+	parents := map[string]string{
+		"node root":     clientSpanID,
+		"step first":    spans["node root"].SpanContext.SpanID().String(),
+		"step second":   spans["node root"].SpanContext.SpanID().String(),
+		"node child":    spans["step second"].SpanContext.SpanID().String(),
+		"step classify": spans["node child"].SpanContext.SpanID().String(),
+	}
+	for name, parent := range parents {
+		assert.Equal(t, parent, spans[name].Parent.SpanID().String(), name)
+	}
+	// the services are called within the spans of their steps
+	assert.Equal(t, fmt.Sprintf("00-%s-%s-01", traceID, spans["step first"].SpanContext.SpanID()), received["first"])
+	assert.Equal(t, fmt.Sprintf("00-%s-%s-01", traceID, spans["step classify"].SpanContext.SpanID()), received["classify"])
+	// the headers of the request are left as they were received
+	assert.Equal(t, fmt.Sprintf("00-%s-%s-01", traceID, clientSpanID), headers.Get("Traceparent"))
+}
+
+// sampleCount returns the number of observations of the histogram.
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	metric := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestMetrics(t *testing.T) {
+	available := modelServer(t, `{"predictions": [1]}`, http.StatusOK, 0)
+	unavailable := modelServer(t, `{"error": "unavailable"}`, http.StatusServiceUnavailable, 0)
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"metrics-splitter": {
+				RouterType: v1alpha1.Splitter,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "available", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: available}, Weight: ptr.To(int64(100))},
+				},
+			},
+			"metrics-switch": {
+				RouterType: v1alpha1.Switch,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "unavailable", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: unavailable}, Condition: "instances"},
+				},
+			},
+		},
+	}
+	for range 2 {
+		_, statusCode, err := routeStep("metrics-splitter", graphSpec, payload{body: []byte(`{}`)}, http.Header{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	}
+	_, statusCode, err := routeStep("metrics-switch", graphSpec, payload{body: []byte(`{}`)}, http.Header{})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	_, statusCode, err = routeStep("metrics-switch", graphSpec, payload{body: []byte(`{"instances": [1]}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(splitterBranches.WithLabelValues("metrics-splitter", "available")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(switchNoMatches.WithLabelValues("metrics-switch")), 0)
+	assert.Equal(t, uint64(2), sampleCount(t, nodeDuration.WithLabelValues("metrics-splitter", "Splitter", "200")))
+	assert.Equal(t, uint64(2), sampleCount(t, stepDuration.WithLabelValues("metrics-splitter", "available", "200")))
+	assert.Equal(t, uint64(1), sampleCount(t, nodeDuration.WithLabelValues("metrics-switch", "Switch", "404")))
+	assert.Equal(t, uint64(1), sampleCount(t, nodeDuration.WithLabelValues("metrics-switch", "Switch", "503")))
+	assert.Equal(t, uint64(1), sampleCount(t, stepDuration.WithLabelValues("metrics-switch", "unavailable", "503")))
+}
+
+func TestMetricsServer(t *testing.T) {
+	previous := routerTimeouts
+	defer func() { routerTimeouts = previous }()
+	initTimeouts(v1alpha1.InferenceGraphSpec{})
+
+	server := newMetricsServer()
+	assert.Equal(t, fmt.Sprintf(":%d", constants.RouterMetricsPort), server.Addr)
+	recorder := httptest.NewRecorder()
+	server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, constants.DefaultPrometheusPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// the metrics server does not serve the graph
+	recorder = httptest.NewRecorder()
+	server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/inferencegraph/template"
+)
+
+// parsedTemplates caches the parsed step templates by their text
+var parsedTemplates sync.Map
+
+func renderStepTemplate(text string, ctx template.Context) ([]byte, error) {
+	var tmpl *template.Template
+	if cached, ok := parsedTemplates.Load(text); ok {
+		tmpl = cached.(*template.Template)
+	} else {
+		parsed, err := template.Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		parsedTemplates.Store(text, parsed)
+		tmpl = parsed
+	}
+	return tmpl.Render(ctx)
+}
+
+// executeTemplatedStep sends the rendered input template of the step as its request, when set,
+// and returns the rendered output template of the step as its response, when set and the step
+// succeeded. The step response is $response in the output template, so the steps with an output
+// template do not stream their response.
+func executeTemplatedStep(nodeName string, step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, request payload, headers http.Header, ctx template.Context, stream *responseStream) (payload, int, error) {
+	if step.Input != "" {
+		rendered, err := renderStepTemplate(step.Input, ctx)
+		if err != nil {
+			log.Error(err, "failed to render the input template", "stepName", step.StepName)
+			return payload{}, 500, errors.Wrapf(err, "failed to render the input template of step %q", step.StepName)
+		}
+		request = payload{body: rendered}
+	}
+	if step.Output != "" {
+		stream = nil
+	}
+	response, statusCode, err := executeCachedStep(nodeName, step, graph, request, headers, stream)
+	if err != nil || step.Output == "" || !isSuccessFul(statusCode) {
+		return response, statusCode, err
+	}
+	ctx.Response = response.body
+	output, err := renderStepTemplate(step.Output, ctx)
+	if err != nil {
+		log.Error(err, "failed to render the output template", "stepName", step.StepName)
+		return payload{}, 500, errors.Wrapf(err, "failed to render the output template of step %q", step.StepName)
+	}
+	return payload{body: output}, statusCode, nil
+}
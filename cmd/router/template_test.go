@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestSequenceWithStepTemplates(t *testing.T) {
+	classifier := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"predictions": ["cat"], "model_name": "classifier"}`))
+	}))
+	defer classifier.Close()
+	var detectorRequest []byte
+	detector := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		detectorRequest, _ = io.ReadAll(req.Body)
+		_, _ = rw.Write([]byte(`{"predictions": [[0.1, 0.9]]}`))
+	}))
+	defer detector.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "classifier",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: classifier.URL},
+						Output:          `{"labels": {{ $response.predictions }}}`,
+					},
+					{
+						StepName:        "detector",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: detector.URL},
+						Input:           `{"instances": {{ $request.instances }}, "labels": {{ $response.labels }}}`,
+						Output:          `{"label": {{ $steps.classifier.labels.0 }}, "boxes": {{ $response.predictions.0 }}}`,
+					},
+				},
+			},
+		},
+	}
+	res, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"instances": [[1, 2]], "parameters": {}}`)}, http.Header{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.JSONEq(t, `{"instances": [[1, 2]], "labels": ["cat"]}`, string(detectorRequest))
+	assert.JSONEq(t, `{"label": "cat", "boxes": [0.1, 0.9]}`, string(res.body))
+}
+
+func TestStepTemplateErrors(t *testing.T) {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`not json`))
+	}))
+	defer model.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Ensemble,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "model",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+						Output:          `{"predictions": {{ $response.predictions }}}`,
+					},
+				},
+			},
+		},
+	}
+	_, statusCode, err := routeStep("root", graphSpec, payload{body: []byte(`{"instances": [[1, 2]]}`)}, http.Header{})
+	require.ErrorContains(t, err, `failed to render the output template of step "model"`)
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// InferenceHeaderContentLength is the header giving the length of the JSON part of the
+// requests and responses of the binary tensor extension of the Open Inference Protocol,
+// which is followed by the raw tensor data.
+const InferenceHeaderContentLength = "Inference-Header-Content-Length"
+
+// payload is a request or response body, along with the length of its JSON header when it is a
+// binary tensor payload. The length is only known from the Inference-Header-Content-Length header
+// the body is received with, or set by the router for the binary tensor payloads it builds.
+type payload struct {
+	body []byte
+	// headerLength is the length of the JSON header of a binary tensor payload, zero otherwise
+	headerLength int
+}
+
+// newPayload returns the payload of a body received with the headers, which is a binary tensor
+// payload when the headers give the length of its JSON header.
+func newPayload(body []byte, headers http.Header) payload {
+	length, err := strconv.Atoi(headers.Get(InferenceHeaderContentLength))
+	if err != nil || length <= 0 || length > len(body) {
+		return payload{body: body}
+	}
+	return payload{body: body, headerLength: length}
+}
+
+// isBinary returns whether the payload is a binary tensor payload.
+func (p payload) isBinary() bool {
+	return p.headerLength > 0
+}
+
+// jsonHeader returns the JSON part of the payload, which is the whole payload unless it is a binary tensor payload.
+func (p payload) jsonHeader() []byte {
+	return p.body[:p.jsonLength()]
+}
+
+// binaryData returns the raw tensor data of a binary tensor payload, which follows its JSON header.
+func (p payload) binaryData() []byte {
+	return p.body[p.jsonLength():]
+}
+
+func (p payload) jsonLength() int {
+	if p.isBinary() {
+		return p.headerLength
+	}
+	return len(p.body)
+}
+
+// v2Payload holds the fields of the Open Inference Protocol requests and responses used by the router.
+type v2Payload struct {
+	Id         string                     `json:"id,omitempty"`
+	Parameters map[string]json.RawMessage `json:"parameters,omitempty"`
+	Inputs     []map[string]interface{}   `json:"inputs,omitempty"`
+	Outputs    []map[string]interface{}   `json:"outputs,omitempty"`
+}
+
+// binaryPayload returns the payload made of the JSON header followed by the binary data, which is
+// a binary tensor payload unless there is no binary data.
+func binaryPayload(header, data []byte) payload {
+	if len(data) == 0 {
+		return payload{body: header}
+	}
+	return payload{body: append(header, data...), headerLength: len(header)}
+}
+
+// decodeJSON decodes the payload, keeping the numbers as json.Number so that the integers are not rounded.
+func decodeJSON(payload []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// mapOutputsToInputs turns the output tensors of a v2 response into the input tensors of a v2
+// request, renaming the tensors listed in the tensor mapping. The binary tensor data keeps its
+// order, so that it is passed through as is after the new JSON header.
+func mapOutputsToInputs(response payload, tensorMapping map[string]string) (payload, error) {
+	decoded := v2Payload{}
+	if err := decodeJSON(response.jsonHeader(), &decoded); err != nil {
+		return payload{}, err
+	}
+	if len(decoded.Outputs) == 0 {
+		return payload{}, errors.New("no outputs in the response")
+	}
+	request := v2Payload{Id: decoded.Id, Inputs: decoded.Outputs}
+	for _, tensor := range request.Inputs {
+		if name, ok := tensor["name"].(string); ok {
+			if renamed, ok := tensorMapping[name]; ok {
+				tensor["name"] = renamed
+			}
+		}
+	}
+	header, err := json.Marshal(request)
+	if err != nil {
+		return payload{}, err
+	}
+	return binaryPayload(header, response.binaryData()), nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestMapOutputsToInputs(t *testing.T) {
+	response := payload{body: []byte(`{"model_name": "classifier", "id": "1", "outputs": [` +
+		`{"name": "OUTPUT0", "datatype": "FP32", "shape": [1, 2], "data": [0.25, 0.75]},` +
+		`{"name": "OUTPUT1", "datatype": "INT64", "shape": [1], "data": [1]}]}`)}
+	mapped, err := mapOutputsToInputs(response, map[string]string{"OUTPUT0": "input-0"})
+	require.NoError(t, err)
+	assert.False(t, mapped.isBinary())
+	assert.JSONEq(t, `{"id": "1", "inputs": [`+
+		`{"name": "input-0", "datatype": "FP32", "shape": [1, 2], "data": [0.25, 0.75]},`+
+		`{"name": "OUTPUT1", "datatype": "INT64", "shape": [1], "data": [1]}]}`, string(mapped.body))
+
+	_, err = mapOutputsToInputs(payload{body: []byte(`{"predictions": [1]}`)}, nil)
+	require.Error(t, err)
+}
+
+func TestMapLargeInt64OutputsToInputs(t *testing.T) {
+	// 2^53 + 1 is not representable as a float64
+	response := payload{body: []byte(`{"outputs": [{"name": "ids", "datatype": "INT64", "shape": [2], "data": [9007199254740993, -9223372036854775808]}]}`)}
+	mapped, err := mapOutputsToInputs(response, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(mapped.body), `"data":[9007199254740993,-9223372036854775808]`)
+}
+
+func TestMapBinaryOutputsToInputs(t *testing.T) {
+	data := []byte{0x00, 0x00, 0x80, 0x3e, 0x00, 0x00, 0x40, 0x3f}
+	header := `{"outputs": [{"name": "OUTPUT0", "datatype": "FP32", "shape": [1, 2], "parameters": {"binary_data_size": 8}}]}`
+	response := binaryPayload([]byte(header), data)
+	assert.Equal(t, len(header), response.headerLength)
+
+	mapped, err := mapOutputsToInputs(response, map[string]string{"OUTPUT0": "input-0"})
+	require.NoError(t, err)
+	require.True(t, mapped.isBinary())
+	assert.JSONEq(t, `{"inputs": [{"name": "input-0", "datatype": "FP32", "shape": [1, 2], "parameters": {"binary_data_size": 8}}]}`, string(mapped.jsonHeader()))
+	assert.Equal(t, data, mapped.binaryData())
+}
+
+func TestNewPayload(t *testing.T) {
+	body := []byte(`{"inputs": []}` + "\x01\x02")
+	headers := http.Header{}
+	assert.False(t, newPayload(body, headers).isBinary())
+
+	headers.Set(InferenceHeaderContentLength, "14")
+	p := newPayload(body, headers)
+	require.True(t, p.isBinary())
+	assert.Equal(t, `{"inputs": []}`, string(p.jsonHeader()))
+	assert.Equal(t, []byte{0x01, 0x02}, p.binaryData())
+
+	for _, length := range []string{"0", "-1", "17", "invalid"} {
+		headers.Set(InferenceHeaderContentLength, length)
+		assert.False(t, newPayload(body, headers).isBinary(), length)
+	}
+}
+
+func TestV2SequenceWithBinaryTensors(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04}
+	header := `{"model_name": "preprocess", "outputs": [{"name": "OUTPUT0", "datatype": "UINT8", "shape": [4], "parameters": {"binary_data_size": 4}}]}`
+	preprocess := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set(InferenceHeaderContentLength, strconv.Itoa(len(header)))
+		_, _ = rw.Write(binaryPayload([]byte(header), data).body)
+	}))
+	defer preprocess.Close()
+
+	var modelRequest []byte
+	var modelHeaders http.Header
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		modelRequest, _ = io.ReadAll(req.Body)
+		modelHeaders = req.Header
+		rw.Header().Set(InferenceHeaderContentLength, strconv.Itoa(len(header)))
+		_, _ = rw.Write(binaryPayload([]byte(header), data).body)
+	}))
+	defer model.Close()
+
+	previous := inferenceGraph
+	defer func() { inferenceGraph = previous }()
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "preprocess",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: preprocess.URL},
+					},
+					{
+						StepName:           "model",
+						InferenceTarget:    v1alpha1.InferenceTarget{ServiceURL: model.URL},
+						Data:               "$response",
+						MapOutputsToInputs: true,
+						TensorMapping:      map[string]string{"OUTPUT0": "image"},
+					},
+				},
+			},
+		},
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"inputs": [{"name": "raw", "datatype": "BYTES", "shape": [1], "data": ["x"]}]}`)))
+	recorder := httptest.NewRecorder()
+	graphHandler(recorder, request)
+
+	expectedHeader := `{"inputs":[{"datatype":"UINT8","name":"image","parameters":{"binary_data_size":4},"shape":[4]}]}`
+	assert.Equal(t, binaryPayload([]byte(expectedHeader), data).body, modelRequest)
+	assert.Equal(t, strconv.Itoa(len(expectedHeader)), modelHeaders.Get(InferenceHeaderContentLength))
+	assert.Equal(t, "application/octet-stream", modelHeaders.Get("Content-Type"))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/octet-stream", recorder.Header().Get("Content-Type"))
+	length, err := strconv.Atoi(recorder.Header().Get(InferenceHeaderContentLength))
+	require.NoError(t, err)
+	assert.Equal(t, data, recorder.Body.Bytes()[length:])
+}
+
+func TestBinaryPayloadsNeedTheirHeaderLength(t *testing.T) {
+	data := []byte(`{"not": "a header"}`)
+	header := `{"inputs": [{"name": "INPUT0", "datatype": "BYTES", "shape": [1], "parameters": {"binary_data_size": 19}}]}`
+	var stepRequest []byte
+	var stepHeaders http.Header
+	step := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		stepRequest, _ = io.ReadAll(req.Body)
+		stepHeaders = req.Header
+		// the lines of a JSON lines response are not the JSON header of a binary tensor payload
+		_, _ = rw.Write([]byte("{\"line\": 1}\n{\"line\": 2}\n"))
+	}))
+	defer step.Close()
+
+	previous := inferenceGraph
+	defer func() { inferenceGraph = previous }()
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: step.URL}},
+				},
+			},
+		},
+	}
+
+	// the binary data is itself a JSON object, so only the header gives the length of the JSON header
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(binaryPayload([]byte(header), data).body))
+	request.Header.Set(InferenceHeaderContentLength, strconv.Itoa(len(header)))
+	recorder := httptest.NewRecorder()
+	graphHandler(recorder, request)
+
+	assert.Equal(t, binaryPayload([]byte(header), data).body, stepRequest)
+	assert.Equal(t, strconv.Itoa(len(header)), stepHeaders.Get(InferenceHeaderContentLength))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Get(InferenceHeaderContentLength))
+	assert.NotEqual(t, "application/octet-stream", recorder.Header().Get("Content-Type"))
+}
@@ -36,6 +36,15 @@ type Downloader struct {
 	mu        sync.Mutex
 	Providers map[storage.Protocol]storage.Provider
 	Logger    *zap.SugaredLogger
+	// Options configure the downloads of the providers created by the downloader
+	Options storage.DownloadOptions
+}
+
+// successFileContent is the content of the success file of a downloaded model, which is the spec of the model
+// and the digest of its verified files.
+type successFileContent struct {
+	v1alpha1.ModelSpec
+	Digest string `json:"digest,omitempty"`
 }
 
 func (d *Downloader) DownloadModel(modelName string, modelSpec *v1alpha1.ModelSpec) error {
@@ -48,9 +57,14 @@ func (d *Downloader) DownloadModel(modelName string, modelSpec *v1alpha1.ModelSp
 		_, err := os.Stat(successFile)
 		switch {
 		case os.IsNotExist(err):
+			// The partially downloaded files are kept, so that the next attempt resumes from them
 			if err := d.download(modelName, modelSpec.StorageURI); err != nil {
 				return errors.Wrapf(err, "failed to download model")
 			}
+			digest, err := storage.DigestModelDir(filepath.Join(d.ModelDir, modelName))
+			if err != nil {
+				return errors.Wrapf(err, "failed to verify model")
+			}
 			file, createErr := storage.Create(successFile)
 			if createErr != nil {
 				return errors.Wrapf(createErr, "failed to create success file")
@@ -61,7 +75,7 @@ func (d *Downloader) DownloadModel(modelName string, modelSpec *v1alpha1.ModelSp
 					d.Logger.Errorf("Failed to close created file %v", err)
 				}
 			}(file)
-			encodedJson, err := json.Marshal(modelSpec)
+			encodedJson, err := json.Marshal(successFileContent{ModelSpec: *modelSpec, Digest: digest})
 			if err != nil {
 				return errors.Wrapf(err, "failed to encode model spec")
 			}
@@ -69,7 +83,7 @@ func (d *Downloader) DownloadModel(modelName string, modelSpec *v1alpha1.ModelSp
 			if err != nil {
 				return errors.Wrapf(err, "failed to write the success file")
 			}
-			d.Logger.Infof("Creating successFile %s", successFile)
+			d.Logger.Infof("Creating successFile %s with digest %s", successFile, digest)
 		case err == nil:
 			d.Logger.Infof("Model successFile exists already for %s", modelName)
 		default:
@@ -85,7 +99,7 @@ func (d *Downloader) download(modelName string, storageUri string) error {
 		return errors.Wrapf(err, "unsupported protocol")
 	}
 	d.mu.Lock()
-	provider, err := storage.GetProvider(d.Providers, protocol)
+	provider, err := storage.GetProviderWithOptions(d.Providers, protocol, d.Options)
 	d.mu.Unlock()
 	if err != nil {
 		return errors.Wrapf(err, "unable to create or get provider for protocol %s", protocol)
@@ -96,6 +110,15 @@ func (d *Downloader) download(modelName string, storageUri string) error {
 	return nil
 }
 
+// RemovePartialDownload removes the files of a model whose download did not complete.
+func (d *Downloader) RemovePartialDownload(modelName string) error {
+	matches, err := filepath.Glob(filepath.Join(d.ModelDir, modelName, "SUCCESS.*"))
+	if err != nil || len(matches) > 0 {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(d.ModelDir, modelName))
+}
+
 func extractProtocol(storageURI string) (storage.Protocol, error) {
 	if storageURI == "" {
 		return "", errors.New("there is no storageUri supplied")
@@ -105,6 +128,10 @@ func extractProtocol(storageURI string) (storage.Protocol, error) {
 		return "", errors.New("there is no protocol specified for the storageUri")
 	}
 
+	// The Azure Blob Storage URIs are https:// URIs, as for the storage initializer
+	if storage.IsAzureBlobURI(storageURI) {
+		return storage.AZURE, nil
+	}
 	for _, prefix := range storage.SupportedProtocols {
 		if strings.HasPrefix(storageURI, string(prefix)) {
 			return prefix, nil
@@ -17,8 +17,10 @@ limitations under the License.
 package agent
 
 import (
+	"encoding/json"
 	logger "log"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -99,4 +101,61 @@ var _ = Describe("Downloader", func() {
 			Expect(err).Should(HaveOccurred())
 		})
 	})
+
+	Context("When storage uri is an Azure Blob Storage uri", func() {
+		It("Should download the model with the Azure provider", func() {
+			azureProvider := &storage.AzureProvider{Client: mocks.NewMockAzureClient()}
+			Expect(azureProvider.UploadObject("models", "model1/model.joblib", []byte("weights"))).To(Succeed())
+			downloader.Providers[storage.AZURE] = azureProvider
+
+			modelSpec := v1alpha1.ModelSpec{
+				StorageURI: "https://account.blob.core.windows.net/models/model1",
+				Framework:  "sklearn",
+			}
+			err := downloader.DownloadModel("model1", &modelSpec)
+			Expect(err).ShouldNot(HaveOccurred())
+			content, err := os.ReadFile(filepath.Join(downloader.ModelDir, "model1", "model.joblib"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(content)).To(Equal("weights"))
+		})
+	})
+
+	Context("When the model is downloaded", func() {
+		modelSpec := v1alpha1.ModelSpec{
+			StorageURI: "s3://models/model1",
+			Framework:  "sklearn",
+		}
+
+		It("Should record the digest of the model in the success file", func() {
+			err := downloader.DownloadModel("model1", &modelSpec)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			successFile := filepath.Join(downloader.ModelDir, "model1", "SUCCESS."+storage.AsSha256(&modelSpec))
+			content, err := os.ReadFile(successFile)
+			Expect(err).ShouldNot(HaveOccurred())
+			var success map[string]interface{}
+			Expect(json.Unmarshal(content, &success)).To(Succeed())
+			// the digest of the model.pt file served by the mock
+			Expect(success).To(HaveKeyWithValue("digest", "sha256:e09d8de69ce3b211053164ddf97664c296cdca4cea7666607ac6b520e872993f"))
+			Expect(success).To(HaveKeyWithValue("storageUri", modelSpec.StorageURI))
+
+			zapLogger, _ := zap.NewProduction()
+			models, err := SyncModelDir(downloader.ModelDir, zapLogger.Sugar())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(models["model1"].Spec.StorageURI).To(Equal(modelSpec.StorageURI))
+			Expect(models["model1"].Spec.Framework).To(Equal(modelSpec.Framework))
+		})
+
+		It("Should fail when the files do not match the manifest of the model", func() {
+			modelPath := filepath.Join(downloader.ModelDir, "model1")
+			Expect(os.MkdirAll(modelPath, 0o700)).To(Succeed())
+			manifest := "0000000000000000000000000000000000000000000000000000000000000000  model.pt\n"
+			Expect(os.WriteFile(filepath.Join(modelPath, storage.ManifestFileName), []byte(manifest), 0o600)).To(Succeed())
+
+			err := downloader.DownloadModel("model1", &modelSpec)
+			Expect(err).Should(MatchError(storage.ErrChecksumMismatch))
+			successFile := filepath.Join(modelPath, "SUCCESS."+storage.AsSha256(&modelSpec))
+			Expect(storage.FileExists(successFile)).To(BeFalse())
+		})
+	})
 })
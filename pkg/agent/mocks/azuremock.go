@@ -76,6 +76,9 @@ func (m mockAzureClient) DownloadFile(ctx context.Context, bucket string, prefix
 }
 
 func (m mockAzureClient) UploadBuffer(ctx context.Context, bucket string, key string, object []byte, o *azblob.UploadBufferOptions) (azblob.UploadBufferResponse, error) {
+	if _, ok := m.buckets[bucket]; !ok {
+		m.buckets[bucket] = &mockAzureBucket{objects: map[string]*mockAzureObject{}}
+	}
 	m.buckets[bucket].objects[key] = &mockAzureObject{
 		blobItem: &container.BlobItem{
 			Name: &key,
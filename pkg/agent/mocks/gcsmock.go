@@ -19,7 +19,9 @@ package mocks
 import (
 	"bytes"
 	"context"
+	"crypto/md5" // #nosec G501
 	"fmt"
+	"hash/crc32"
 	"strings"
 
 	gstorage "cloud.google.com/go/storage"
@@ -33,8 +35,9 @@ type mockGCSClient struct {
 }
 
 type mockBucket struct {
-	attrs   *gstorage.BucketAttrs
-	objects map[string]*gstorage.ObjectAttrs
+	attrs    *gstorage.BucketAttrs
+	objects  map[string]*gstorage.ObjectAttrs
+	contents map[string][]byte
 }
 
 func NewMockClient() stiface.Client {
@@ -59,7 +62,7 @@ func (b mockBucketHandle) Create(_ context.Context, _ string, attrs *gstorage.Bu
 		attrs = &gstorage.BucketAttrs{}
 	}
 	attrs.Name = b.name
-	b.c.buckets[b.name] = &mockBucket{attrs: attrs, objects: map[string]*gstorage.ObjectAttrs{}}
+	b.c.buckets[b.name] = &mockBucket{attrs: attrs, objects: map[string]*gstorage.ObjectAttrs{}, contents: map[string][]byte{}}
 	return nil
 }
 
@@ -117,20 +120,36 @@ func (o mockObjectHandle) NewReader(context.Context) (stiface.Reader, error) {
 	if !ok {
 		return nil, fmt.Errorf("bucket %q not found", o.bucketName)
 	}
-	contents, ok := bkt.objects[o.name]
+	contents, ok := bkt.contents[o.name]
 	if !ok {
 		return nil, fmt.Errorf("object %q not found in bucket %q", o.name, o.bucketName)
 	}
-	return mockReader{r: bytes.NewReader(contents.MD5)}, nil
+	return mockReader{r: bytes.NewReader(contents)}, nil
+}
+
+func (o mockObjectHandle) NewRangeReader(_ context.Context, offset int64, length int64) (stiface.Reader, error) {
+	bkt, ok := o.c.buckets[o.bucketName]
+	if !ok {
+		return nil, fmt.Errorf("bucket %q not found", o.bucketName)
+	}
+	contents, ok := bkt.contents[o.name]
+	if !ok {
+		return nil, fmt.Errorf("object %q not found in bucket %q", o.name, o.bucketName)
+	}
+	end := int64(len(contents))
+	if length >= 0 {
+		end = min(offset+length, end)
+	}
+	return mockReader{r: bytes.NewReader(contents[offset:end])}, nil
 }
 
 func (o mockObjectHandle) NewWriter(context.Context) stiface.Writer {
 	attrs := &gstorage.ObjectAttrs{
 		Bucket: o.bucketName,
 		Name:   o.name,
-		MD5:    nil,
 	}
 	o.c.buckets[o.bucketName].objects[o.name] = attrs
+	o.c.buckets[o.bucketName].contents[o.name] = nil
 	return &mockWriter{o: o, obj: attrs}
 }
 
@@ -156,6 +175,12 @@ type mockWriter struct {
 
 func (w *mockWriter) Write(data []byte) (int, error) {
 	int, err := w.buf.Write(data)
-	w.obj.MD5 = data
+	contents := w.buf.Bytes()
+	w.o.c.buckets[w.o.bucketName].contents[w.o.name] = contents
+	// the attributes of the object are updated as GCS computes them
+	digest := md5.Sum(contents) // #nosec G401
+	w.obj.MD5 = digest[:]
+	w.obj.CRC32C = crc32.Checksum(contents, crc32.MakeTable(crc32.Castagnoli))
+	w.obj.Size = int64(len(contents))
 	return int, err
 }
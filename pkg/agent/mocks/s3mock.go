@@ -17,9 +17,16 @@ limitations under the License.
 package mocks
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5" // #nosec G501 -- the ETag of the objects uploaded in a single part is their MD5
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
@@ -27,7 +34,44 @@ import (
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
-type MockS3Client struct{}
+// MockS3Content is the content of the objects of the mock S3 clients.
+var MockS3Content = []byte("model")
+
+// MockS3Objects serves the attributes and the ranges of the objects of the mock S3 clients, which all
+// have MockS3Content as content unless GetErr is set.
+type MockS3Objects struct {
+	GetErr error
+}
+
+func (m *MockS3Objects) HeadObject(_ context.Context, _ *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	md5Sum := md5.Sum(MockS3Content) // #nosec G401
+	return &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(int64(len(MockS3Content))),
+		ETag:          aws.String(`"` + hex.EncodeToString(md5Sum[:]) + `"`),
+	}, nil
+}
+
+func (m *MockS3Objects) GetObject(_ context.Context, input *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if m.GetErr != nil {
+		return nil, m.GetErr
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(ObjectRange(MockS3Content, aws.ToString(input.Range))))}, nil
+}
+
+// ObjectRange returns the bytes=<start>-<end> range of an object, or the whole object without range.
+func ObjectRange(content []byte, byteRange string) []byte {
+	start, end, ok := strings.Cut(strings.TrimPrefix(byteRange, "bytes="), "-")
+	if !ok {
+		return content
+	}
+	first, _ := strconv.Atoi(start)
+	last, _ := strconv.Atoi(end)
+	return content[first:min(last+1, len(content))]
+}
+
+type MockS3Client struct {
+	MockS3Objects
+}
 
 func (m *MockS3Client) ListObjectsV2(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
 	return &s3.ListObjectsV2Output{
@@ -39,9 +83,34 @@ func (m *MockS3Client) ListObjectsV2(_ context.Context, _ *s3.ListObjectsV2Input
 	}, nil
 }
 
+// NewMockS3FailClient returns a client whose downloads fail with err.
+func NewMockS3FailClient(err error) *MockS3Client {
+	return &MockS3Client{MockS3Objects{GetErr: err}}
+}
+
+// MockS3FlakyClient fails the first downloads before succeeding.
+type MockS3FlakyClient struct {
+	MockS3Client
+	Failures  int
+	Downloads int
+	mu        sync.Mutex
+}
+
+func (m *MockS3FlakyClient) GetObject(ctx context.Context, input *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	m.mu.Lock()
+	m.Downloads++
+	failed := m.Downloads <= m.Failures
+	m.mu.Unlock()
+	if failed {
+		return nil, errors.New("connection reset")
+	}
+	return m.MockS3Client.GetObject(ctx, input, optFns...)
+}
+
 // MockS3PaginatedClient simulates paginated ListObjectsV2 responses.
 // Pages is a slice of object key slices, one per page.
 type MockS3PaginatedClient struct {
+	MockS3Objects
 	Pages [][]string
 	calls int
 }
@@ -71,6 +140,7 @@ func (m *MockS3PaginatedClient) ListObjectsV2(_ context.Context, input *s3.ListO
 
 // MockS3FailClient returns an error from ListObjectsV2.
 type MockS3FailClient struct {
+	MockS3Objects
 	Err error
 }
 
@@ -83,25 +153,6 @@ func (m *MockS3FailClient) ListObjectsV2(_ context.Context, _ *s3.ListObjectsV2I
 
 type MockS3TransferClient struct{}
 
-func (m *MockS3TransferClient) DownloadObject(_ context.Context, _ *transfermanager.DownloadObjectInput, _ ...func(*transfermanager.Options)) (*transfermanager.DownloadObjectOutput, error) {
-	return &transfermanager.DownloadObjectOutput{}, nil
-}
-
 func (m *MockS3TransferClient) UploadObject(_ context.Context, _ *transfermanager.UploadObjectInput, _ ...func(*transfermanager.Options)) (*transfermanager.UploadObjectOutput, error) {
 	return &transfermanager.UploadObjectOutput{}, nil
 }
-
-type MockS3FailTransferClient struct {
-	Err error
-}
-
-func (m *MockS3FailTransferClient) DownloadObject(_ context.Context, _ *transfermanager.DownloadObjectInput, _ ...func(*transfermanager.Options)) (*transfermanager.DownloadObjectOutput, error) {
-	if m.Err != nil {
-		return nil, m.Err
-	}
-	return nil, errors.New("failed to download")
-}
-
-func (m *MockS3FailTransferClient) UploadObject(_ context.Context, _ *transfermanager.UploadObjectInput, _ ...func(*transfermanager.Options)) (*transfermanager.UploadObjectOutput, error) {
-	return &transfermanager.UploadObjectOutput{}, nil
-}
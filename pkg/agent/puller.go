@@ -20,15 +20,19 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/kserve/kserve/pkg/agent/storage"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/modelconfig"
 )
 
 type OpType string
@@ -38,13 +42,47 @@ const (
 	Remove OpType = "Remove"
 )
 
+const defaultModelServerURL = "http://localhost:8080"
+
+// RetryPolicy is the retry policy of the models which failed to be downloaded or loaded.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts to download and load a model, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled with every retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts.
+	MaxBackoff time.Duration
+}
+
+// Backoff returns the wait before the given retry, counted from 1. The wait is drawn between
+// half and the whole of the exponential backoff, so that the replicas which failed to pull a
+// model together do not retry it at the same time.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.MaxBackoff)
+	half := backoff / 2
+	return half + rand.N(backoff-half+1) // #nosec G404 -- jitter does not need a secure source
+}
+
 type Puller struct {
 	channelMap  map[string]*ModelChannel
 	completions chan *ModelOp
 	opStats     map[string]map[OpType]int
 	waitGroup   WaitGroupWrapper
 	Downloader  *Downloader
-	logger      *zap.SugaredLogger
+	// ModelServerURL is the URL of the model server loading the models, http://localhost:8080 by default
+	ModelServerURL string
+	// Statuses tracks the state of the models, when it is set
+	Statuses *ModelStatusTracker
+	// Retry is the retry policy of the models which failed to be downloaded or loaded, a model is
+	// attempted once when it is not set
+	Retry RetryPolicy
+	// ready is set once the models of the model config found on startup are processed
+	ready  atomic.Bool
+	logger *zap.SugaredLogger
 }
 
 type ModelOp struct {
@@ -58,14 +96,21 @@ type WaitGroupWrapper struct {
 	wg sync.WaitGroup
 }
 
-func StartPullerAndProcessModels(downloader *Downloader, commands <-chan ModelOp, logger *zap.SugaredLogger) {
-	puller := Puller{
-		channelMap:  make(map[string]*ModelChannel),
-		completions: make(chan *ModelOp, 4),
-		opStats:     make(map[string]map[OpType]int),
-		waitGroup:   WaitGroupWrapper{sync.WaitGroup{}},
-		Downloader:  downloader,
-		logger:      logger,
+// StartPullerAndProcessModels starts processing the model ops, the returned puller being ready once
+// the models of the model config found on startup are loaded or failed to be loaded.
+func StartPullerAndProcessModels(downloader *Downloader, modelServerURL string, statuses *ModelStatusTracker, retry RetryPolicy,
+	commands <-chan ModelOp, logger *zap.SugaredLogger,
+) *Puller {
+	puller := &Puller{
+		channelMap:     make(map[string]*ModelChannel),
+		completions:    make(chan *ModelOp, 4),
+		opStats:        make(map[string]map[OpType]int),
+		waitGroup:      WaitGroupWrapper{sync.WaitGroup{}},
+		Downloader:     downloader,
+		ModelServerURL: modelServerURL,
+		Statuses:       statuses,
+		Retry:          retry,
+		logger:         logger,
 	}
 
 	// Change umask to ensure we have control over the downloaded file
@@ -75,7 +120,18 @@ func StartPullerAndProcessModels(downloader *Downloader, commands <-chan ModelOp
 
 	puller.waitGroup.wg.Add(len(commands))
 	go puller.processCommands(commands)
-	puller.waitGroup.wg.Wait()
+	go func() {
+		puller.waitGroup.wg.Wait()
+		logger.Info("Models found on startup are processed")
+		puller.ready.Store(true)
+	}()
+	return puller
+}
+
+// Ready returns true once the models of the model config found on startup are processed, so that
+// a new replica does not receive requests for the models it has not attempted to load yet.
+func (p *Puller) Ready() bool {
+	return p.ready.Load()
 }
 
 func (p *Puller) processCommands(commands <-chan ModelOp) {
@@ -146,78 +202,108 @@ func (p *Puller) modelProcessor(modelName string, ops <-chan *ModelOp) {
 	// this is important for handling Load --> Unload requests sent in tandem
 	// Load --> Unload = 0 (cancel first load)
 	// Load --> Unload --> Load = 1 Load (cancel second load?)
-	processOp := func(modelOp *ModelOp) {
+	// processOp returns the op which was received while a failed Add was waiting for its retry, if any
+	processOp := func(modelOp *ModelOp) *ModelOp {
+		var next *ModelOp
 		switch modelOp.Op {
 		case Add:
-			p.logger.Infof("Downloading model from %s", modelOp.Spec.StorageURI)
-			err := p.Downloader.DownloadModel(modelName, modelOp.Spec)
-			if err != nil {
-				// If there is an error, we will NOT send a request. As such, to know about errors, you will
-				// need to call the error endpoint of the puller
-				p.logger.Errorf("Failed to download model %s with err %v", modelName, err)
-				break
-			}
-			// Load the model onto the model server
-			resp, err := http.Post(fmt.Sprintf("http://localhost:8080/v2/repository/models/%s/load", modelName),
-				"application/json",
-				bytes.NewBufferString("{}"))
-			if err != nil {
-				// handle error
-				p.logger.Errorf("Failed to Load model %s", modelName)
-			} else {
-				defer func() {
-					if resp.Body != nil {
-						closeErr := resp.Body.Close()
-						if closeErr != nil {
-							p.logger.Error(closeErr, "failed to close body")
-						}
-					}
-				}()
-				if resp.StatusCode == http.StatusOK {
-					p.logger.Infof("Successfully loaded model %s", modelName)
-				} else {
-					body, err := io.ReadAll(resp.Body)
-					if err == nil {
-						p.logger.Infof("Failed to load model %s with status [%d] and resp:%s", modelName, resp.StatusCode, string(body))
-					}
-				}
-			}
+			next = p.addModel(modelName, modelOp, ops)
 		case Remove:
 			p.logger.Infof("unloading model %s", modelName)
+			p.Statuses.Remove(modelName)
 			// If there is an error, we will NOT do a delete... that could be problematic
 			if err := storage.RemoveDir(filepath.Join(p.Downloader.ModelDir, modelName)); err != nil {
 				p.logger.Error(err, "failing to delete model directory")
 				break
 			}
 			// unload model from model server
-			resp, err := http.Post(fmt.Sprintf("http://localhost:8080/v2/repository/models/%s/unload", modelName),
-				"application/json",
-				bytes.NewBufferString("{}"))
-			if err != nil {
-				// handle error
-				p.logger.Errorf("Failed to Unload model %s", modelName)
-			} else {
-				if resp != nil {
-					defer func() {
-						closeErr := resp.Body.Close()
-						if closeErr != nil {
-							p.logger.Error(closeErr, "failed to close body")
-						}
-					}()
-				}
-				if resp.StatusCode == http.StatusOK {
-					p.logger.Infof("Successfully unloaded model %s", modelName)
-				} else {
-					body, err := io.ReadAll(resp.Body)
-					if err == nil {
-						p.logger.Infof("Failed to unload model %s with status [%d] and resp:%v", modelName, resp.StatusCode, body)
-					}
-				}
+			if err := p.callModelServer(modelName, "unload"); err != nil {
+				p.logger.Errorf("Failed to unload model %s with err %v", modelName, err)
+				break
 			}
+			p.logger.Infof("Successfully unloaded model %s", modelName)
 		}
 		p.completions <- modelOp
+		return next
 	}
 	for modelOp := range ops {
-		processOp(modelOp)
+		for modelOp != nil {
+			modelOp = processOp(modelOp)
+		}
+	}
+}
+
+// addModel downloads the model and loads it onto the model server. The failed attempts are retried with an
+// exponential backoff until the retry policy is exhausted, or until another op is queued for the model which
+// supersedes this one. The op which interrupted the wait for a retry is returned to be processed next. To know
+// about errors, you will need to call the model status endpoint of the agent.
+func (p *Puller) addModel(modelName string, modelOp *ModelOp, ops <-chan *ModelOp) *ModelOp {
+	maxAttempts := max(p.Retry.MaxAttempts, 1)
+	p.Statuses.Set(modelName, modelconfig.ModelLoading, 1, nil)
+	for attempt := 1; ; attempt++ {
+		err := p.pullModel(modelName, modelOp)
+		if err == nil {
+			p.logger.Infof("Successfully loaded model %s", modelName)
+			p.Statuses.Set(modelName, modelconfig.ModelLoaded, attempt, nil)
+			return nil
+		}
+		if attempt >= maxAttempts {
+			p.logger.Errorf("Failed to pull model %s after %d attempts with err %v", modelName, attempt, err)
+			p.Statuses.Set(modelName, modelconfig.ModelFailed, attempt, err)
+			// The partially downloaded files are only kept for the retries
+			if removeErr := p.Downloader.RemovePartialDownload(modelName); removeErr != nil {
+				p.logger.Errorf("Failed to remove the partial download of model %s: %v", modelName, removeErr)
+			}
+			return nil
+		}
+		backoff := p.Retry.Backoff(attempt)
+		p.logger.Warnf("Failed to pull model %s with err %v, retrying in %s", modelName, err, backoff)
+		p.Statuses.Set(modelName, modelconfig.ModelLoading, attempt, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case next, ok := <-ops:
+			timer.Stop()
+			p.logger.Infof("Stop retrying model %s which has a new op", modelName)
+			if !ok {
+				return nil
+			}
+			return next
+		}
+	}
+}
+
+// pullModel makes a single attempt to download the model and load it onto the model server.
+func (p *Puller) pullModel(modelName string, modelOp *ModelOp) error {
+	p.logger.Infof("Downloading model from %s", modelOp.Spec.StorageURI)
+	if err := p.Downloader.DownloadModel(modelName, modelOp.Spec); err != nil {
+		// If there is an error, we will NOT send a request to the model server
+		return fmt.Errorf("failed to download model: %w", err)
+	}
+	// Load the model onto the model server
+	return p.callModelServer(modelName, "load")
+}
+
+// callModelServer calls the load or unload endpoint of the model repository extension of the model server.
+func (p *Puller) callModelServer(modelName string, action string) error {
+	modelServerURL := p.ModelServerURL
+	if modelServerURL == "" {
+		modelServerURL = defaultModelServerURL
+	}
+	resp, err := http.Post(fmt.Sprintf("%s/v2/repository/models/%s/%s", modelServerURL, modelName, action),
+		"application/json",
+		bytes.NewBufferString("{}"))
+	if err != nil {
+		return fmt.Errorf("failed to %s model: %w", action, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			p.logger.Error(closeErr, "failed to close body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to %s model with status [%d] and resp: %s", action, resp.StatusCode, string(body))
 	}
+	return nil
 }
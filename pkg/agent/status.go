@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/modelconfig"
+)
+
+// ModelStatusTracker keeps the state of the models of the replica, which the model agent serves on
+// its model status endpoint for the TrainedModel controller. A nil tracker tracks nothing.
+type ModelStatusTracker struct {
+	mu     sync.RWMutex
+	models map[string]modelconfig.ModelStatus
+	now    func() time.Time
+}
+
+func NewModelStatusTracker() *ModelStatusTracker {
+	return &ModelStatusTracker{
+		models: map[string]modelconfig.ModelStatus{},
+		now:    time.Now,
+	}
+}
+
+// Set records the state of a model after a number of attempts, and the error which made the last attempt fail.
+func (t *ModelStatusTracker) Set(name string, state modelconfig.ModelState, attempts int, err error) {
+	if t == nil {
+		return
+	}
+	status := modelconfig.ModelStatus{Name: name, State: state, Attempts: attempts}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if existing, ok := t.models[name]; ok && existing.State == status.State {
+		status.LastTransitionTime = existing.LastTransitionTime
+	} else {
+		status.LastTransitionTime = t.now().UTC()
+	}
+	t.models[name] = status
+}
+
+// Remove forgets a model which was unloaded.
+func (t *ModelStatusTracker) Remove(name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.models, name)
+}
+
+// Statuses returns the state of the models by name.
+func (t *ModelStatusTracker) Statuses() modelconfig.ModelStatuses {
+	statuses := modelconfig.ModelStatuses{Models: []modelconfig.ModelStatus{}}
+	if t == nil {
+		return statuses
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, status := range t.models {
+		statuses.Models = append(statuses.Models, status)
+	}
+	sort.Slice(statuses.Models, func(i, j int) bool {
+		return statuses.Models[i].Name < statuses.Models[j].Name
+	})
+	return statuses
+}
+
+func (t *ModelStatusTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t.Statuses())
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+)
+
+var _ = Describe("Model status", func() {
+	var modelDir string
+	var sugar *zap.SugaredLogger
+	var modelServer *httptest.Server
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "status")
+		Expect(err).NotTo(HaveOccurred())
+		modelDir = dir
+		zapLogger, _ := zap.NewProduction()
+		sugar = zapLogger.Sugar()
+		// the model server fails to load model2
+		modelServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2/repository/models/model2/load" {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("out of memory"))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+	AfterEach(func() {
+		modelServer.Close()
+		os.RemoveAll(modelDir)
+	})
+
+	newPuller := func(client storage.S3Client, statuses *ModelStatusTracker, retry RetryPolicy) *Puller {
+		return &Puller{
+			channelMap:  make(map[string]*ModelChannel),
+			completions: make(chan *ModelOp, 4),
+			opStats:     make(map[string]map[OpType]int),
+			waitGroup:   WaitGroupWrapper{sync.WaitGroup{}},
+			Downloader: &Downloader{
+				ModelDir: modelDir,
+				Providers: map[storage.Protocol]storage.Provider{
+					storage.S3: &storage.S3Provider{
+						Client:         client,
+						TransferClient: &mocks.MockS3TransferClient{},
+					},
+				},
+				Logger: sugar,
+			},
+			ModelServerURL: modelServer.URL,
+			Statuses:       statuses,
+			Retry:          retry,
+			logger:         sugar,
+		}
+	}
+	getState := func(statuses *ModelStatusTracker, name string) func() modelconfig.ModelState {
+		return func() modelconfig.ModelState {
+			if status := statuses.Statuses().Get(name); status != nil {
+				return status.State
+			}
+			return ""
+		}
+	}
+	getStatuses := func(statuses *ModelStatusTracker) modelconfig.ModelStatuses {
+		recorder := httptest.NewRecorder()
+		statuses.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, constants.AgentModelStatusPath, nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		result := modelconfig.ModelStatuses{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &result)).To(Succeed())
+		return result
+	}
+	modelConfigs := modelconfig.ModelConfigs{
+		{Name: "model1", Spec: v1alpha1.ModelSpec{StorageURI: "s3://models/model1", Framework: "sklearn"}},
+		{Name: "model2", Spec: v1alpha1.ModelSpec{StorageURI: "s3://models/model2", Framework: "sklearn"}},
+	}
+
+	Context("When models are loaded", func() {
+		It("Should report the loaded and failed models", func() {
+			statuses := NewModelStatusTracker()
+			watcher := NewWatcher("/tmp/configs", modelDir, sugar)
+			puller := newPuller(&mocks.MockS3Client{}, statuses, RetryPolicy{})
+			go puller.processCommands(watcher.ModelEvents)
+			watcher.parseConfig(modelConfigs, false)
+			Eventually(getState(statuses, "model1")).Should(Equal(modelconfig.ModelLoaded))
+			Eventually(getState(statuses, "model2")).Should(Equal(modelconfig.ModelFailed))
+
+			result := getStatuses(statuses)
+			Expect(result.Models).To(HaveLen(2))
+			Expect(result.Get("model1").State).To(Equal(modelconfig.ModelLoaded))
+			Expect(result.Get("model2").State).To(Equal(modelconfig.ModelFailed))
+			Expect(result.Get("model2").Error).To(ContainSubstring("out of memory"))
+
+			watcher.parseConfig(modelConfigs[:1], false)
+			Eventually(getState(statuses, "model2")).Should(BeEmpty())
+			Expect(getStatuses(statuses).Models).To(HaveLen(1))
+		})
+	})
+
+	Context("When model download fails", func() {
+		It("Should report the download error", func() {
+			statuses := NewModelStatusTracker()
+			watcher := NewWatcher("/tmp/configs", modelDir, sugar)
+			retry := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+			puller := newPuller(mocks.NewMockS3FailClient(errors.New("access denied")), statuses, retry)
+			go puller.processCommands(watcher.ModelEvents)
+			watcher.parseConfig(modelConfigs[:1], false)
+			Eventually(getState(statuses, "model1")).Should(Equal(modelconfig.ModelFailed))
+
+			status := getStatuses(statuses).Get("model1")
+			Expect(status.Attempts).To(Equal(3))
+			Expect(status.Error).To(ContainSubstring("failed to download model"))
+			// the partially downloaded files are removed
+			Expect(filepath.Join(modelDir, "model1")).NotTo(BeADirectory())
+		})
+	})
+
+	Context("When model download fails transiently", func() {
+		It("Should retry and load the model", func() {
+			statuses := NewModelStatusTracker()
+			watcher := NewWatcher("/tmp/configs", modelDir, sugar)
+			client := &mocks.MockS3FlakyClient{Failures: 2}
+			retry := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+			puller := newPuller(client, statuses, retry)
+			go puller.processCommands(watcher.ModelEvents)
+			watcher.parseConfig(modelConfigs[:1], false)
+			Eventually(getState(statuses, "model1")).Should(Equal(modelconfig.ModelLoaded))
+
+			status := getStatuses(statuses).Get("model1")
+			Expect(status.Attempts).To(Equal(3))
+			Expect(status.Error).To(BeEmpty())
+			Expect(client.Downloads).To(Equal(3))
+		})
+	})
+
+	Context("When a model is removed while waiting for a retry", func() {
+		It("Should not wait for the backoff to unload it", func() {
+			statuses := NewModelStatusTracker()
+			watcher := NewWatcher("/tmp/configs", modelDir, sugar)
+			retry := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+			puller := newPuller(mocks.NewMockS3FailClient(errors.New("access denied")), statuses, retry)
+			go puller.processCommands(watcher.ModelEvents)
+			watcher.parseConfig(modelConfigs[:1], false)
+			Eventually(func() string {
+				if status := statuses.Statuses().Get("model1"); status != nil {
+					return status.Error
+				}
+				return ""
+			}).Should(ContainSubstring("access denied"))
+
+			watcher.parseConfig(modelconfig.ModelConfigs{}, false)
+			Eventually(getState(statuses, "model1")).Should(BeEmpty())
+		})
+	})
+
+	Context("When models are found on startup", func() {
+		It("Should be ready once they are processed", func() {
+			statuses := NewModelStatusTracker()
+			commands := make(chan ModelOp, 2)
+			for _, modelConfig := range modelConfigs {
+				commands <- ModelOp{OnStartup: true, ModelName: modelConfig.Name, Op: Add, Spec: &modelConfig.Spec}
+			}
+			client := &mocks.MockS3FlakyClient{Failures: 1}
+			retry := RetryPolicy{MaxAttempts: 2, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 200 * time.Millisecond}
+			puller := StartPullerAndProcessModels(newPuller(client, nil, RetryPolicy{}).Downloader, modelServer.URL, statuses, retry, commands, sugar)
+			Expect(puller.Ready()).To(BeFalse())
+			Eventually(puller.Ready).Should(BeTrue())
+			Expect(getStatuses(statuses).Get("model1").State).To(Equal(modelconfig.ModelLoaded))
+			Expect(getStatuses(statuses).Get("model2").State).To(Equal(modelconfig.ModelFailed))
+		})
+	})
+
+	Context("When a model keeps its state", func() {
+		It("Should keep its last transition time", func() {
+			statuses := NewModelStatusTracker()
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			statuses.now = func() time.Time { return now }
+			statuses.Set("model1", modelconfig.ModelLoading, 1, nil)
+			now = now.Add(time.Minute)
+			statuses.Set("model1", modelconfig.ModelLoading, 1, errors.New("connection reset"))
+			Expect(statuses.Statuses().Get("model1").LastTransitionTime).To(Equal(now.Add(-time.Minute)))
+			statuses.Set("model1", modelconfig.ModelLoaded, 2, nil)
+			Expect(statuses.Statuses().Get("model1").LastTransitionTime).To(Equal(now))
+		})
+	})
+
+	Context("When the request is not a GET", func() {
+		It("Should not be allowed", func() {
+			recorder := httptest.NewRecorder()
+			NewModelStatusTracker().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, constants.AgentModelStatusPath, nil))
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+	})
+})
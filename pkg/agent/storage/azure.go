@@ -18,12 +18,18 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 )
 
 type AzureClient interface {
@@ -34,54 +40,132 @@ type AzureClient interface {
 
 type AzureProvider struct {
 	Client AzureClient
+	// Account is the storage account the client is created for, the URIs of the other accounts are rejected
+	// since the client would read the containers of the same name in its own account
+	Account string
+	// Options configure the concurrent downloads of the blobs and of their blocks
+	Options DownloadOptions
 }
 
 var _ Provider = (*AzureProvider)(nil)
 
 func (a AzureProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
 	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
-	uri := strings.TrimPrefix(storageUri, string(HTTPS))
-	tokens := strings.SplitN(uri, "/", 2)
-	prefix := ""
-	if len(tokens) == 2 {
-		prefix = tokens[1]
+	account, bucket, prefix, err := parseAzureURI(storageUri)
+	if err != nil {
+		return err
+	}
+	if a.Account != "" && !strings.EqualFold(account, a.Account) {
+		return fmt.Errorf("storage uri %s is in the account %s, but the model agent is configured for the account %s", storageUri, account, a.Account)
 	}
 	ctx := context.Background()
-	bucket := tokens[0]
 	pager := a.Client.NewListBlobsFlatPager(bucket, &azblob.ListBlobsFlatOptions{
 		Prefix: &prefix,
 	})
-
+	var blobs []*container.BlobItem
 	for pager.More() {
 		resp, err := pager.NextPage(ctx)
 		if err != nil {
 			return err
 		}
-		for _, _blob := range resp.Segment.BlobItems {
-			fileName := filepath.Join(modelDir, modelName, *_blob.Name)
-			log.Info("Downloading blob %s", "fileName", fileName)
-			file, err := os.Create(filepath.Clean(fileName))
-			if err != nil {
-				return err
-			}
-			defer func(destFile *os.File) {
-				err = destFile.Close()
-				log.Error(err, "Error closing file")
-			}(file)
-
-			_, err = a.Client.DownloadFile(ctx, bucket, prefix, file,
-				&azblob.DownloadFileOptions{
-					// If Progress is non-nil, this function is called periodically as bytes are uploaded.
-					Progress: func(bytesTransferred int64) {
-						log.Info("Downloaded %d bytes of object %s", bytesTransferred, prefix)
-					},
-				})
-			if err != nil {
-				return err
+		blobs = append(blobs, resp.Segment.BlobItems...)
+	}
+
+	// The blobs are downloaded concurrently, and the client downloads the blocks of each blob concurrently
+	blobWorkers, blockOptions := a.Options.split(len(blobs))
+	return forEach(ctx, blobWorkers, len(blobs), func(ctx context.Context, i int) error {
+		_blob := blobs[i]
+		fileName := filepath.Join(modelDir, modelName, strings.TrimPrefix(*_blob.Name, prefix))
+		size := int64(-1)
+		checksums := Checksums{}
+		if _blob.Properties != nil {
+			if _blob.Properties.ContentLength != nil {
+				size = *_blob.Properties.ContentLength
 			}
+			checksums.MD5 = _blob.Properties.ContentMD5
+		}
+		if isComplete(fileName, size, checksums) {
+			log.Info("Blob already downloaded", "name", *_blob.Name, "fileName", fileName)
+			return nil
+		}
+		log.Info("Downloading blob", "fileName", fileName)
+		file, err := createPartial(fileName)
+		if err != nil {
+			return err
+		}
+		_, err = a.Client.DownloadFile(ctx, bucket, *_blob.Name, file,
+			&azblob.DownloadFileOptions{
+				BlockSize:   blockOptions.partSize(),
+				Concurrency: uint16(min(blockOptions.workers(), math.MaxUint16)), // #nosec G115
+				// If Progress is non-nil, this function is called periodically as bytes are downloaded.
+				Progress: func(bytesTransferred int64) {
+					log.V(1).Info("Downloaded bytes of blob", "bytes", bytesTransferred, "name", *_blob.Name)
+				},
+			})
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		return completeFile(fileName, checksums)
+	})
+}
+
+// azureBlobURIRegexps match the Azure Blob Storage URIs, as supported by the storage initializer.
+var azureBlobURIRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`^https://(.+?)\.blob\.core\.windows\.net/(.+)`),
+	regexp.MustCompile(`^https://(.+?)\.z[0-9]{1,2}\.blob\.storage\.azure\.net/(.+)`),
+}
+
+// IsAzureBlobURI returns true for the https:// URIs of Azure Blob Storage.
+func IsAzureBlobURI(storageUri string) bool {
+	for _, re := range azureBlobURIRegexps {
+		if re.MatchString(storageUri) {
+			return true
 		}
 	}
-	return nil
+	return false
+}
+
+// parseAzureURI returns the account, the container and the blob prefix of an
+// abfs://<container>@<account>.dfs.core.windows.net/<path> or https://<account>.blob.core.windows.net/<container>/<path> URI.
+func parseAzureURI(storageUri string) (account string, container string, prefix string, err error) {
+	uri, err := url.Parse(storageUri)
+	if err != nil {
+		return "", "", "", fmt.Errorf("unable to parse storage uri: %w", err)
+	}
+	account, _, _ = strings.Cut(uri.Hostname(), ".")
+	path := strings.TrimPrefix(uri.Path, "/")
+	if uri.User != nil {
+		container = uri.User.Username()
+		prefix = path
+	} else {
+		container, prefix, _ = strings.Cut(path, "/")
+	}
+	if account == "" {
+		return "", "", "", fmt.Errorf("no account specified in storage uri %s", storageUri)
+	}
+	if container == "" {
+		return "", "", "", fmt.Errorf("no container specified in storage uri %s", storageUri)
+	}
+	return account, container, prefix, nil
+}
+
+// azureAccountName returns the account of the blob service URL of a client, which is the first label of its
+// host, or the first segment of its path for the emulators addressed by IP or localhost.
+func azureAccountName(serviceUrl string) (string, error) {
+	uri, err := url.Parse(serviceUrl)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse service url: %w", err)
+	}
+	host := uri.Hostname()
+	if host == "localhost" || net.ParseIP(host) != nil {
+		account, _, _ := strings.Cut(strings.TrimPrefix(uri.Path, "/"), "/")
+		return account, nil
+	}
+	account, _, _ := strings.Cut(host, ".")
+	return account, nil
 }
 
 func (a AzureProvider) UploadObject(bucket string, key string, object []byte) error {
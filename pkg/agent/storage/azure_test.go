@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+)
+
+func TestParseAzureURI(t *testing.T) {
+	scenarios := map[string]struct {
+		uri               string
+		expectedAccount   string
+		expectedContainer string
+		expectedPrefix    string
+		expectedErr       bool
+	}{
+		"abfs uri": {
+			uri:               "abfs://models@account.dfs.core.windows.net/sklearn/model1",
+			expectedAccount:   "account",
+			expectedContainer: "models",
+			expectedPrefix:    "sklearn/model1",
+		},
+		"blob uri": {
+			uri:               "https://account.blob.core.windows.net/models/sklearn/model1",
+			expectedAccount:   "account",
+			expectedContainer: "models",
+			expectedPrefix:    "sklearn/model1",
+		},
+		"blob uri of a container": {
+			uri:               "https://account.blob.core.windows.net/models",
+			expectedAccount:   "account",
+			expectedContainer: "models",
+		},
+		"no container": {
+			uri:         "https://account.blob.core.windows.net/",
+			expectedErr: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			account, container, prefix, err := parseAzureURI(scenario.uri)
+			if scenario.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error for %s", scenario.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAzureURI failed: %v", err)
+			}
+			if account != scenario.expectedAccount || container != scenario.expectedContainer || prefix != scenario.expectedPrefix {
+				t.Errorf("expected account %q, container %q and prefix %q, got %q, %q and %q", scenario.expectedAccount,
+					scenario.expectedContainer, scenario.expectedPrefix, account, container, prefix)
+			}
+		})
+	}
+}
+
+func TestAzureAccountName(t *testing.T) {
+	for serviceUrl, expected := range map[string]string{
+		"https://account.blob.core.windows.net/":            "account",
+		"https://account.privatelink.blob.core.windows.net": "account",
+		"http://127.0.0.1:10000/devstoreaccount1":           "devstoreaccount1",
+		"http://localhost:10000/devstoreaccount1/":          "devstoreaccount1",
+	} {
+		account, err := azureAccountName(serviceUrl)
+		if err != nil || account != expected {
+			t.Errorf("expected the account %s of %s, got %q with err %v", expected, serviceUrl, account, err)
+		}
+	}
+}
+
+func TestIsAzureBlobURI(t *testing.T) {
+	for uri, expected := range map[string]bool{
+		"https://account.blob.core.windows.net/models/model1":  true,
+		"https://account.z12.blob.storage.azure.net/models/m1": true,
+		"https://account.file.core.windows.net/share/model1":   false,
+		"https://example.com/models/model.bin":                 false,
+	} {
+		if IsAzureBlobURI(uri) != expected {
+			t.Errorf("expected IsAzureBlobURI(%s) to be %v", uri, expected)
+		}
+	}
+}
+
+func TestAzureDownloadModel(t *testing.T) {
+	modelDir := t.TempDir()
+	client := mocks.NewMockAzureClient()
+	provider := &AzureProvider{Client: client, Account: "account"}
+	for key, content := range map[string]string{
+		"sklearn/model1/model.joblib":      "weights",
+		"sklearn/model1/config/model.json": "{}",
+	} {
+		if err := provider.UploadObject("models", key, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := provider.DownloadModel(modelDir, "model1", "https://account.blob.core.windows.net/models/sklearn/model1/")
+	if err != nil {
+		t.Fatalf("DownloadModel failed: %v", err)
+	}
+	for name, expected := range map[string]string{"model.joblib": "weights", "config/model.json": "{}"} {
+		content, err := os.ReadFile(filepath.Join(modelDir, "model1", name))
+		if err != nil {
+			t.Fatalf("expected file %s to be downloaded: %v", name, err)
+		}
+		if string(content) != expected {
+			t.Errorf("expected %s to contain %q, got %q", name, expected, content)
+		}
+	}
+}
+
+func TestAzureDownloadModel_OtherAccount(t *testing.T) {
+	modelDir := t.TempDir()
+	client := mocks.NewMockAzureClient()
+	provider := &AzureProvider{Client: client, Account: "account"}
+	if err := provider.UploadObject("models", "sklearn/model1/model.joblib", []byte("weights")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the container of the same name in the account of the client must not be read
+	err := provider.DownloadModel(modelDir, "model1", "https://other.blob.core.windows.net/models/sklearn/model1/")
+	if err == nil || !strings.Contains(err.Error(), "account other") {
+		t.Fatalf("expected the uri of another account to be rejected, got %v", err)
+	}
+	if FileExists(filepath.Join(modelDir, "model1", "model.joblib")) {
+		t.Error("expected no file to be downloaded")
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -32,6 +31,8 @@ import (
 
 type GCSProvider struct {
 	Client stiface.Client
+	// Options configure the concurrent downloads of the objects and of their parts
+	Options DownloadOptions
 }
 
 func (p *GCSProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
@@ -49,6 +50,7 @@ func (p *GCSProvider) DownloadModel(modelDir string, modelName string, storageUr
 		ModelName:  modelName,
 		Bucket:     tokens[0],
 		Item:       prefix,
+		Options:    p.Options,
 	}
 	it, err := gcsObjectDownloader.GetObjectIterator(ctx, p.Client)
 	if err != nil {
@@ -78,6 +80,7 @@ type GCSObjectDownloader struct {
 	ModelName  string
 	Bucket     string
 	Item       string
+	Options    DownloadOptions
 }
 
 func (g *GCSObjectDownloader) GetObjectIterator(ctx context.Context, client stiface.Client) (stiface.ObjectIterator, error) {
@@ -86,10 +89,7 @@ func (g *GCSObjectDownloader) GetObjectIterator(ctx context.Context, client stif
 }
 
 func (g *GCSObjectDownloader) Download(ctx context.Context, client stiface.Client, it stiface.ObjectIterator) error {
-	var errs []error
-	// flag to help determine if query prefix returned an empty iterator
-	foundObject := false
-
+	var objects []*gstorage.ObjectAttrs
 	for {
 		attrs, err := it.Next()
 		if errors.Is(err, iterator.Done) {
@@ -98,49 +98,53 @@ func (g *GCSObjectDownloader) Download(ctx context.Context, client stiface.Clien
 		if err != nil {
 			return fmt.Errorf("an error occurred while iterating: %w", err)
 		}
+		objects = append(objects, attrs)
+	}
+	// the query prefix returned an empty iterator
+	if len(objects) == 0 {
+		return gstorage.ErrObjectNotExist
+	}
+
+	objectWorkers, partOptions := g.Options.split(len(objects))
+	err := forEach(ctx, objectWorkers, len(objects), func(ctx context.Context, i int) error {
+		attrs := objects[i]
 		objectValue := strings.TrimPrefix(attrs.Name, g.Item)
 		fileName := filepath.Join(g.ModelDir, g.ModelName, objectValue)
-
-		foundObject = true
-		if FileExists(fileName) {
-			log.Info("Deleting file", "name", fileName)
-			if err := os.Remove(fileName); err != nil {
-				return fmt.Errorf("file is unable to be deleted: %w", err)
-			}
-		}
-		file, err := Create(fileName)
-		if err != nil {
-			return fmt.Errorf("file is already created: %w", err)
+		// GCS reports the CRC32C of every object, and the MD5 of the objects which are not composite
+		checksums := Checksums{MD5: attrs.MD5, CRC32C: &attrs.CRC32C}
+		if isComplete(fileName, attrs.Size, checksums) {
+			log.Info("Object already downloaded", "name", attrs.Name, "fileName", fileName)
+			return nil
 		}
-		if err := g.DownloadFile(ctx, client, attrs, file); err != nil {
-			errs = append(errs, err)
+		if err := g.DownloadFile(ctx, client, attrs, fileName, partOptions); err != nil {
+			return err
 		}
-	}
-	if !foundObject {
-		return gstorage.ErrObjectNotExist
-	}
-	if len(errs) > 0 {
-		return fmt.Errorf("GCSDownloadIncomplete: some objects failed to download: %w", errors.Join(errs...))
+		return completeFile(fileName, checksums)
+	})
+	if err != nil {
+		return fmt.Errorf("GCSDownloadIncomplete: some objects failed to download: %w", err)
 	}
 	return nil
 }
 
-func (g *GCSObjectDownloader) DownloadFile(ctx context.Context, client stiface.Client, attrs *gstorage.ObjectAttrs, file *os.File) error {
-	reader, err := client.Bucket(attrs.Bucket).Object(attrs.Name).NewReader(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create reader for object(%s) in bucket(%s): %w",
-			attrs.Name,
-			attrs.Bucket,
-			err,
-		)
-	}
-	defer func(reader stiface.Reader) {
-		closeErr := reader.Close()
-		if closeErr != nil {
-			log.Error(closeErr, "failed to close reader")
+// DownloadFile downloads an object into the partial file of fileName, resuming from the partial file
+// of a previous attempt, with the parts of a large object being downloaded concurrently with the options.
+func (g *GCSObjectDownloader) DownloadFile(ctx context.Context, client stiface.Client, attrs *gstorage.ObjectAttrs, fileName string,
+	options DownloadOptions,
+) error {
+	object := client.Bucket(attrs.Bucket).Object(attrs.Name)
+	err := downloadRanges(ctx, fileName, attrs.Size, options, func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error) {
+		reader, err := object.NewRangeReader(ctx, offset, length)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reader for object(%s) in bucket(%s): %w",
+				attrs.Name,
+				attrs.Bucket,
+				err,
+			)
 		}
-	}(reader)
-	if _, err := io.Copy(file, reader); err != nil {
+		return reader, nil
+	})
+	if err != nil {
 		return fmt.Errorf(
 			"failed to copy object(%s) from bucket(%s) to file: %w",
 			attrs.Name,
@@ -148,6 +152,6 @@ func (g *GCSObjectDownloader) DownloadFile(ctx context.Context, client stiface.C
 			err,
 		)
 	}
-	log.Info("Wrote " + attrs.Prefix + " to file " + file.Name())
+	log.Info("Wrote " + attrs.Name + " to file " + fileName)
 	return nil
 }
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultHuggingFaceEndpoint is the Hugging Face Hub the hf://<owner>/<model>[:<revision>] URIs are downloaded from
+	DefaultHuggingFaceEndpoint = "https://huggingface.co"
+	// HuggingFaceEndpointEnvKey overrides the Hugging Face Hub endpoint, as for the huggingface_hub library
+	HuggingFaceEndpointEnvKey = "HF_ENDPOINT"
+)
+
+// HuggingFaceProvider downloads the files of a model repository of the Hugging Face Hub.
+type HuggingFaceProvider struct {
+	Client   *http.Client
+	Endpoint string
+	// Token authenticates the downloads of private and gated models
+	Token string
+	// Options configure the concurrent downloads of the files and of their parts
+	Options DownloadOptions
+}
+
+var _ Provider = (*HuggingFaceProvider)(nil)
+
+// huggingFaceModelInfo is the part of the model info of the Hub API listing the files of a revision.
+type huggingFaceModelInfo struct {
+	SHA      string `json:"sha"`
+	Siblings []struct {
+		RFilename string `json:"rfilename"`
+		Size      *int64 `json:"size"`
+		LFS       *struct {
+			SHA256 string `json:"sha256"`
+			Size   int64  `json:"size"`
+		} `json:"lfs"`
+	} `json:"siblings"`
+}
+
+func (h *HuggingFaceProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	repoID, revision, err := parseHuggingFaceURI(storageUri)
+	if err != nil {
+		return err
+	}
+	endpoint := strings.TrimSuffix(h.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = DefaultHuggingFaceEndpoint
+	}
+	headers := map[string]string{}
+	if h.Token != "" {
+		headers["Authorization"] = "Bearer " + h.Token
+	}
+	ctx := context.Background()
+	info, err := h.modelInfo(ctx, fmt.Sprintf("%s/api/models/%s/revision/%s?blobs=true", endpoint, repoID, url.PathEscape(revision)), headers)
+	if err != nil {
+		return fmt.Errorf("unable to get the files of %s: %w", storageUri, err)
+	}
+	if len(info.Siblings) == 0 {
+		return fmt.Errorf("%s has no files", storageUri)
+	}
+	// The files are downloaded from the commit of the revision, so that they are consistent across attempts
+	if info.SHA != "" {
+		revision = info.SHA
+	}
+
+	fileWorkers, partOptions := h.Options.split(len(info.Siblings))
+	return forEach(ctx, fileWorkers, len(info.Siblings), func(ctx context.Context, i int) error {
+		sibling := info.Siblings[i]
+		if strings.Contains(sibling.RFilename, "..") {
+			return fmt.Errorf("path traversal detected in file path: %s", sibling.RFilename)
+		}
+		fileName := filepath.Join(modelDir, modelName, filepath.FromSlash(sibling.RFilename))
+		size := int64(-1)
+		if sibling.Size != nil {
+			size = *sibling.Size
+		}
+		checksums := Checksums{}
+		if sibling.LFS != nil {
+			size = sibling.LFS.Size
+			if sha256, err := hex.DecodeString(sibling.LFS.SHA256); err == nil {
+				checksums.SHA256 = sha256
+			}
+		}
+		if isComplete(fileName, size, checksums) {
+			log.Info("File already downloaded", "name", sibling.RFilename, "fileName", fileName)
+			return nil
+		}
+		log.Info("Downloading file", "fileName", fileName)
+		var escapedPath []string
+		for _, segment := range strings.Split(sibling.RFilename, "/") {
+			escapedPath = append(escapedPath, url.PathEscape(segment))
+		}
+		fileUri := fmt.Sprintf("%s/%s/resolve/%s/%s", endpoint, repoID, url.PathEscape(revision), strings.Join(escapedPath, "/"))
+		if size < 0 {
+			if err := h.downloadFile(ctx, fileUri, headers, fileName); err != nil {
+				return err
+			}
+		} else if err := downloadRanges(ctx, fileName, size, partOptions, httpRangeReader(h.Client, fileUri, headers)); err != nil {
+			return err
+		}
+		return completeFile(fileName, checksums)
+	})
+}
+
+func (h *HuggingFaceProvider) UploadObject(bucket string, key string, object []byte) error {
+	return errors.New("upload not supported for Hugging Face storage")
+}
+
+func (h *HuggingFaceProvider) modelInfo(ctx context.Context, uri string, headers map[string]string) (*huggingFaceModelInfo, error) {
+	resp, err := h.get(ctx, uri, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	info := &huggingFaceModelInfo{}
+	if err := json.NewDecoder(resp.Body).Decode(info); err != nil {
+		return nil, fmt.Errorf("unable to decode the model info: %w", err)
+	}
+	return info, nil
+}
+
+// downloadFile downloads a file whose size is unknown to its partial file.
+func (h *HuggingFaceProvider) downloadFile(ctx context.Context, uri string, headers map[string]string, fileName string) error {
+	resp, err := h.get(ctx, uri, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	file, err := createPartial(fileName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to copy file content: %w", err)
+	}
+	return nil
+}
+
+func (h *HuggingFaceProvider) get(ctx context.Context, uri string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, element := range headers {
+		req.Header.Add(key, element)
+	}
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make a request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("URI: %s returned a %d response code", uri, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// parseHuggingFaceURI returns the repository and the revision of an hf://<owner>/<model>[:<revision>] URI, the
+// revision being main by default.
+func parseHuggingFaceURI(storageUri string) (repoID string, revision string, err error) {
+	components := strings.Split(strings.TrimPrefix(storageUri, string(HF)), "/")
+	if len(components) != 2 || components[0] == "" {
+		return "", "", fmt.Errorf("invalid Hugging Face URI format. Expected 'hf://owner/model[:revision]', got '%s'", storageUri)
+	}
+	model, revision, _ := strings.Cut(components[1], ":")
+	if model == "" {
+		return "", "", fmt.Errorf("model name cannot be empty in Hugging Face URI: %s", storageUri)
+	}
+	if revision == "" {
+		revision = "main"
+	}
+	return components[0] + "/" + model, revision, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHuggingFaceURI(t *testing.T) {
+	scenarios := map[string]struct {
+		uri              string
+		expectedRepoID   string
+		expectedRevision string
+		expectedErr      bool
+	}{
+		"model":               {uri: "hf://meta-llama/llama-3", expectedRepoID: "meta-llama/llama-3", expectedRevision: "main"},
+		"model with revision": {uri: "hf://meta-llama/llama-3:v1.0", expectedRepoID: "meta-llama/llama-3", expectedRevision: "v1.0"},
+		"no owner":            {uri: "hf://llama-3", expectedErr: true},
+		"no model name":       {uri: "hf://meta-llama/:v1.0", expectedErr: true},
+		"file of a model":     {uri: "hf://meta-llama/llama-3/model.safetensors", expectedErr: true},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			repoID, revision, err := parseHuggingFaceURI(scenario.uri)
+			if scenario.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error for %s", scenario.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHuggingFaceURI failed: %v", err)
+			}
+			if repoID != scenario.expectedRepoID || revision != scenario.expectedRevision {
+				t.Errorf("expected %s at %s, got %s at %s", scenario.expectedRepoID, scenario.expectedRevision, repoID, revision)
+			}
+		})
+	}
+}
+
+// newHuggingFaceServer serves the files of the org/model repository at the commit abc123, the weights being stored with LFS.
+func newHuggingFaceServer(t *testing.T, files map[string][]byte, lfsSHA256 string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer hf_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/api/models/org/model/revision/main":
+			var siblings []string
+			for name, content := range files {
+				if name == "model.safetensors" {
+					siblings = append(siblings, fmt.Sprintf(`{"rfilename": %q, "size": %d, "lfs": {"sha256": %q, "size": %d}}`, name, len(content), lfsSHA256, len(content)))
+				} else {
+					siblings = append(siblings, fmt.Sprintf(`{"rfilename": %q, "size": %d}`, name, len(content)))
+				}
+			}
+			_, _ = fmt.Fprintf(w, `{"sha": "abc123", "siblings": [%s]}`, strings.Join(siblings, ","))
+		case strings.HasPrefix(r.URL.Path, "/org/model/resolve/abc123/"):
+			content, ok := files[strings.TrimPrefix(r.URL.Path, "/org/model/resolve/abc123/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestHuggingFaceDownloadModel(t *testing.T) {
+	files := map[string][]byte{
+		"model.safetensors":        bytes.Repeat([]byte("weights"), 100),
+		"config.json":              []byte("{}"),
+		"tokenizer/tokenizer.json": []byte(`{"version": "1.0"}`),
+	}
+	weightsSHA256 := sha256.Sum256(files["model.safetensors"])
+	server := newHuggingFaceServer(t, files, hex.EncodeToString(weightsSHA256[:]))
+	defer server.Close()
+
+	modelDir := t.TempDir()
+	provider := &HuggingFaceProvider{
+		Client:   server.Client(),
+		Endpoint: server.URL,
+		Token:    "hf_token",
+		Options:  DownloadOptions{PartSize: 128},
+	}
+	if err := provider.DownloadModel(modelDir, "model1", "hf://org/model"); err != nil {
+		t.Fatalf("DownloadModel failed: %v", err)
+	}
+	for name, expected := range files {
+		content, err := os.ReadFile(filepath.Join(modelDir, "model1", name))
+		if err != nil {
+			t.Fatalf("expected file %s to be downloaded: %v", name, err)
+		}
+		if !bytes.Equal(content, expected) {
+			t.Errorf("unexpected content of %s", name)
+		}
+	}
+}
+
+func TestHuggingFaceDownloadModel_ChecksumMismatch(t *testing.T) {
+	files := map[string][]byte{"model.safetensors": []byte("corrupted weights")}
+	server := newHuggingFaceServer(t, files, strings.Repeat("0", 64))
+	defer server.Close()
+
+	modelDir := t.TempDir()
+	provider := &HuggingFaceProvider{Client: server.Client(), Endpoint: server.URL, Token: "hf_token"}
+	err := provider.DownloadModel(modelDir, "model1", "hf://org/model")
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	if FileExists(filepath.Join(modelDir, "model1", "model.safetensors")) {
+		t.Error("expected the mismatching file not to be kept")
+	}
+}
+
+func TestHuggingFaceDownloadModel_Unauthorized(t *testing.T) {
+	server := newHuggingFaceServer(t, map[string][]byte{}, "")
+	defer server.Close()
+
+	provider := &HuggingFaceProvider{Client: server.Client(), Endpoint: server.URL}
+	if err := provider.DownloadModel(t.TempDir(), "model1", "hf://org/model"); err == nil {
+		t.Fatal("expected the download of a gated model without token to fail")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -38,7 +39,8 @@ const (
 )
 
 type HTTPSProvider struct {
-	Client *http.Client
+	Client  *http.Client
+	Options DownloadOptions
 }
 
 func (m *HTTPSProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
@@ -52,6 +54,7 @@ func (m *HTTPSProvider) DownloadModel(modelDir string, modelName string, storage
 		ModelDir:   modelDir,
 		ModelName:  modelName,
 		Uri:        uri,
+		Options:    m.Options,
 	}
 	if err := HTTPSDownloader.Download(*m.Client); err != nil {
 		return err
@@ -68,6 +71,7 @@ type HTTPSDownloader struct {
 	ModelDir   string
 	ModelName  string
 	Uri        *url.URL
+	Options    DownloadOptions
 }
 
 func (h *HTTPSDownloader) Download(client http.Client) error {
@@ -121,14 +125,34 @@ func (h *HTTPSDownloader) Download(client http.Client) error {
 		paths := strings.Split(h.Uri.Path, "/")
 		fileName := paths[len(paths)-1]
 
-		fileFullName := filepath.Join(fileDirectory, fileName)
-		file, err := createNewFile(fileFullName)
+		fileFullName, err := validateFilePath(filepath.Join(fileDirectory, fileName))
 		if err != nil {
 			return err
 		}
-		if _, err = io.Copy(file, resp.Body); err != nil {
+		checksums := Checksums{MD5: MD5FromBase64(resp.Header.Get("Content-MD5"))}
+		if resp.Header.Get("Accept-Ranges") == "bytes" && resp.ContentLength > 0 {
+			// the file is downloaded in ranges, so that it resumes where a previous attempt stopped
+			if isComplete(fileFullName, resp.ContentLength, checksums) {
+				return nil
+			}
+			read := httpRangeReader(&client, h.StorageUri, headers)
+			if err := downloadRanges(context.Background(), fileFullName, resp.ContentLength, h.Options, read); err != nil {
+				return err
+			}
+			return completeFile(fileFullName, checksums)
+		}
+		file, err := createNewFile(fileFullName + partialSuffix)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, resp.Body)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
 			return fmt.Errorf("unable to copy file content: %w", err)
 		}
+		return completeFile(fileFullName, checksums)
 	}
 
 	return nil
@@ -146,24 +170,33 @@ func (h *HTTPSDownloader) extractHeaders() (headers map[string]string, err error
 	return headers, err
 }
 
-func createNewFile(fileFullName string) (*os.File, error) {
+// validateFilePath cleans the path of a file to download, and rejects the paths outside of the model directory.
+func validateFilePath(fileFullName string) (string, error) {
 	protectedPaths := []string{"/etc", "/bin", "/dev", "/usr/bin", "/sbin", "/usr/sbin"}
 	fileFullName = filepath.Clean(fileFullName)
 
 	// Check if path starts with any protected directory
 	for _, protectedPath := range protectedPaths {
 		if strings.HasPrefix(fileFullName, protectedPath+"/") || fileFullName == protectedPath {
-			return nil, fmt.Errorf("access denied: cannot write to protected system directory %s", protectedPath)
+			return "", fmt.Errorf("access denied: cannot write to protected system directory %s", protectedPath)
 		}
 	}
 
 	// Reject any path containing traversal sequences upfront
 	if strings.Contains(fileFullName, "..") {
-		return nil, fmt.Errorf("path traversal detected in file path: %s", fileFullName)
+		return "", fmt.Errorf("path traversal detected in file path: %s", fileFullName)
 	}
 	// Reject paths that resolve to current directory or empty
 	if fileFullName == "." || fileFullName == "" {
-		return nil, fmt.Errorf("please provide the full file path. The provided path [%s] is not valid", fileFullName)
+		return "", fmt.Errorf("please provide the full file path. The provided path [%s] is not valid", fileFullName)
+	}
+	return fileFullName, nil
+}
+
+func createNewFile(fileFullName string) (*os.File, error) {
+	fileFullName, err := validateFilePath(fileFullName)
+	if err != nil {
+		return nil, err
 	}
 
 	if FileExists(fileFullName) {
@@ -17,8 +17,18 @@ limitations under the License.
 package storage
 
 import (
+	"bytes"
+	"crypto/md5" // #nosec G501
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestCreateNewFileValidation(t *testing.T) {
@@ -237,3 +247,62 @@ func TestCreateNewFileValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPSDownload_Ranges(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	md5Sum := md5.Sum(content) // #nosec G401
+	var rangeRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			rangeRequests.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	modelDir := t.TempDir()
+	provider := &HTTPSProvider{Client: server.Client(), Options: DownloadOptions{Workers: 2, PartSize: 300}}
+	if err := provider.DownloadModel(modelDir, "model1", server.URL+"/models/model.bin"); err != nil {
+		t.Fatalf("DownloadModel failed: %v", err)
+	}
+	if rangeRequests.Load() != 4 {
+		t.Errorf("expected 4 range requests, got %d", rangeRequests.Load())
+	}
+	downloaded, err := os.ReadFile(filepath.Join(modelDir, "model1", "model.bin"))
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	if !bytes.Equal(downloaded, content) {
+		t.Error("downloaded content does not match")
+	}
+
+	// a complete file is not downloaded again
+	rangeRequests.Store(0)
+	if err := provider.DownloadModel(modelDir, "model1", server.URL+"/models/model.bin"); err != nil {
+		t.Fatalf("DownloadModel failed: %v", err)
+	}
+	if rangeRequests.Load() != 0 {
+		t.Errorf("expected no range requests, got %d", rangeRequests.Load())
+	}
+}
+
+func TestHTTPSDownload_ChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(make([]byte, md5.Size)))
+		_, _ = w.Write([]byte("corrupted weights"))
+	}))
+	defer server.Close()
+
+	modelDir := t.TempDir()
+	uri, _ := url.Parse(server.URL + "/model.bin")
+	downloader := &HTTPSDownloader{StorageUri: uri.String(), ModelDir: modelDir, ModelName: "model1", Uri: uri}
+	err := downloader.Download(*server.Client())
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	if FileExists(filepath.Join(modelDir, "model1", "model.bin")) {
+		t.Error("expected the mismatching file not to be kept")
+	}
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+const (
+	// OCIDockerConfigEnvKey is the path of the docker config.json with the credentials of the registries,
+	// as for the storage initializer
+	OCIDockerConfigEnvKey = "KSERVE_OCI_DOCKER_CONFIG"
+	// OCIInsecureRegistryEnvKey pulls the images over plain HTTP when it is true
+	OCIInsecureRegistryEnvKey = "KSERVE_OCI_INSECURE_REGISTRY"
+	// ociModelsPrefix is the directory of the model in the layers of a modelcar image
+	ociModelsPrefix = "models/"
+)
+
+var (
+	ociIndexMediaTypes = []string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+	}
+	ociManifestMediaTypes = []string{
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}
+	// ociLayerGzip tells whether the layers of a media type are compressed
+	ociLayerGzip = map[string]bool{
+		"application/vnd.oci.image.layer.v1.tar+gzip":             true,
+		"application/vnd.docker.image.rootfs.diff.tar.gzip":       true,
+		"application/vnd.oci.image.layer.v1.tar":                  false,
+		"application/vnd.docker.image.rootfs.diff.tar":            false,
+		"application/vnd.oci.image.layer.nondistributable.v1.tar": false,
+	}
+)
+
+// OCIProvider extracts the /models directory of the modelcar images of the oci://<registry>/<repository>[:<tag>|@<digest>] URIs.
+type OCIProvider struct {
+	Client *http.Client
+	// DockerConfig is the path of the docker config.json with the credentials of the registries
+	DockerConfig string
+	// Insecure pulls the images over plain HTTP
+	Insecure bool
+}
+
+var _ Provider = (*OCIProvider)(nil)
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	} `json:"platform,omitempty"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+func (o *OCIProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	registry, repository, reference, err := parseOCIReference(storageUri)
+	if err != nil {
+		return err
+	}
+	scheme := "https"
+	if o.Insecure {
+		scheme = "http"
+	}
+	client := &ociRegistryClient{
+		client:     o.Client,
+		baseUrl:    fmt.Sprintf("%s://%s/v2/%s", scheme, registryHost(registry), repository),
+		repository: repository,
+	}
+	client.username, client.password, err = dockerConfigCredentials(o.DockerConfig, registry)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	manifest, err := client.manifest(ctx, reference)
+	if err != nil {
+		return fmt.Errorf("unable to get the manifest of %s: %w", storageUri, err)
+	}
+	if slices.Contains(ociIndexMediaTypes, manifest.MediaType) {
+		// The image of the platform of the agent is pulled from a multi-platform image
+		digest := ""
+		for _, descriptor := range manifest.Manifests {
+			if descriptor.Platform != nil && descriptor.Platform.OS == "linux" && descriptor.Platform.Architecture == runtime.GOARCH {
+				digest = descriptor.Digest
+				break
+			}
+		}
+		if digest == "" {
+			return fmt.Errorf("the image index of %s has no manifest for linux/%s", storageUri, runtime.GOARCH)
+		}
+		if manifest, err = client.manifest(ctx, digest); err != nil {
+			return fmt.Errorf("unable to get the manifest of %s: %w", storageUri, err)
+		}
+	}
+
+	extracted := 0
+	for _, layer := range manifest.Layers {
+		compressed, ok := ociLayerGzip[layer.MediaType]
+		if !ok {
+			if strings.HasSuffix(layer.MediaType, "+zstd") {
+				return fmt.Errorf("layer %s of %s is compressed with zstd, which is not supported", layer.Digest, storageUri)
+			}
+			// The layers which are not archives have no model files
+			continue
+		}
+		count, err := client.extractLayer(ctx, layer.Digest, compressed, filepath.Join(modelDir, modelName))
+		if err != nil {
+			return fmt.Errorf("unable to extract layer %s of %s: %w", layer.Digest, storageUri, err)
+		}
+		extracted += count
+	}
+	if extracted == 0 {
+		return fmt.Errorf("the image %s has no /%s directory", storageUri, strings.TrimSuffix(ociModelsPrefix, "/"))
+	}
+	return nil
+}
+
+func (o *OCIProvider) UploadObject(bucket string, key string, object []byte) error {
+	return errors.New("upload not supported for OCI storage")
+}
+
+// ociRegistryClient pulls the manifests and the blobs of a repository of a registry, authenticating with a
+// bearer token or with basic credentials as requested by the registry.
+type ociRegistryClient struct {
+	client     *http.Client
+	baseUrl    string
+	repository string
+	username   string
+	password   string
+	token      string
+	basic      bool
+}
+
+func (c *ociRegistryClient) manifest(ctx context.Context, reference string) (*ociManifest, error) {
+	accept := strings.Join(append(append([]string{}, ociIndexMediaTypes...), ociManifestMediaTypes...), ", ")
+	resp, err := c.get(ctx, "/manifests/"+reference, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	manifest := &ociManifest{}
+	if err := json.NewDecoder(resp.Body).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("unable to decode the manifest: %w", err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = resp.Header.Get("Content-Type")
+	}
+	return manifest, nil
+}
+
+// extractLayer extracts the files of the models directory of a layer into dest, and returns how many were extracted.
+func (c *ociRegistryClient) extractLayer(ctx context.Context, digest string, compressed bool, dest string) (int, error) {
+	algorithm, expected, ok := strings.Cut(digest, ":")
+	if !ok || algorithm != "sha256" {
+		return 0, fmt.Errorf("unsupported digest %s", digest)
+	}
+	resp, err := c.get(ctx, "/blobs/"+digest, "")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	hash := sha256.New()
+	layer := io.TeeReader(resp.Body, hash)
+	reader := layer
+	if compressed {
+		gzipReader, err := gzip.NewReader(layer)
+		if err != nil {
+			return 0, err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	extracted := 0
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return extracted, err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(header.Name, "./"), "/")
+		if !strings.HasPrefix(name, ociModelsPrefix) {
+			continue
+		}
+		relPath := strings.TrimSuffix(strings.TrimPrefix(name, ociModelsPrefix), "/")
+		if relPath == "" || strings.HasPrefix(filepath.Base(relPath), ".wh.") {
+			continue
+		}
+		if strings.Contains(relPath, "..") {
+			return extracted, fmt.Errorf("path traversal detected in file path: %s", header.Name)
+		}
+		fileName := filepath.Join(dest, filepath.FromSlash(relPath))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(fileName, os.ModePerm); err != nil { //nolint:gosec // G301: agent and model server run as different UIDs sharing an emptyDir volume
+				return extracted, err
+			}
+		case tar.TypeReg:
+			file, err := Create(fileName)
+			if err != nil {
+				return extracted, err
+			}
+			_, err = io.Copy(file, tarReader) // #nosec G110 -- the layers are verified against their digest
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return extracted, fmt.Errorf("unable to extract %s: %w", header.Name, err)
+			}
+			extracted++
+		default:
+			log.Info("Skipping file of the image which is not a regular file", "name", header.Name)
+		}
+	}
+	// The rest of the layer is read to verify its digest
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return extracted, err
+	}
+	if _, err := io.Copy(io.Discard, layer); err != nil {
+		return extracted, err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != expected {
+		return extracted, fmt.Errorf("%w: layer does not match its digest %s", ErrChecksumMismatch, digest)
+	}
+	return extracted, nil
+}
+
+// get requests a path of the repository, and authenticates once when the registry requests it.
+func (c *ociRegistryClient) get(ctx context.Context, path string, accept string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		switch {
+		case c.token != "":
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		case c.basic:
+			req.SetBasicAuth(c.username, c.password)
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make a request: %w", err)
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return nil, fmt.Errorf("URI: %s returned a %d response code", c.baseUrl+path, resp.StatusCode)
+		}
+		if err := c.authenticate(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// authenticate answers the challenge of the registry, fetching a pull token for the bearer challenges.
+func (c *ociRegistryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if strings.EqualFold(scheme, "Basic") {
+		if c.username == "" {
+			return errors.New("the registry requires credentials")
+		}
+		c.basic = true
+		return nil
+	}
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+	values := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		values[key] = strings.Trim(value, `"`)
+	}
+	if values["realm"] == "" {
+		return fmt.Errorf("no realm in authentication challenge %q", challenge)
+	}
+	query := url.Values{}
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = "repository:" + c.repository + ":pull"
+	}
+	query.Set("scope", scope)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request a token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request returned a %d response code", resp.StatusCode)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("unable to decode the token: %w", err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	return nil
+}
+
+// parseOCIReference returns the registry, the repository and the tag or digest of an oci:// URI, the tag being
+// latest by default.
+func parseOCIReference(storageUri string) (registry string, repository string, reference string, err error) {
+	registry, repository, _ = strings.Cut(strings.TrimPrefix(storageUri, string(OCI)), "/")
+	if registry == "" || repository == "" {
+		return "", "", "", fmt.Errorf("invalid OCI URI; expected 'oci://<registry>/<repository>[:tag|@digest]', got '%s'", storageUri)
+	}
+	if repository, reference, ok := strings.Cut(repository, "@"); ok {
+		return registry, repository, reference, nil
+	}
+	reference = "latest"
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, reference = repository[:i], repository[i+1:]
+	}
+	if registry == "docker.io" && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	return registry, repository, reference, nil
+}
+
+// registryHost returns the host serving the registry API of a registry.
+func registryHost(registry string) string {
+	if registry == "docker.io" || registry == "index.docker.io" {
+		return "registry-1.docker.io"
+	}
+	return registry
+}
+
+// dockerConfigCredentials returns the credentials of a registry in a docker config.json, which are empty when the
+// config does not exist or has no credentials for the registry.
+func dockerConfigCredentials(configPath string, registry string) (string, string, error) {
+	if configPath == "" {
+		return "", "", nil
+	}
+	content, err := os.ReadFile(filepath.Clean(configPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	config := struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return "", "", fmt.Errorf("unable to parse the docker config %s: %w", configPath, err)
+	}
+	for key, auth := range config.Auths {
+		host := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		if host != registry && !(registryHost(registry) == "registry-1.docker.io" && host == "index.docker.io") {
+			continue
+		}
+		if auth.Auth == "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid auth of %s in the docker config: %w", key, err)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return username, password, nil
+	}
+	return "", "", nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	scenarios := map[string]struct {
+		uri                string
+		expectedRegistry   string
+		expectedRepository string
+		expectedReference  string
+		expectedErr        bool
+	}{
+		"tag": {
+			uri:              "oci://quay.io/org/model:v1",
+			expectedRegistry: "quay.io", expectedRepository: "org/model", expectedReference: "v1",
+		},
+		"default tag": {
+			uri:              "oci://localhost:5000/org/model",
+			expectedRegistry: "localhost:5000", expectedRepository: "org/model", expectedReference: "latest",
+		},
+		"digest": {
+			uri:              "oci://quay.io/org/model@sha256:abc",
+			expectedRegistry: "quay.io", expectedRepository: "org/model", expectedReference: "sha256:abc",
+		},
+		"docker hub official image": {
+			uri:              "oci://docker.io/model",
+			expectedRegistry: "docker.io", expectedRepository: "library/model", expectedReference: "latest",
+		},
+		"no repository": {
+			uri:         "oci://quay.io",
+			expectedErr: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			registry, repository, reference, err := parseOCIReference(scenario.uri)
+			if scenario.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error for %s", scenario.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOCIReference failed: %v", err)
+			}
+			if registry != scenario.expectedRegistry || repository != scenario.expectedRepository || reference != scenario.expectedReference {
+				t.Errorf("unexpected reference %s %s %s", registry, repository, reference)
+			}
+		})
+	}
+}
+
+func makeLayer(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, dir := range []string{"models/", "models/config/"} {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: dir, Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// newRegistryServer serves the org/model:v1 image index, whose image for the platform of the test has a single
+// layer, to the clients authenticated with a bearer token obtained with the user:password credentials.
+func newRegistryServer(t *testing.T, layer []byte, layerDigest string) *httptest.Server {
+	manifest := fmt.Sprintf(`{"mediaType": "application/vnd.oci.image.manifest.v1+json", "layers": [
+		{"mediaType": "application/vnd.oci.image.config.v1+json", "digest": "sha256:config"},
+		{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": %q}]}`, layerDigest)
+	manifestDigest := sha256Digest([]byte(manifest))
+	index := fmt.Sprintf(`{"mediaType": "application/vnd.oci.image.index.v1+json", "manifests": [
+		{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:other", "platform": {"os": "linux", "architecture": "other"}},
+		{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": %q, "platform": {"os": "linux", "architecture": %q}}]}`,
+		manifestDigest, runtime.GOARCH)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "password" || r.URL.Query().Get("scope") != "repository:org/model:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token": "registry-token"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer registry-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/model:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/org/model/manifests/v1":
+			_, _ = w.Write([]byte(index))
+		case "/v2/org/model/manifests/" + manifestDigest:
+			_, _ = w.Write([]byte(manifest))
+		case "/v2/org/model/blobs/" + layerDigest:
+			_, _ = w.Write(layer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func writeDockerConfig(t *testing.T, registry string) string {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	auth := base64.StdEncoding.EncodeToString([]byte("user:password"))
+	config := fmt.Sprintf(`{"auths": {"https://%s": {"auth": %q}}}`, registry, auth)
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func TestOCIDownloadModel(t *testing.T) {
+	layer := makeLayer(t, map[string]string{
+		"models/model.joblib":      "weights",
+		"models/config/model.json": "{}",
+		"etc/passwd":               "root",
+	})
+	server := newRegistryServer(t, layer, sha256Digest(layer))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	modelDir := t.TempDir()
+	provider := &OCIProvider{Client: server.Client(), DockerConfig: writeDockerConfig(t, registry), Insecure: true}
+	if err := provider.DownloadModel(modelDir, "model1", "oci://"+registry+"/org/model:v1"); err != nil {
+		t.Fatalf("DownloadModel failed: %v", err)
+	}
+	for name, expected := range map[string]string{"model.joblib": "weights", "config/model.json": "{}"} {
+		content, err := os.ReadFile(filepath.Join(modelDir, "model1", name))
+		if err != nil {
+			t.Fatalf("expected file %s to be extracted: %v", name, err)
+		}
+		if string(content) != expected {
+			t.Errorf("expected %s to contain %q, got %q", name, expected, content)
+		}
+	}
+	if FileExists(filepath.Join(modelDir, "model1", "etc", "passwd")) || FileExists(filepath.Join(modelDir, "model1", "passwd")) {
+		t.Error("expected the files outside of /models not to be extracted")
+	}
+}
+
+func TestOCIDownloadModel_Errors(t *testing.T) {
+	scenarios := map[string]struct {
+		files        map[string]string
+		wrongDigest  bool
+		withoutCreds bool
+		expectedErr  error
+	}{
+		"layer which does not match its digest": {
+			files:       map[string]string{"models/model.joblib": "weights"},
+			wrongDigest: true,
+			expectedErr: ErrChecksumMismatch,
+		},
+		"image without models": {
+			files: map[string]string{"app/main.py": "print()"},
+		},
+		"registry without credentials": {
+			files:        map[string]string{"models/model.joblib": "weights"},
+			withoutCreds: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			layer := makeLayer(t, scenario.files)
+			digest := sha256Digest(layer)
+			if scenario.wrongDigest {
+				digest = sha256Digest([]byte("other layer"))
+			}
+			server := newRegistryServer(t, layer, digest)
+			defer server.Close()
+			registry := strings.TrimPrefix(server.URL, "http://")
+
+			provider := &OCIProvider{Client: server.Client(), Insecure: true}
+			if !scenario.withoutCreds {
+				provider.DockerConfig = writeDockerConfig(t, registry)
+			}
+			err := provider.DownloadModel(t.TempDir(), "model1", "oci://"+registry+"/org/model:v1")
+			if err == nil {
+				t.Fatal("expected DownloadModel to fail")
+			}
+			if scenario.expectedErr != nil && !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
@@ -27,13 +27,17 @@ const (
 	S3    Protocol = "s3://"
 	GCS   Protocol = "gs://"
 	AZURE Protocol = "abfs://"
-	// PVC   Protocol = "pvc://"
-	// File  Protocol = "file://"
+	PVC   Protocol = "pvc://"
+	File  Protocol = "file://"
+	HF    Protocol = "hf://"
+	OCI   Protocol = "oci://"
 	HTTPS Protocol = "https://"
 	HTTP  Protocol = "http://"
 )
 
-var SupportedProtocols = []Protocol{S3, GCS, HTTPS, HTTP}
+// SupportedProtocols are the protocols of the storage URIs the model agent downloads models from. The Azure
+// Blob Storage URIs are also supported in their https://<account>.blob.core.windows.net/<container> form.
+var SupportedProtocols = []Protocol{S3, GCS, AZURE, PVC, HF, OCI, HTTPS, HTTP}
 
 func GetAllProtocol() (protocols []string) {
 	for _, protocol := range SupportedProtocols {
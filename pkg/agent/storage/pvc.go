@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPVCMountPath is where the claims of the pvc://<claim>/<path> URIs are mounted, as for the storage initializer.
+const DefaultPVCMountPath = "/mnt/pvc"
+
+// PVCProvider copies the models of a persistent volume claim mounted at MountPath/<claim>.
+type PVCProvider struct {
+	MountPath string
+	// Options configure the concurrent copies of the files
+	Options DownloadOptions
+}
+
+var _ Provider = (*PVCProvider)(nil)
+
+func (p *PVCProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	claim, path, _ := strings.Cut(strings.TrimPrefix(storageUri, string(PVC)), "/")
+	if claim == "" {
+		return fmt.Errorf("no claim specified in storage uri %s", storageUri)
+	}
+	mountPath := p.MountPath
+	if mountPath == "" {
+		mountPath = DefaultPVCMountPath
+	}
+	source := filepath.Join(mountPath, claim, path)
+	if relPath, err := filepath.Rel(filepath.Join(mountPath, claim), source); err != nil || strings.HasPrefix(relPath, "..") {
+		return fmt.Errorf("path traversal detected in storage uri %s", storageUri)
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("unable to find %s, the claim %s must be mounted on the model agent at %s: %w", source, claim, mountPath, err)
+	}
+
+	// A single file is copied to the model dir, and the files of a directory keep their relative path
+	root := source
+	if !info.IsDir() {
+		root = filepath.Dir(source)
+	}
+	var files []string
+	err = filepath.WalkDir(source, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("%s has no files", storageUri)
+	}
+
+	return forEach(context.Background(), p.Options.workers(), len(files), func(ctx context.Context, i int) error {
+		relPath, err := filepath.Rel(root, files[i])
+		if err != nil {
+			return err
+		}
+		fileName := filepath.Join(modelDir, modelName, relPath)
+		info, err := os.Stat(files[i])
+		if err != nil {
+			return err
+		}
+		if isComplete(fileName, info.Size(), Checksums{}) {
+			log.Info("File already copied", "fileName", fileName)
+			return nil
+		}
+		return copyFile(files[i], fileName)
+	})
+}
+
+func (p *PVCProvider) UploadObject(bucket string, key string, object []byte) error {
+	return errors.New("upload not supported for PVC storage")
+}
+
+// copyFile copies a file to the partial file of fileName, which is renamed once complete.
+func copyFile(source string, fileName string) error {
+	in, err := os.Open(filepath.Clean(source))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := createPartial(fileName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to copy %s: %w", source, err)
+	}
+	return completeFile(fileName, Checksums{})
+}
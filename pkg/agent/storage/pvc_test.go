@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPVCDownloadModel(t *testing.T) {
+	mountPath := t.TempDir()
+	for name, content := range map[string]string{
+		"claim/models/model1/model.joblib":      "weights",
+		"claim/models/model1/config/model.json": "{}",
+		"claim/models/model2/model.joblib":      "other weights",
+	} {
+		fileName := filepath.Join(mountPath, name)
+		if err := os.MkdirAll(filepath.Dir(fileName), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	provider := &PVCProvider{MountPath: mountPath}
+
+	scenarios := map[string]struct {
+		uri           string
+		expectedFiles map[string]string
+		expectedErr   bool
+	}{
+		"directory": {
+			uri:           "pvc://claim/models/model1",
+			expectedFiles: map[string]string{"model.joblib": "weights", "config/model.json": "{}"},
+		},
+		"single file": {
+			uri:           "pvc://claim/models/model2/model.joblib",
+			expectedFiles: map[string]string{"model.joblib": "other weights"},
+		},
+		"missing path": {
+			uri:         "pvc://claim/models/model3",
+			expectedErr: true,
+		},
+		"path outside of the claim": {
+			uri:         "pvc://claim/../other-claim/models",
+			expectedErr: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			modelDir := t.TempDir()
+			err := provider.DownloadModel(modelDir, "model", scenario.uri)
+			if scenario.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error for %s", scenario.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DownloadModel failed: %v", err)
+			}
+			for name, expected := range scenario.expectedFiles {
+				content, err := os.ReadFile(filepath.Join(modelDir, "model", name))
+				if err != nil {
+					t.Fatalf("expected file %s to be copied: %v", name, err)
+				}
+				if string(content) != expected {
+					t.Errorf("expected %s to contain %q, got %q", name, expected, content)
+				}
+			}
+		})
+	}
+}
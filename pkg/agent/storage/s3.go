@@ -19,19 +19,22 @@ package storage
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type S3Provider struct {
-	Client         S3ListClient
+	Client         S3Client
 	TransferClient S3TransferClient
+	// Options configure the concurrent downloads of the objects and of their parts
+	Options DownloadOptions
 }
 
 var log = logf.Log.WithName("modelAgent")
@@ -65,62 +68,87 @@ func (m *S3Provider) DownloadModel(modelDir string, modelName string, storageUri
 		Prefix: aws.String(prefix),
 	})
 
-	foundObject := false
-
+	objects := []s3types.Object{}
 	for paginator.HasMorePages() {
 		resp, err := paginator.NextPage(ctx)
 		if err != nil {
 			return fmt.Errorf("unable to list objects: %w", err)
 		}
-
 		for _, object := range resp.Contents {
-			if strings.HasSuffix(*object.Key, "/") {
-				continue
-			}
-			subObjectKey := strings.TrimPrefix(*object.Key, prefix)
-			fileName := filepath.Join(modelDir, modelName, subObjectKey)
-
-			if FileExists(fileName) {
-				// File got corrupted or is mid-download :(
-				// TODO: Figure out if we can maybe continue?
-				if err := os.Remove(fileName); err != nil {
-					return fmt.Errorf("file is unable to be deleted: %w", err)
-				}
-			}
-			file, err := Create(fileName)
-			if err != nil {
-				return fmt.Errorf("file is already created: %w", err)
-			}
-
-			if _, err := m.TransferClient.DownloadObject(ctx, &transfermanager.DownloadObjectInput{
-				Key:      object.Key,
-				Bucket:   aws.String(bucket),
-				WriterAt: file,
-			}); err != nil {
-				_ = file.Close()
-				return fmt.Errorf("failed to download %s: %w", *object.Key, err)
+			if !strings.HasSuffix(*object.Key, "/") {
+				objects = append(objects, object)
 			}
-			if err := file.Close(); err != nil {
-				return fmt.Errorf("failed to close file %s: %w", fileName, err)
-			}
-			foundObject = true
 		}
 	}
 
-	if !foundObject {
+	if len(objects) == 0 {
 		return fmt.Errorf("%s has no objects or does not exist", storageUri)
 	}
 
-	return nil
+	// The objects are downloaded concurrently, and the parts of each object are downloaded concurrently with
+	// ranged requests, resuming from the parts downloaded by a previous attempt
+	objectWorkers, partOptions := m.Options.split(len(objects))
+	return forEach(ctx, objectWorkers, len(objects), func(ctx context.Context, i int) error {
+		object := objects[i]
+		fileName := filepath.Join(modelDir, modelName, strings.TrimPrefix(*object.Key, prefix))
+		head, err := m.Client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    object.Key,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get the attributes of %s: %w", *object.Key, err)
+		}
+		size := aws.ToInt64(head.ContentLength)
+		checksums := s3Checksums(head)
+		if isComplete(fileName, size, checksums) {
+			log.Info("Object already downloaded", "key", *object.Key, "fileName", fileName)
+			return nil
+		}
+		if err := downloadRanges(ctx, fileName, size, partOptions, s3RangeReader(m.Client, bucket, *object.Key, head.ETag)); err != nil {
+			return fmt.Errorf("failed to download %s: %w", *object.Key, err)
+		}
+		return completeFile(fileName, checksums)
+	})
+}
+
+// s3Checksums returns the checksums the download of an object is verified against. The ETag of an object
+// uploaded in a single part is the MD5 of its content, unless the object is encrypted with SSE-KMS or with a
+// key of the customer. The ranged requests do not return the checksums of the whole object.
+func s3Checksums(head *s3.HeadObjectOutput) Checksums {
+	if head.SSECustomerAlgorithm != nil {
+		return Checksums{}
+	}
+	switch head.ServerSideEncryption {
+	case s3types.ServerSideEncryptionAwsKms, s3types.ServerSideEncryptionAwsKmsDsse:
+		return Checksums{}
+	}
+	return Checksums{MD5: MD5FromETag(aws.ToString(head.ETag))}
+}
+
+// s3RangeReader reads the ranges of an object, which must keep the ETag it had when the download started.
+func s3RangeReader(client S3Client, bucket string, key string, etag *string) rangeReader {
+	return func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error) {
+		resp, err := client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:  aws.String(bucket),
+			Key:     aws.String(key),
+			Range:   aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+			IfMatch: etag,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
 }
 
-// S3ListClient abstracts the S3 ListObjectsV2 operation for dependency injection and testing.
-type S3ListClient interface {
+// S3Client abstracts the S3 operations the objects are listed and downloaded with, for dependency injection and testing.
+type S3Client interface {
 	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 }
 
-// S3TransferClient abstracts the S3 transfer manager operations for download and upload.
+// S3TransferClient abstracts the S3 transfer manager operations for upload.
 type S3TransferClient interface {
-	DownloadObject(ctx context.Context, input *transfermanager.DownloadObjectInput, opts ...func(*transfermanager.Options)) (*transfermanager.DownloadObjectOutput, error)
 	UploadObject(ctx context.Context, input *transfermanager.UploadObjectInput, opts ...func(*transfermanager.Options)) (*transfermanager.UploadObjectOutput, error)
 }
@@ -17,12 +17,24 @@ limitations under the License.
 package storage
 
 import (
+	"bytes"
+	"context"
+	"crypto/md5" // #nosec G501
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"syscall"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
 	"github.com/kserve/kserve/pkg/agent/mocks"
 )
 
@@ -183,11 +195,12 @@ func TestDownloadModel_TransferError(t *testing.T) {
 
 	provider := &S3Provider{
 		Client: &mocks.MockS3PaginatedClient{
+			MockS3Objects: mocks.MockS3Objects{GetErr: errors.New("network timeout")},
 			Pages: [][]string{
 				{"prefix/model.pt"},
 			},
 		},
-		TransferClient: &mocks.MockS3FailTransferClient{Err: errors.New("network timeout")},
+		TransferClient: &mocks.MockS3TransferClient{},
 	}
 
 	err := provider.DownloadModel(t.TempDir(), "model1", "s3://bucket/prefix/")
@@ -252,3 +265,128 @@ func TestDownloadModel_NoPrefixInURI(t *testing.T) {
 		t.Error("expected model.pt to exist")
 	}
 }
+
+// rangedS3Client serves a single object, failing the ranges from failFrom until failures is reached.
+type rangedS3Client struct {
+	mocks.MockS3Client
+	content    []byte
+	etag       string
+	encryption s3types.ServerSideEncryption
+	failFrom   int64
+	failures   int
+	mu         sync.Mutex
+	ranges     []string
+}
+
+func (c *rangedS3Client) HeadObject(_ context.Context, _ *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	return &s3.HeadObjectOutput{
+		ContentLength:        aws.Int64(int64(len(c.content))),
+		ETag:                 aws.String(c.etag),
+		ServerSideEncryption: c.encryption,
+	}, nil
+}
+
+func (c *rangedS3Client) GetObject(_ context.Context, input *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if aws.ToString(input.IfMatch) != c.etag {
+		return nil, errors.New("precondition failed")
+	}
+	c.ranges = append(c.ranges, aws.ToString(input.Range))
+	var start int64
+	if _, err := fmt.Sscanf(aws.ToString(input.Range), "bytes=%d-", &start); err == nil && c.failures > 0 && start >= c.failFrom {
+		c.failures--
+		return nil, errors.New("connection reset")
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(mocks.ObjectRange(c.content, aws.ToString(input.Range))))}, nil
+}
+
+func etagOf(content []byte) string {
+	md5Sum := md5.Sum(content) // #nosec G401
+	return `"` + hex.EncodeToString(md5Sum[:]) + `"`
+}
+
+func TestDownloadModel_ResumesPartialObject(t *testing.T) {
+	syscall.Umask(0)
+	modelDir := t.TempDir()
+	content := bytes.Repeat([]byte("0123456789"), 30)
+	client := &rangedS3Client{content: content, etag: etagOf(content), failFrom: 192, failures: 1}
+	provider := &S3Provider{
+		Client:         client,
+		TransferClient: &mocks.MockS3TransferClient{},
+		Options:        DownloadOptions{Workers: 1, PartSize: 64},
+	}
+
+	if err := provider.DownloadModel(modelDir, "model1", "s3://bucket/"); err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+	partial, err := os.ReadFile(filepath.Join(modelDir, "model1", "model.pt"+partialSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(partial, content[:192]) {
+		t.Fatalf("expected the parts downloaded before the failure to be kept, got %d bytes", len(partial))
+	}
+
+	client.ranges = nil
+	if err := provider.DownloadModel(modelDir, "model1", "s3://bucket/"); err != nil {
+		t.Fatalf("DownloadModel failed: %v", err)
+	}
+	if got := client.ranges; len(got) != 2 || got[0] != "bytes=192-255" || got[1] != "bytes=256-299" {
+		t.Errorf("expected the download to resume from the failed part, got the ranges %v", got)
+	}
+	downloaded, err := os.ReadFile(filepath.Join(modelDir, "model1", "model.pt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(downloaded, content) {
+		t.Error("expected the resumed file to match the object")
+	}
+}
+
+func TestDownloadModel_VerifiesETag(t *testing.T) {
+	syscall.Umask(0)
+	content := []byte("model weights")
+	scenarios := map[string]struct {
+		etag        string
+		encryption  s3types.ServerSideEncryption
+		expectedErr error
+	}{
+		"matching ETag": {
+			etag: etagOf(content),
+		},
+		"corrupted download": {
+			etag:        etagOf([]byte("other weights")),
+			expectedErr: ErrChecksumMismatch,
+		},
+		"multipart upload": {
+			etag: strings.TrimSuffix(etagOf([]byte("other weights")), `"`) + `-3"`,
+		},
+		"SSE-KMS encrypted object": {
+			etag:       etagOf([]byte("other weights")),
+			encryption: s3types.ServerSideEncryptionAwsKms,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			modelDir := t.TempDir()
+			provider := &S3Provider{
+				Client:         &rangedS3Client{content: content, etag: scenario.etag, encryption: scenario.encryption},
+				TransferClient: &mocks.MockS3TransferClient{},
+			}
+			err := provider.DownloadModel(modelDir, "model1", "s3://bucket/")
+			if scenario.expectedErr != nil {
+				if !errors.Is(err, scenario.expectedErr) {
+					t.Fatalf("expected %v, got %v", scenario.expectedErr, err)
+				}
+				if FileExists(filepath.Join(modelDir, "model1", "model.pt"+partialSuffix)) {
+					t.Error("expected the corrupted download to be removed")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DownloadModel failed: %v", err)
+			}
+		})
+	}
+}
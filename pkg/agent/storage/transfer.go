@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // #nosec G501 -- MD5 is only used to verify the object checksums of the storage
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// partialSuffix is the suffix of the files being downloaded, which are renamed once verified
+	partialSuffix = ".part"
+	// DefaultDownloadWorkers is the number of requests in flight to download a model by default
+	DefaultDownloadWorkers = 4
+	// DefaultDownloadPartSize is the size of the parts of a file downloaded concurrently by default
+	DefaultDownloadPartSize = 64 << 20
+	// ManifestFileName is the name of the optional manifest of a model, listing the SHA-256 of its files
+	// in the format of sha256sum
+	ManifestFileName = "SHA256SUMS"
+)
+
+// ErrChecksumMismatch is returned when a downloaded file does not match the checksum of its object.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// DownloadOptions configure how the providers download the files of a model.
+type DownloadOptions struct {
+	// Workers is the number of requests in flight for a model, which download its files or the parts of a file
+	Workers int
+	// PartSize is the size of the parts of a large file downloaded concurrently
+	PartSize int64
+}
+
+func (o DownloadOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return DefaultDownloadWorkers
+}
+
+func (o DownloadOptions) partSize() int64 {
+	if o.PartSize > 0 {
+		return o.PartSize
+	}
+	return DefaultDownloadPartSize
+}
+
+// split divides the workers between the files downloaded concurrently and the parts of each of them, so
+// that no more than Workers requests are in flight for a model.
+func (o DownloadOptions) split(files int) (int, DownloadOptions) {
+	fileWorkers := max(min(o.workers(), files), 1)
+	return fileWorkers, DownloadOptions{Workers: max(o.workers()/fileWorkers, 1), PartSize: o.PartSize}
+}
+
+// Checksums are the checksums of an object reported by the storage, which are verified when they are set.
+type Checksums struct {
+	MD5    []byte
+	CRC32C *uint32
+	SHA256 []byte
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// MD5FromETag returns the MD5 of an object from its ETag, which is only the MD5 of the object content
+// when the object was not uploaded in multiple parts.
+func MD5FromETag(etag string) []byte {
+	etag = strings.Trim(etag, `"`)
+	if len(etag) != 2*md5.Size {
+		return nil
+	}
+	digest, err := hex.DecodeString(etag)
+	if err != nil {
+		return nil
+	}
+	return digest
+}
+
+// MD5FromBase64 returns the MD5 of an object from its base64 encoding, as in the Content-MD5 header.
+func MD5FromBase64(encoded string) []byte {
+	digest, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(digest) != md5.Size {
+		return nil
+	}
+	return digest
+}
+
+func (c Checksums) empty() bool {
+	return c.MD5 == nil && c.CRC32C == nil && c.SHA256 == nil
+}
+
+// verify checks the content of a file against the checksums.
+func (c Checksums) verify(fileName string) error {
+	if c.empty() {
+		return nil
+	}
+	file, err := os.Open(filepath.Clean(fileName))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	md5Hash, crc32cHash, sha256Hash := md5.New(), crc32.New(crc32cTable), sha256.New() // #nosec G401
+	if _, err := io.Copy(io.MultiWriter(md5Hash, crc32cHash, sha256Hash), file); err != nil {
+		return fmt.Errorf("failed to read %s: %w", fileName, err)
+	}
+	if c.MD5 != nil && !bytes.Equal(md5Hash.Sum(nil), c.MD5) {
+		return fmt.Errorf("%w: %s does not match the MD5 %s", ErrChecksumMismatch, fileName, hex.EncodeToString(c.MD5))
+	}
+	if c.CRC32C != nil && crc32cHash.Sum32() != *c.CRC32C {
+		return fmt.Errorf("%w: %s does not match the CRC32C %d", ErrChecksumMismatch, fileName, *c.CRC32C)
+	}
+	if c.SHA256 != nil && !bytes.Equal(sha256Hash.Sum(nil), c.SHA256) {
+		return fmt.Errorf("%w: %s does not match the SHA-256 %s", ErrChecksumMismatch, fileName, hex.EncodeToString(c.SHA256))
+	}
+	return nil
+}
+
+// isComplete returns true when the file of an object was downloaded and verified by a previous attempt,
+// the file being verified again when the storage reports the checksums of the object.
+func isComplete(fileName string, size int64, checksums Checksums) bool {
+	info, err := os.Stat(fileName)
+	if err != nil || info.IsDir() || size < 0 || info.Size() != size {
+		return false
+	}
+	return checksums.verify(fileName) == nil
+}
+
+// completeFile verifies the downloaded file of an object, and moves it to its final name. A file which
+// does not match the checksums is removed, so that the next attempt downloads it from the start.
+func completeFile(fileName string, checksums Checksums) error {
+	partName := fileName + partialSuffix
+	if err := checksums.verify(partName); err != nil {
+		if errors.Is(err, ErrChecksumMismatch) {
+			_ = os.Remove(partName)
+		}
+		return err
+	}
+	if err := os.Rename(partName, fileName); err != nil {
+		return fmt.Errorf("failed to move the downloaded file %s: %w", fileName, err)
+	}
+	return nil
+}
+
+// createPartial creates the file an object is downloaded to, removing the partial file of a previous attempt.
+func createPartial(fileName string) (*os.File, error) {
+	partName := fileName + partialSuffix
+	if FileExists(partName) {
+		if err := os.Remove(partName); err != nil {
+			return nil, fmt.Errorf("file is unable to be deleted: %w", err)
+		}
+	}
+	return Create(partName)
+}
+
+// rangeReader reads length bytes of an object from offset.
+type rangeReader func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error)
+
+// httpRangeReader reads the ranges of a file served over HTTP(S) with range requests.
+func httpRangeReader(client *http.Client, uri string, headers map[string]string) rangeReader {
+	return func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, err
+		}
+		for key, element := range headers {
+			req.Header.Add(key, element)
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make a request: %w", err)
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			return nil, fmt.Errorf("URI: %s returned a %d response code for a range request", uri, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
+// downloadRanges downloads an object of the given size into the partial file of fileName. The download
+// resumes from the size of the partial file left by a previous attempt, and the rest of the object is
+// fetched in parts by concurrent workers. When a part fails, the partial file is truncated to the parts
+// downloaded before it, so that the next attempt resumes from there.
+func downloadRanges(ctx context.Context, fileName string, size int64, options DownloadOptions, read rangeReader) error {
+	partName := fileName + partialSuffix
+	if err := os.MkdirAll(filepath.Dir(partName), os.ModePerm); err != nil { //nolint:gosec // G301: agent and model server run as different UIDs sharing an emptyDir volume
+		return err
+	}
+	file, err := os.OpenFile(filepath.Clean(partName), os.O_CREATE|os.O_WRONLY, 0o666) // #nosec G302 G304 -- same permissions as Create
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+	if offset > size {
+		// the object changed since the previous attempt
+		offset = 0
+	}
+	if offset > 0 {
+		log.Info("Resuming download", "file", fileName, "offset", offset, "size", size)
+	}
+	if err := file.Truncate(offset); err != nil {
+		return err
+	}
+
+	partSize := options.partSize()
+	parts := int((size - offset + partSize - 1) / partSize)
+	done := make([]bool, parts)
+	err = forEach(ctx, options.workers(), parts, func(ctx context.Context, i int) error {
+		start := offset + int64(i)*partSize
+		length := min(partSize, size-start)
+		reader, err := read(ctx, start, length)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		written, err := io.Copy(io.NewOffsetWriter(file, start), io.LimitReader(reader, length))
+		if err != nil {
+			return err
+		}
+		if written != length {
+			return fmt.Errorf("read %d bytes of %s from offset %d instead of %d", written, fileName, start, length)
+		}
+		done[i] = true
+		return nil
+	})
+	if err != nil {
+		resumeAt := offset
+		for i := 0; i < parts && done[i]; i++ {
+			resumeAt = min(offset+int64(i+1)*partSize, size)
+		}
+		if truncateErr := file.Truncate(resumeAt); truncateErr != nil {
+			log.Error(truncateErr, "failed to truncate the partial file", "file", partName)
+		}
+		return err
+	}
+	return file.Sync()
+}
+
+// forEach calls fn for the indexes from 0 to n with a pool of concurrent workers. Every index is processed
+// even when some fail, and the errors are joined.
+func forEach(ctx context.Context, workers int, n int, fn func(ctx context.Context, i int) error) error {
+	indexes := make(chan int)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(ctx, i)
+			}
+		}()
+	}
+	for i := range n {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// DigestModelDir verifies the files of a downloaded model against its manifest when the model provides one,
+// and returns the digest of the model, which is the SHA-256 of the sorted list of the SHA-256 of its files.
+// A file which does not match the manifest is removed, so that the next attempt downloads it again.
+func DigestModelDir(dir string) (string, error) {
+	sums := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, "SUCCESS.") || strings.HasSuffix(name, partialSuffix) {
+			return nil
+		}
+		sum, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		sums[filepath.ToSlash(relPath)] = sum
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, ok := sums[ManifestFileName]; ok {
+		if err := verifyManifest(dir, sums); err != nil {
+			return "", err
+		}
+	}
+
+	paths := make([]string, 0, len(sums))
+	for path := range sums {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	digest := sha256.New()
+	for _, path := range paths {
+		fmt.Fprintf(digest, "%s  %s\n", sums[path], path)
+	}
+	return "sha256:" + hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// verifyManifest checks the SHA-256 of the files of a model against the ones listed by its manifest.
+func verifyManifest(dir string, sums map[string]string) error {
+	manifest, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, line := range strings.Split(string(manifest), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		expected, path, ok := strings.Cut(line, " ")
+		if !ok {
+			return fmt.Errorf("invalid line in %s: %q", ManifestFileName, line)
+		}
+		// the path is prefixed with '*' for the files read in binary mode
+		path = strings.TrimPrefix(strings.TrimLeft(path, " "), "*")
+		path = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+		sum, ok := sums[path]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s listed in %s was not downloaded", path, ManifestFileName))
+		case !strings.EqualFold(sum, expected):
+			_ = os.Remove(filepath.Join(dir, filepath.FromSlash(path)))
+			errs = append(errs, fmt.Errorf("%w: %s does not match the SHA-256 %s", ErrChecksumMismatch, path, expected))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sha256File(fileName string) (string, error) {
+	file, err := os.Open(filepath.Clean(fileName))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", fileName, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // #nosec G501
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func bytesReader(content []byte) rangeReader {
+	return func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content[offset : offset+length])), nil
+	}
+}
+
+func TestDownloadRanges(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	fileName := filepath.Join(t.TempDir(), "model.bin")
+
+	var requests atomic.Int32
+	read := func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error) {
+		requests.Add(1)
+		return bytesReader(content)(ctx, offset, length)
+	}
+	err := downloadRanges(context.Background(), fileName, int64(len(content)), DownloadOptions{Workers: 3, PartSize: 64}, read)
+	if err != nil {
+		t.Fatalf("downloadRanges failed: %v", err)
+	}
+	if requests.Load() != 16 {
+		t.Errorf("expected 16 parts, got %d", requests.Load())
+	}
+	downloaded, err := os.ReadFile(fileName + partialSuffix)
+	if err != nil {
+		t.Fatalf("failed to read partial file: %v", err)
+	}
+	if !bytes.Equal(downloaded, content) {
+		t.Error("downloaded content does not match")
+	}
+}
+
+func TestDownloadRanges_ResumesFromCompletedParts(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	fileName := filepath.Join(t.TempDir(), "model.bin")
+	options := DownloadOptions{Workers: 1, PartSize: 100}
+
+	// the fourth part fails, so the partial file keeps the first three parts
+	failing := func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error) {
+		if offset == 300 {
+			return nil, errors.New("connection reset")
+		}
+		return bytesReader(content)(ctx, offset, length)
+	}
+	if err := downloadRanges(context.Background(), fileName, int64(len(content)), options, failing); err == nil {
+		t.Fatal("expected downloadRanges to fail")
+	}
+	info, err := os.Stat(fileName + partialSuffix)
+	if err != nil {
+		t.Fatalf("expected the partial file to be kept: %v", err)
+	}
+	if info.Size() != 300 {
+		t.Errorf("expected the partial file to be truncated to 300 bytes, got %d", info.Size())
+	}
+
+	var offsets []int64
+	var mu sync.Mutex
+	resumed := func(ctx context.Context, offset int64, length int64) (io.ReadCloser, error) {
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+		return bytesReader(content)(ctx, offset, length)
+	}
+	if err := downloadRanges(context.Background(), fileName, int64(len(content)), options, resumed); err != nil {
+		t.Fatalf("downloadRanges failed: %v", err)
+	}
+	if len(offsets) != 7 || offsets[0] != 300 {
+		t.Errorf("expected the download to resume from offset 300, got offsets %v", offsets)
+	}
+	downloaded, _ := os.ReadFile(fileName + partialSuffix)
+	if !bytes.Equal(downloaded, content) {
+		t.Error("resumed content does not match")
+	}
+}
+
+func TestCompleteFile(t *testing.T) {
+	content := []byte("model weights")
+	md5Sum := md5.Sum(content) // #nosec G401
+	crc32c := crc32.Checksum(content, crc32.MakeTable(crc32.Castagnoli))
+	wrongCRC32C := crc32c + 1
+
+	scenarios := map[string]struct {
+		checksums   Checksums
+		expectedErr bool
+	}{
+		"no checksums":    {checksums: Checksums{}},
+		"matching MD5":    {checksums: Checksums{MD5: md5Sum[:]}},
+		"matching CRC32C": {checksums: Checksums{CRC32C: &crc32c}},
+		"wrong MD5":       {checksums: Checksums{MD5: make([]byte, md5.Size)}, expectedErr: true},
+		"wrong CRC32C":    {checksums: Checksums{MD5: md5Sum[:], CRC32C: &wrongCRC32C}, expectedErr: true},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "model.bin")
+			if err := os.WriteFile(fileName+partialSuffix, content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			err := completeFile(fileName, scenario.checksums)
+			if scenario.expectedErr {
+				if !errors.Is(err, ErrChecksumMismatch) {
+					t.Fatalf("expected a checksum mismatch, got %v", err)
+				}
+				if FileExists(fileName) || FileExists(fileName+partialSuffix) {
+					t.Error("expected the mismatching file to be removed")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("completeFile failed: %v", err)
+			}
+			if !FileExists(fileName) || FileExists(fileName+partialSuffix) {
+				t.Error("expected the partial file to be renamed")
+			}
+			if !isComplete(fileName, int64(len(content)), scenario.checksums) {
+				t.Error("expected the file to be complete")
+			}
+		})
+	}
+}
+
+func TestDownloadOptionsSplit(t *testing.T) {
+	scenarios := map[string]struct {
+		options             DownloadOptions
+		files               int
+		expectedFileWorkers int
+		expectedPartWorkers int
+	}{
+		"single file":              {options: DownloadOptions{Workers: 8}, files: 1, expectedFileWorkers: 1, expectedPartWorkers: 8},
+		"fewer files than workers": {options: DownloadOptions{Workers: 8}, files: 3, expectedFileWorkers: 3, expectedPartWorkers: 2},
+		"more files than workers":  {options: DownloadOptions{Workers: 4}, files: 10, expectedFileWorkers: 4, expectedPartWorkers: 1},
+		"default workers":          {options: DownloadOptions{}, files: 2, expectedFileWorkers: 2, expectedPartWorkers: DefaultDownloadWorkers / 2},
+		"no files":                 {options: DownloadOptions{Workers: 4}, files: 0, expectedFileWorkers: 1, expectedPartWorkers: 4},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			fileWorkers, partOptions := scenario.options.split(scenario.files)
+			if fileWorkers != scenario.expectedFileWorkers || partOptions.workers() != scenario.expectedPartWorkers {
+				t.Errorf("expected %d file workers and %d part workers, got %d and %d", scenario.expectedFileWorkers,
+					scenario.expectedPartWorkers, fileWorkers, partOptions.workers())
+			}
+			if fileWorkers*partOptions.workers() > scenario.options.workers() {
+				t.Errorf("expected at most %d requests in flight, got %d", scenario.options.workers(), fileWorkers*partOptions.workers())
+			}
+		})
+	}
+}
+
+func TestMD5FromETag(t *testing.T) {
+	md5Sum := md5.Sum([]byte("model")) // #nosec G401
+	etag := `"` + hex.EncodeToString(md5Sum[:]) + `"`
+	if !bytes.Equal(MD5FromETag(etag), md5Sum[:]) {
+		t.Errorf("expected the MD5 of the ETag %s", etag)
+	}
+	// the ETag of a multipart upload is not the MD5 of the object
+	if MD5FromETag(`"`+hex.EncodeToString(md5Sum[:])+`-3"`) != nil {
+		t.Error("expected no MD5 for a multipart ETag")
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	err := forEach(context.Background(), 2, 6, func(ctx context.Context, i int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if i%2 == 1 {
+			return fmt.Errorf("file %d failed", i)
+		}
+		return nil
+	})
+	if maxRunning.Load() > 2 {
+		t.Errorf("expected at most 2 workers, got %d", maxRunning.Load())
+	}
+	for _, i := range []int{1, 3, 5} {
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("file %d failed", i)) {
+			t.Errorf("expected the error of file %d, got %v", i, err)
+		}
+	}
+}
+
+func TestDigestModelDir(t *testing.T) {
+	writeModel := func(t *testing.T, manifest string) string {
+		dir := t.TempDir()
+		files := map[string]string{
+			"model.bin":                 "weights",
+			"config/config.json":        "{}",
+			"SUCCESS.abc":               "{}",
+			"other.bin" + partialSuffix: "partial",
+		}
+		if manifest != "" {
+			files[ManifestFileName] = manifest
+		}
+		for name, content := range files {
+			if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o700); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+	sum := func(content string) string {
+		digest := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(digest[:])
+	}
+
+	t.Run("digest without manifest", func(t *testing.T) {
+		digest, err := DigestModelDir(writeModel(t, ""))
+		if err != nil {
+			t.Fatalf("DigestModelDir failed: %v", err)
+		}
+		expected := sum(sum("{}") + "  config/config.json\n" + sum("weights") + "  model.bin\n")
+		if digest != "sha256:"+expected {
+			t.Errorf("expected digest sha256:%s, got %s", expected, digest)
+		}
+	})
+
+	t.Run("matching manifest", func(t *testing.T) {
+		manifest := sum("weights") + "  model.bin\n" + sum("{}") + " *./config/config.json\n"
+		if _, err := DigestModelDir(writeModel(t, manifest)); err != nil {
+			t.Fatalf("DigestModelDir failed: %v", err)
+		}
+	})
+
+	t.Run("mismatching manifest", func(t *testing.T) {
+		dir := writeModel(t, sum("other weights")+"  model.bin\n")
+		_, err := DigestModelDir(dir)
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("expected a checksum mismatch, got %v", err)
+		}
+		if FileExists(filepath.Join(dir, "model.bin")) {
+			t.Error("expected the mismatching file to be removed")
+		}
+	})
+
+	t.Run("file missing from the download", func(t *testing.T) {
+		_, err := DigestModelDir(writeModel(t, sum("tokenizer")+"  tokenizer.json\n"))
+		if err == nil || !strings.Contains(err.Error(), "tokenizer.json") {
+			t.Fatalf("expected the missing file to be reported, got %v", err)
+		}
+	})
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/kserve/kserve/pkg/credentials/azure"
 
 	gcscredential "github.com/kserve/kserve/pkg/credentials/gcs"
+	hfcredential "github.com/kserve/kserve/pkg/credentials/hf"
 	s3credential "github.com/kserve/kserve/pkg/credentials/s3"
 )
 
@@ -123,25 +124,31 @@ func (s *azureStaticTokenCredential) GetToken(ctx context.Context, opts policy.T
 	}, nil
 }
 
-func initializeAzureClient() (AzureClient, error) {
+// initializeAzureClient returns the client of the blob service of the account configured by the environment, and
+// the name of the account.
+func initializeAzureClient() (AzureClient, string, error) {
 	var azureClient AzureClient
 	clientOptions := azblob.ClientOptions{}
 	serviceUrl, ok := os.LookupEnv(azure.AzureServiceUrl)
 	if !ok {
 		accountName, ok := os.LookupEnv(azure.AzureAccountName)
 		if !ok {
-			return nil, fmt.Errorf("one of %s or %s is required", azure.AzureAccountName, azure.AzureServiceUrl)
+			return nil, "", fmt.Errorf("one of %s or %s is required", azure.AzureAccountName, azure.AzureServiceUrl)
 		}
 		serviceUrl = fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
 	}
+	account, err := azureAccountName(serviceUrl)
+	if err != nil {
+		return nil, "", err
+	}
 	if _, ok := os.LookupEnv(azure.AzureStorageAccessKey); ok {
 		defaultCred, err := azidentity.NewDefaultAzureCredential(nil)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		client, err := azblob.NewClient(serviceUrl, defaultCred, &clientOptions)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		azureClient = client
 	} else if token, ok := os.LookupEnv(azure.AzureAccessToken); ok {
@@ -150,7 +157,7 @@ func initializeAzureClient() (AzureClient, error) {
 		if ok {
 			seconds, err := strconv.ParseInt(expiresOnStr, 10, 64)
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
 			expiresOn = time.Unix(seconds, 0)
 		} else {
@@ -162,16 +169,22 @@ func initializeAzureClient() (AzureClient, error) {
 		}
 		client, err := azblob.NewClient(serviceUrl, &tokenCred, &clientOptions)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		azureClient = client
 	} else {
-		return nil, fmt.Errorf("one of %s or %s must be provided", azure.AzureStorageAccessKey, azure.AzureAccessToken)
+		return nil, "", fmt.Errorf("one of %s or %s must be provided", azure.AzureStorageAccessKey, azure.AzureAccessToken)
 	}
-	return azureClient, nil
+	return azureClient, account, nil
 }
 
 func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider, error) {
+	return GetProviderWithOptions(providers, protocol, DownloadOptions{})
+}
+
+// GetProviderWithOptions returns the provider of the protocol, initializing it with the download options
+// when it is not in the providers yet.
+func GetProviderWithOptions(providers map[Protocol]Provider, protocol Protocol, options DownloadOptions) (Provider, error) {
 	if provider, ok := providers[protocol]; ok {
 		return provider, nil
 	}
@@ -179,11 +192,11 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 	switch protocol {
 	case AZURE:
 		log.Info("Initializing Azure client")
-		azureClient, err := initializeAzureClient()
+		azureClient, account, err := initializeAzureClient()
 		if err != nil {
 			return nil, err
 		}
-		providers[AZURE] = &AzureProvider{Client: azureClient}
+		providers[AZURE] = &AzureProvider{Client: azureClient, Account: account, Options: options}
 	case GCS:
 		log.Info("Initializing GCS client")
 		var gcsClient *gstorage.Client
@@ -203,7 +216,8 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 		}
 
 		providers[GCS] = &GCSProvider{
-			Client: stiface.AdaptClient(gcsClient),
+			Client:  stiface.AdaptClient(gcsClient),
+			Options: options,
 		}
 	case S3:
 		log.Info("Initializing S3 client")
@@ -246,16 +260,43 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 		providers[S3] = &S3Provider{
 			Client:         s3Client,
 			TransferClient: transfermanager.New(s3Client),
+			Options:        options,
+		}
+	case PVC:
+		providers[PVC] = &PVCProvider{
+			MountPath: DefaultPVCMountPath,
+			Options:   options,
+		}
+	case HF:
+		log.Info("Initializing Hugging Face client")
+		endpoint, ok := os.LookupEnv(HuggingFaceEndpointEnvKey)
+		if !ok {
+			endpoint = DefaultHuggingFaceEndpoint
+		}
+		providers[HF] = &HuggingFaceProvider{
+			Client:   &http.Client{},
+			Endpoint: endpoint,
+			Token:    os.Getenv(hfcredential.HFTokenKey),
+			Options:  options,
+		}
+	case OCI:
+		insecure, _ := strconv.ParseBool(os.Getenv(OCIInsecureRegistryEnvKey))
+		providers[OCI] = &OCIProvider{
+			Client:       &http.Client{},
+			DockerConfig: os.Getenv(OCIDockerConfigEnvKey),
+			Insecure:     insecure,
 		}
 	case HTTPS:
 		httpsClient := &http.Client{}
 		providers[HTTPS] = &HTTPSProvider{
-			Client: httpsClient,
+			Client:  httpsClient,
+			Options: options,
 		}
 	case HTTP:
 		httpsClient := &http.Client{}
 		providers[HTTP] = &HTTPSProvider{
-			Client: httpsClient,
+			Client:  httpsClient,
+			Options: options,
 		}
 	}
 
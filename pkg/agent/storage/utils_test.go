@@ -26,6 +26,7 @@ import (
 	"github.com/onsi/gomega"
 
 	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/kserve/kserve/pkg/credentials/azure"
 )
 
 func TestCreate(t *testing.T) {
@@ -101,6 +102,9 @@ func TestRemoveDir(t *testing.T) {
 
 func TestGetProvider(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
+	// The Azure client is created from the credentials injected by the credential builder
+	t.Setenv(azure.AzureAccountName, "account")
+	t.Setenv(azure.AzureAccessToken, "token")
 
 	// When providers map already have specified provider
 	mockProviders := map[Protocol]Provider{
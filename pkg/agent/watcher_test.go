@@ -334,8 +334,8 @@ var _ = Describe("Watcher", func() {
 						ModelDir: modelDir + "/test4",
 						Providers: map[storage.Protocol]storage.Provider{
 							storage.S3: &storage.S3Provider{
-								Client:         &mocks.MockS3Client{},
-								TransferClient: &mocks.MockS3FailTransferClient{Err: err},
+								Client:         mocks.NewMockS3FailClient(err),
+								TransferClient: &mocks.MockS3TransferClient{},
 							},
 						},
 						Logger: sugar,
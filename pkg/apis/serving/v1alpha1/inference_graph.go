@@ -21,6 +21,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
 )
 
 // InferenceGraph is the Schema for the InferenceGraph API for multiple models
@@ -86,6 +88,14 @@ type InferenceGraphSpec struct {
 	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Tracing enables the OTLP trace spans of the router for every node and step of the graph.
+	// When present (even as an empty object `{}`), tracing is enabled with the TracingSpec defaults.
+	// +optional
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+	// EnableMetrics exposes the Prometheus metrics of the nodes and steps of the graph
+	// on the /metrics path of the router.
+	// +optional
+	EnableMetrics bool `json:"enableMetrics,omitempty"`
 }
 
 // ScaleMetric enum
@@ -269,8 +279,80 @@ type InferenceRouter struct {
 	// Steps defines destinations for the current router node
 	// +optional
 	Steps []InferenceStep `json:"steps,omitempty"`
+
+	// Aggregation of the step responses of an Ensemble node, defaults to Merge
+	// +optional
+	Aggregation EnsembleAggregation `json:"aggregation,omitempty"`
+
+	// Number of steps of an Ensemble node which must succeed. When set, the failures and timeouts of
+	// the soft dependency steps are left out of the aggregation as long as the quorum is met.
+	// Otherwise, any step error fails the node.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Quorum *int32 `json:"quorum,omitempty"`
+
+	// Sticky routes the requests of a Splitter node by the hash of a key read from the request
+	// instead of randomly, so that the requests with the same key go to the same step for the
+	// given weights. The requests without the key are routed randomly.
+	// +optional
+	Sticky *SplitterStickiness `json:"sticky,omitempty"`
 }
 
+// SplitterStickiness defines the key of the requests which a Splitter node routes consistently.
+// Exactly one of header and field must be set.
+// +k8s:openapi-gen=true
+type SplitterStickiness struct {
+	// Header whose value is the key of the request
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Field is the gjson path (https://github.com/tidwall/gjson/blob/master/SYNTAX.md) of the field
+	// of the JSON request whose value is the key of the request
+	// +optional
+	Field string `json:"field,omitempty"`
+}
+
+// EnsembleAggregation defines how an Ensemble node combines the responses of its steps.
+//
+// - `Merge:` returns a map of the step responses keyed by step name, or by index for unnamed steps
+//
+// - `MajorityVote:` returns the most frequent prediction of every instance, the earliest step wins ties
+//
+// - `Mean:` returns the mean of the numeric predictions of every instance
+//
+// - `WeightedMean:` returns the mean of the numeric predictions of every instance weighted by the step weights
+//
+// - `MaxConfidence:` returns the prediction of every instance with the highest score, or the highest class probability
+//
+// - `FirstSuccessful:` returns the first successful step response
+//
+// The aggregations other than Merge and FirstSuccessful expect the `predictions` of the step responses to
+// be lists with one prediction per instance, and return a response with the aggregated `predictions`.
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=Merge;MajorityVote;Mean;WeightedMean;MaxConfidence;FirstSuccessful
+type EnsembleAggregation string
+
+// EnsembleAggregation Enum
+const (
+	// MergeAggregation Default aggregation returning the step responses keyed by step name
+	MergeAggregation EnsembleAggregation = "Merge"
+
+	// MajorityVoteAggregation returns the most frequent prediction of every instance
+	MajorityVoteAggregation EnsembleAggregation = "MajorityVote"
+
+	// MeanAggregation returns the mean of the predictions of every instance
+	MeanAggregation EnsembleAggregation = "Mean"
+
+	// WeightedMeanAggregation returns the mean of the predictions of every instance weighted by the step weights
+	WeightedMeanAggregation EnsembleAggregation = "WeightedMean"
+
+	// MaxConfidenceAggregation returns the most confident prediction of every instance
+	MaxConfidenceAggregation EnsembleAggregation = "MaxConfidence"
+
+	// FirstSuccessfulAggregation returns the first successful step response
+	FirstSuccessfulAggregation EnsembleAggregation = "FirstSuccessful"
+)
+
 // +k8s:openapi-gen=true
 // Exactly one InferenceTarget field must be specified
 type InferenceTarget struct {
@@ -284,6 +366,14 @@ type InferenceTarget struct {
 	// InferenceService URL, mutually exclusive with ServiceName
 	// +optional
 	ServiceURL string `json:"serviceUrl,omitempty"`
+
+	// Protocol used to call the service, either v1, v2 or grpc-v2. The grpc-v2 services are
+	// called with the ModelInfer method of the v2 GRPCInferenceService, for the model named in
+	// the /v2/models/<name>/infer path of the url. Defaults to the protocol of the predictor of
+	// the InferenceService, or to REST for the services given by url.
+	// +kubebuilder:validation:Enum=v1;v2;grpc-v2
+	// +optional
+	Protocol constants.InferenceServiceProtocol `json:"protocol,omitempty"`
 }
 
 // InferenceStepDependencyType constant for inference step dependency
@@ -300,6 +390,44 @@ const (
 	Hard InferenceStepDependencyType = "Hard"
 )
 
+// InferenceStepRetry defines how the router retries a failed inference step.
+// +k8s:openapi-gen=true
+type InferenceStepRetry struct {
+	// Max number of attempts of the step, including the first one. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Attempts *int32 `json:"attempts,omitempty"`
+
+	// Wait before the first retry (e.g. "100ms"), doubled with every retry. Defaults to "100ms".
+	// +optional
+	Backoff string `json:"backoff,omitempty"`
+
+	// Response status codes for which the step is retried. The step is always retried when the
+	// target cannot be reached. Defaults to 502, 503 and 504.
+	// +listType=set
+	// +optional
+	RetryableStatusCodes []int32 `json:"retryableStatusCodes,omitempty"`
+}
+
+// InferenceStepCache defines how the router caches the responses of an inference step.
+// +k8s:openapi-gen=true
+type InferenceStepCache struct {
+	// Time the responses are cached for (e.g. "30s", "10m").
+	TTL string `json:"ttl"`
+
+	// Max number of responses cached for the step, the least recently used ones are evicted
+	// first. Defaults to 1000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEntries *int32 `json:"maxEntries,omitempty"`
+
+	// Request headers which are part of the cache key, for the steps whose response depends on
+	// them. The other headers are ignored.
+	// +listType=set
+	// +optional
+	Headers []string `json:"headers,omitempty"`
+}
+
 // InferenceStep defines the inference target of the current step with condition, weights and data.
 // +k8s:openapi-gen=true
 type InferenceStep struct {
@@ -323,18 +451,65 @@ type InferenceStep struct {
 	// +optional
 	MapPredictionsToInstances bool `json:"mapPredictionsToInstances,omitempty"`
 
+	// If true, maps the 'outputs' tensors of the previous step's response to the
+	// 'inputs' tensors of this step's request, for services using the Open Inference
+	// Protocol (v2). The binary tensor data of the response is passed through as is.
+	// +optional
+	MapOutputsToInputs bool `json:"mapOutputsToInputs,omitempty"`
+
+	// Renames the tensors mapped by mapOutputsToInputs, from the output name of the
+	// previous step to the input name of this step. The other tensors keep their name.
+	// +optional
+	TensorMapping map[string]string `json:"tensorMapping,omitempty"`
+
+	// JSON template of the request sent to the step, used instead of data and
+	// mapPredictionsToInstances. Every {{ expression }} placeholder is replaced with the JSON
+	// value selected by a gjson path from $request, $response or the output of an earlier step
+	// of a Sequence node, e.g.
+	// {"instances": {{ $request.instances }}, "labels": {{ $steps.classifier.predictions }}}
+	// +optional
+	Input string `json:"input,omitempty"`
+
+	// JSON template of the step output built from the step response, which is $response in the
+	// template, and from the same sources as the input template.
+	// +optional
+	Output string `json:"output,omitempty"`
+
 	// the weight for split of the traffic, only used for Split Router
-	// when weight is specified all the routing targets should be sum to 100
+	// when weight is specified all the routing targets should be sum to 100.
+	// Also the weight of the step responses in the WeightedMean aggregation of an Ensemble router
 	// +optional
 	Weight *int64 `json:"weight,omitempty"`
 
-	// routing based on the condition
+	// routing based on the condition, a gjson path which matches when it selects a value, or with
+	// the cel: prefix, a CEL expression over request.body, request.headers and, in Sequence nodes,
+	// the previous step response. The step of a Switch node without a condition is its default step.
 	// +optional
 	Condition string `json:"condition,omitempty"`
 
 	// to decide whether a step is a hard or a soft dependency in the Inference Graph
 	// +optional
 	Dependency InferenceStepDependencyType `json:"dependency,omitempty"`
+
+	// Retry policy of the step. The step is attempted once when not set.
+	// +optional
+	Retry *InferenceStepRetry `json:"retry,omitempty"`
+
+	// Time limit of each attempt of the step (e.g. "500ms", "5s"), overriding the
+	// routerTimeouts.serviceClient timeout of the graph.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// Node or service called with the step request when the step still fails after its retries,
+	// either with an error or with an unsuccessful status code.
+	// +optional
+	Fallback *InferenceTarget `json:"fallback,omitempty"`
+
+	// Cache of the successful responses of the step, keyed by the step request and the cached
+	// headers. The step target is not called for the requests whose response is cached, so a
+	// step targeting a node caches the response of the whole node.
+	// +optional
+	Cache *InferenceStepCache `json:"cache,omitempty"`
 }
 
 // InferenceGraphStatus defines the InferenceGraph conditions and status
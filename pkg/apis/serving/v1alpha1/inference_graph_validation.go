@@ -21,7 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
+	"github.com/kserve/kserve/pkg/inferencegraph/condition"
+	"github.com/kserve/kserve/pkg/inferencegraph/template"
 	utils "github.com/kserve/kserve/pkg/utils"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -46,6 +49,40 @@ const (
 	TargetNotProvidedError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" does not specify an inference target"
 	// InvalidTargetError defines the error message for inference graph target specifies more than one of nodeName, serviceName, serviceUrl
 	InvalidTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" specifies more than one of nodeName, serviceName, serviceUrl"
+	// InvalidRetryAttemptsError defines the error message for a step retry policy with less than one attempt
+	InvalidRetryAttemptsError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" must be attempted at least once"
+	// InvalidRetryableStatusCodeError defines the error message for a retryable status code which is not an HTTP status code
+	InvalidRetryableStatusCodeError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid retryable status code %d"
+	// InvalidStepDurationError defines the error message for a step backoff, timeout or cache ttl which is not a positive duration
+	InvalidStepDurationError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid %s %q"
+	// InvalidFallbackError defines the error message for a step fallback which does not specify exactly one target
+	InvalidFallbackError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" must specify exactly one of nodeName, serviceName, serviceUrl as fallback"
+	// FallbackNodeNotFoundError defines the error message for a step falling back to a node which does not exist
+	FallbackNodeNotFoundError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" falls back to the node \"%s\" which does not exist"
+	// NodeProtocolError defines the error message for a protocol set on a step or fallback targeting a node
+	NodeProtocolError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" sets a protocol for the node \"%s\", which is only supported for services"
+	// EnsembleSettingsError defines the error message for an aggregation or quorum set on a node which is not an Ensemble
+	EnsembleSettingsError = "Node \"%s\" of InferenceGraph \"%s\" sets an aggregation or a quorum but is not an Ensemble node"
+	// InvalidQuorumError defines the error message for an ensemble quorum which cannot be met
+	InvalidQuorumError = "Node \"%s\" of InferenceGraph \"%s\" has a quorum of %d but only %d steps"
+	// PayloadMappingConflictError defines the error message for a step mapping both the v1 predictions and the v2 outputs
+	PayloadMappingConflictError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" cannot specify both mapPredictionsToInstances and mapOutputsToInputs"
+	// InvalidTensorMappingError defines the error message for a tensor mapping which is not used or which maps several outputs to the same input
+	InvalidTensorMappingError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid tensorMapping: %s"
+	// InvalidStepTemplateError defines the error message for a step input or output template which cannot be parsed
+	InvalidStepTemplateError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid %s template: %v"
+	// InputTemplateConflictError defines the error message for a step with both an input template and data or mapPredictionsToInstances
+	InputTemplateConflictError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" cannot specify data, mapPredictionsToInstances or mapOutputsToInputs with an input template"
+	// StepTemplateReferenceError defines the error message for a step template referring to a step which is not an earlier step of its sequence
+	StepTemplateReferenceError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" refers to the step \"%s\" which is not an earlier step of a Sequence node"
+	// InvalidConditionError defines the error message for a step condition which does not compile
+	InvalidConditionError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid condition: %v"
+	// MultipleDefaultStepsError defines the error message for a Switch node with more than one step without a condition
+	MultipleDefaultStepsError = "Node \"%s\" of InferenceGraph \"%s\" has more than one step without a condition, a Switch node has at most one default step"
+	// StickySettingsError defines the error message for a sticky key set on a node which is not a Splitter
+	StickySettingsError = "Node \"%s\" of InferenceGraph \"%s\" sets a sticky key but is not a Splitter node"
+	// InvalidStickyKeyError defines the error message for a sticky key which does not specify exactly one of header and field
+	InvalidStickyKeyError = "Node \"%s\" of InferenceGraph \"%s\" must specify exactly one of header and field as sticky key"
 )
 
 const (
@@ -129,6 +166,30 @@ func validateInferenceGraph(ig *InferenceGraph) (admission.Warnings, error) {
 	if err := validateInferenceGraphSplitterWeight(ig); err != nil {
 		return nil, err
 	}
+
+	if err := validateInferenceGraphSplitterStickiness(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphStepPolicies(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphStepTemplates(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphConditions(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphEnsembles(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphPayloadMappings(ig); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
@@ -177,6 +238,169 @@ func validateInferenceGraphSingleStepTargets(ig *InferenceGraph) error {
 	return nil
 }
 
+// Validation of the step retries, timeouts, fallbacks and caches
+func validateInferenceGraphStepPolicies(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, route := range node.Steps {
+			for _, target := range []*InferenceTarget{&route.InferenceTarget, route.Fallback} {
+				if target != nil && target.NodeName != "" && target.Protocol != "" {
+					return fmt.Errorf(NodeProtocolError, i, route.StepName, nodeName, ig.Name, target.NodeName)
+				}
+			}
+			if retry := route.Retry; retry != nil {
+				if retry.Attempts != nil && *retry.Attempts < 1 {
+					return fmt.Errorf(InvalidRetryAttemptsError, i, route.StepName, nodeName, ig.Name)
+				}
+				if retry.Backoff != "" && !isPositiveDuration(retry.Backoff) {
+					return fmt.Errorf(InvalidStepDurationError, i, route.StepName, nodeName, ig.Name, "retry backoff", retry.Backoff)
+				}
+				for _, code := range retry.RetryableStatusCodes {
+					if code < 100 || code > 599 {
+						return fmt.Errorf(InvalidRetryableStatusCodeError, i, route.StepName, nodeName, ig.Name, code)
+					}
+				}
+			}
+			if route.Timeout != "" && !isPositiveDuration(route.Timeout) {
+				return fmt.Errorf(InvalidStepDurationError, i, route.StepName, nodeName, ig.Name, "timeout", route.Timeout)
+			}
+			if cache := route.Cache; cache != nil && !isPositiveDuration(cache.TTL) {
+				return fmt.Errorf(InvalidStepDurationError, i, route.StepName, nodeName, ig.Name, "cache ttl", cache.TTL)
+			}
+			if fallback := route.Fallback; fallback != nil {
+				count := 0
+				for _, target := range []string{fallback.NodeName, fallback.ServiceName, fallback.ServiceURL} {
+					if target != "" {
+						count += 1
+					}
+				}
+				if count != 1 {
+					return fmt.Errorf(InvalidFallbackError, i, route.StepName, nodeName, ig.Name)
+				}
+				if _, ok := ig.Spec.Nodes[fallback.NodeName]; fallback.NodeName != "" && !ok {
+					return fmt.Errorf(FallbackNodeNotFoundError, i, route.StepName, nodeName, ig.Name, fallback.NodeName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the conditions of the Switch and Sequence steps
+func validateInferenceGraphConditions(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		var scope condition.Scope
+		switch node.RouterType {
+		case Switch:
+			scope = condition.SwitchScope
+		case Sequence:
+			scope = condition.SequenceScope
+		default:
+			continue
+		}
+		defaultSteps := 0
+		for i, route := range node.Steps {
+			if route.Condition == "" {
+				defaultSteps++
+				continue
+			}
+			if _, err := condition.Compile(route.Condition, scope); err != nil {
+				return fmt.Errorf(InvalidConditionError, i, route.StepName, nodeName, ig.Name, err)
+			}
+		}
+		if node.RouterType == Switch && defaultSteps > 1 {
+			return fmt.Errorf(MultipleDefaultStepsError, nodeName, ig.Name)
+		}
+	}
+	return nil
+}
+
+// Validation of the ensemble aggregations and quorums
+func validateInferenceGraphEnsembles(ig *InferenceGraph) error {
+	for name, node := range ig.Spec.Nodes {
+		if node.RouterType != Ensemble {
+			if node.Aggregation != "" || node.Quorum != nil {
+				return fmt.Errorf(EnsembleSettingsError, name, ig.Name)
+			}
+			continue
+		}
+		if node.Quorum != nil && int(*node.Quorum) > len(node.Steps) {
+			return fmt.Errorf(InvalidQuorumError, name, ig.Name, *node.Quorum, len(node.Steps))
+		}
+		if node.Aggregation == WeightedMeanAggregation {
+			for _, route := range node.Steps {
+				if route.Weight == nil {
+					return fmt.Errorf(WeightNotProvidedError, ig.Name, name, route.StepName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the mappings of the previous step responses to the step requests
+func validateInferenceGraphPayloadMappings(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, route := range node.Steps {
+			if route.MapPredictionsToInstances && route.MapOutputsToInputs {
+				return fmt.Errorf(PayloadMappingConflictError, i, route.StepName, nodeName, ig.Name)
+			}
+			if len(route.TensorMapping) == 0 {
+				continue
+			}
+			if !route.MapOutputsToInputs {
+				return fmt.Errorf(InvalidTensorMappingError, i, route.StepName, nodeName, ig.Name, "mapOutputsToInputs is not set")
+			}
+			inputs := sets.New[string]()
+			for _, input := range route.TensorMapping {
+				if input == "" || inputs.Has(input) {
+					return fmt.Errorf(InvalidTensorMappingError, i, route.StepName, nodeName, ig.Name, fmt.Sprintf("input name %q is empty or used more than once", input))
+				}
+				inputs.Insert(input)
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the step input and output templates
+func validateInferenceGraphStepTemplates(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		earlierSteps := sets.New[string]()
+		for i, route := range node.Steps {
+			if route.Input != "" && (route.Data != "" || route.MapPredictionsToInstances || route.MapOutputsToInputs) {
+				return fmt.Errorf(InputTemplateConflictError, i, route.StepName, nodeName, ig.Name)
+			}
+			for _, t := range []struct{ kind, text string }{{"input", route.Input}, {"output", route.Output}} {
+				kind, text := t.kind, t.text
+				if text == "" {
+					continue
+				}
+				tmpl, err := template.Parse(text)
+				if err != nil {
+					return fmt.Errorf(InvalidStepTemplateError, i, route.StepName, nodeName, ig.Name, kind, err)
+				}
+				for _, expression := range tmpl.Expressions() {
+					if expression.Source != template.StepsSource {
+						continue
+					}
+					if node.RouterType != Sequence || !earlierSteps.Has(expression.StepName) {
+						return fmt.Errorf(StepTemplateReferenceError, i, route.StepName, nodeName, ig.Name, expression.StepName)
+					}
+				}
+			}
+			if route.StepName != "" {
+				earlierSteps.Insert(route.StepName)
+			}
+		}
+	}
+	return nil
+}
+
+func isPositiveDuration(value string) bool {
+	duration, err := time.ParseDuration(value)
+	return err == nil && duration > 0
+}
+
 // Validation of inference graph name
 func validateInferenceGraphName(ig *InferenceGraph) error {
 	if !GraphRegexp.MatchString(ig.Name) {
@@ -215,3 +439,19 @@ func validateInferenceGraphSplitterWeight(ig *InferenceGraph) error {
 	}
 	return nil
 }
+
+// Validation of the sticky keys of the splitter nodes
+func validateInferenceGraphSplitterStickiness(ig *InferenceGraph) error {
+	for name, node := range ig.Spec.Nodes {
+		if node.Sticky == nil {
+			continue
+		}
+		if node.RouterType != Splitter {
+			return fmt.Errorf(StickySettingsError, name, ig.Name)
+		}
+		if (node.Sticky.Header == "") == (node.Sticky.Field == "") {
+			return fmt.Errorf(InvalidStickyKeyError, name, ig.Name)
+		}
+	}
+	return nil
+}
@@ -25,8 +25,16 @@ import (
 	"github.com/onsi/gomega/types"
 	"google.golang.org/protobuf/proto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/inferencegraph/condition"
 )
 
+func conditionError(text string, scope condition.Scope) error {
+	_, err := condition.Compile(text, scope)
+	return err
+}
+
 func makeTestInferenceGraph() InferenceGraph {
 	ig := InferenceGraph{
 		TypeMeta: metav1.TypeMeta{
@@ -260,6 +268,545 @@ func TestInferenceGraph_ValidateCreate(t *testing.T) {
 			errMatcher:      gomega.MatchError(fmt.Errorf(DuplicateStepNameError, GraphRootNodeName, "foo-bar", "step1")),
 			warningsMatcher: gomega.BeEmpty(),
 		},
+		"step with retries, timeout and fallback": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Retry: &InferenceStepRetry{
+								Attempts:             proto.Int32(3),
+								Backoff:              "200ms",
+								RetryableStatusCodes: []int32{429, 503},
+							},
+							Timeout: "1s",
+							Fallback: &InferenceTarget{
+								NodeName: "fallback",
+							},
+						},
+					},
+				},
+				"fallback": {},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"step retried zero times": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Retry: &InferenceStepRetry{
+								Attempts: proto.Int32(0),
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidRetryAttemptsError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid retryable status code": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Retry: &InferenceStepRetry{
+								RetryableStatusCodes: []int32{5000},
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidRetryableStatusCodeError, 0, "step1", GraphRootNodeName, "foo-bar", 5000)),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid step timeout": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Timeout: "10",
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepDurationError, 0, "step1", GraphRootNodeName, "foo-bar", "timeout", "10")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"fallback with more than one target": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Fallback: &InferenceTarget{
+								ServiceName: "service2",
+								ServiceURL:  "http://service2.local/",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidFallbackError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"fallback to unknown node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Fallback: &InferenceTarget{
+								NodeName: "missing",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(FallbackNodeNotFoundError, 0, "step1", GraphRootNodeName, "foo-bar", "missing")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sequence with templates": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Output: `{"labels": {{ $response.predictions }}}`,
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Input: `{"instances": {{ $request.instances }}, "labels": {{ $steps.step1.labels }}}`,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid input template": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Input: `{"instances": {{ $input.instances }}}`,
+						},
+					},
+				},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidStepTemplateError, 0, "step1", GraphRootNodeName, "foo-bar", "input",
+				errors.New(`expression "$input.instances" must start with $request, $response or $steps.<step name>`))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"input template with data": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Data:  "$response",
+							Input: `{{ $response }}`,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InputTemplateConflictError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"template referring to a later step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Input: `{"instances": {{ $steps.step2.predictions }}}`,
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(StepTemplateReferenceError, 0, "step1", GraphRootNodeName, "foo-bar", "step2")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"weighted mean ensemble with quorum": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:  "Ensemble",
+					Aggregation: WeightedMeanAggregation,
+					Quorum:      proto.Int32(1),
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							Weight:   proto.Int64(2),
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							Weight:   proto.Int64(1),
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"weighted mean without weight": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:  "Ensemble",
+					Aggregation: WeightedMeanAggregation,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(WeightNotProvidedError, "foo-bar", GraphRootNodeName, "step1")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"quorum larger than the ensemble": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Ensemble",
+					Quorum:     proto.Int32(2),
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidQuorumError, GraphRootNodeName, "foo-bar", 2, 1)),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"aggregation of a sequence": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:  "Sequence",
+					Aggregation: MajorityVoteAggregation,
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(EnsembleSettingsError, GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"v2 sequence with tensor mapping": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Data:               "$response",
+							MapOutputsToInputs: true,
+							TensorMapping:      map[string]string{"OUTPUT0": "input-0"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"both v1 and v2 mapping": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							MapPredictionsToInstances: true,
+							MapOutputsToInputs:        true,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(PayloadMappingConflictError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"tensor mapping without output mapping": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							TensorMapping: map[string]string{"OUTPUT0": "input-0"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidTensorMappingError, 0, "step1", GraphRootNodeName, "foo-bar", "mapOutputsToInputs is not set")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"grpc step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceURL: "http://triton.default.svc/v2/models/densenet/infer",
+								Protocol:   constants.ProtocolGRPCV2,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"switch conditions with a default step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName:        "premium",
+							InferenceTarget: InferenceTarget{ServiceName: "large"},
+							Condition:       `cel:request.headers["x-tier"] == "premium" && request.body.score > 0.5`,
+						},
+						{
+							StepName:        "legacy",
+							InferenceTarget: InferenceTarget{ServiceName: "legacy"},
+							Condition:       "instances",
+						},
+						{
+							StepName:        "default",
+							InferenceTarget: InferenceTarget{ServiceName: "small"},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid condition": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName:        "step1",
+							InferenceTarget: InferenceTarget{ServiceName: "large"},
+							Condition:       `cel:response.predictions[0] > 0.5`,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidConditionError, 0, "step1", GraphRootNodeName, "foo-bar", conditionError(`cel:response.predictions[0] > 0.5`, condition.SwitchScope))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"multiple default steps": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{StepName: "step1", InferenceTarget: InferenceTarget{ServiceName: "small"}},
+						{StepName: "step2", InferenceTarget: InferenceTarget{ServiceName: "large"}},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(MultipleDefaultStepsError, GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sticky splitter": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Sticky:     &SplitterStickiness{Header: "x-user-id"},
+					Steps: []InferenceStep{
+						{StepName: "stable", InferenceTarget: InferenceTarget{ServiceName: "stable"}, Weight: proto.Int64(90)},
+						{StepName: "canary", InferenceTarget: InferenceTarget{ServiceName: "canary"}, Weight: proto.Int64(10)},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sticky key of a switch node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Sticky:     &SplitterStickiness{Field: "user.id"},
+					Steps: []InferenceStep{
+						{StepName: "step1", InferenceTarget: InferenceTarget{ServiceName: "model"}},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(StickySettingsError, GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sticky header and field": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Sticky:     &SplitterStickiness{Header: "x-user-id", Field: "user.id"},
+					Steps: []InferenceStep{
+						{StepName: "step1", InferenceTarget: InferenceTarget{ServiceName: "model"}, Weight: proto.Int64(100)},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStickyKeyError, GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"cached step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "embed",
+							InferenceTarget: InferenceTarget{ServiceName: "embedder"},
+							Cache:           &InferenceStepCache{TTL: "10m", MaxEntries: proto.Int32(100), Headers: []string{"x-tenant"}},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid cache ttl": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "embed",
+							InferenceTarget: InferenceTarget{ServiceName: "embedder"},
+							Cache:           &InferenceStepCache{},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepDurationError, 0, "embed", GraphRootNodeName, "foo-bar", "cache ttl", "")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"protocol of a node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: "Sequence",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								NodeName: "other",
+								Protocol: constants.ProtocolGRPCV2,
+							},
+						},
+					},
+				},
+				"other": {},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(NodeProtocolError, 0, "step1", GraphRootNodeName, "foo-bar", "other")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
 	}
 
 	validator := InferenceGraphValidator{}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -33,6 +35,19 @@ type TrainedModelStatus struct {
 	// Addressable endpoint for the deployed trained model
 	// http://&lt;inferenceservice.metadata.name&gt;/v1/models/&lt;trainedmodel&gt;.metadata.name
 	Address *duckv1.Addressable `json:"address,omitempty"`
+	// Shard is the id of the shard of the parent InferenceService which the trained model is assigned to,
+	// each shard being served by its own set of predictor replicas
+	// +optional
+	Shard *int32 `json:"shard,omitempty"`
+	// Replicas is the number of predictor replicas of the shard reporting the state of their models
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// LoadedReplicas is the number of predictor replicas which loaded the trained model
+	// +optional
+	LoadedReplicas int32 `json:"loadedReplicas,omitempty"`
+	// FailedReplicas is the number of predictor replicas which failed to download or load the trained model
+	// +optional
+	FailedReplicas int32 `json:"failedReplicas,omitempty"`
 }
 
 // ConditionType represents a Service condition value
@@ -43,6 +58,10 @@ const (
 	MemoryResourceAvailable apis.ConditionType = "MemoryResourceAvailable"
 	// IsMMSPredictor is set when inference service predictor is set to multi-model serving
 	IsMMSPredictor apis.ConditionType = "IsMMSPredictor"
+	// Loaded is set when all the predictor replicas of the shard loaded the trained model
+	Loaded apis.ConditionType = "Loaded"
+	// Failed is set when a predictor replica of the shard failed to download or load the trained model
+	Failed apis.ConditionType = "Failed"
 )
 
 // TrainedModel Ready condition is depending on inference service readiness condition
@@ -85,3 +104,51 @@ func (ss *TrainedModelStatus) SetCondition(conditionType apis.ConditionType, con
 		conditionSet.Manage(ss).MarkFalse(conditionType, condition.Reason, condition.Message)
 	}
 }
+
+// PropagateLoadStatus sets the Loaded and Failed conditions from the state of the trained model reported
+// by the predictor replicas of its shard. They are informational and do not change the readiness, which
+// only tells whether the trained model can be deployed.
+func (ss *TrainedModelStatus) PropagateLoadStatus(replicas, loaded, failed int32, failure string) {
+	ss.Replicas, ss.LoadedReplicas, ss.FailedReplicas = replicas, loaded, failed
+	manager := conditionSet.Manage(ss)
+	switch {
+	case replicas == 0:
+		manager.SetCondition(apis.Condition{
+			Type:     Loaded,
+			Status:   corev1.ConditionUnknown,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "NoReplicas",
+			Message:  "No predictor replica of the shard is running",
+		})
+	case loaded == replicas:
+		manager.SetCondition(apis.Condition{
+			Type:     Loaded,
+			Status:   corev1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Message:  fmt.Sprintf("%d/%d replicas loaded the model", loaded, replicas),
+		})
+	default:
+		manager.SetCondition(apis.Condition{
+			Type:     Loaded,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "ModelNotLoaded",
+			Message:  fmt.Sprintf("%d/%d replicas loaded the model", loaded, replicas),
+		})
+	}
+	if failed > 0 {
+		manager.SetCondition(apis.Condition{
+			Type:     Failed,
+			Status:   corev1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Reason:   "ModelLoadFailed",
+			Message:  fmt.Sprintf("%d/%d replicas failed to load the model: %s", failed, replicas, failure),
+		})
+	} else {
+		manager.SetCondition(apis.Condition{
+			Type:     Failed,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityInfo,
+		})
+	}
+}
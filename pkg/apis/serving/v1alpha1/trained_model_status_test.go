@@ -375,3 +375,58 @@ func TestTrainedModelStatus_SetCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestTrainedModelStatus_PropagateLoadStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cases := []struct {
+		name            string
+		replicas        int32
+		loaded          int32
+		failed          int32
+		failure         string
+		expectedLoaded  corev1.ConditionStatus
+		expectedFailed  corev1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name:            "no replicas",
+			expectedLoaded:  corev1.ConditionUnknown,
+			expectedFailed:  corev1.ConditionFalse,
+			expectedMessage: "No predictor replica of the shard is running",
+		}, {
+			name:            "loaded by all the replicas",
+			replicas:        2,
+			loaded:          2,
+			expectedLoaded:  corev1.ConditionTrue,
+			expectedFailed:  corev1.ConditionFalse,
+			expectedMessage: "2/2 replicas loaded the model",
+		}, {
+			name:            "failed on a replica",
+			replicas:        2,
+			loaded:          1,
+			failed:          1,
+			failure:         "mms-predictor-abc: failed to download model",
+			expectedLoaded:  corev1.ConditionFalse,
+			expectedFailed:  corev1.ConditionTrue,
+			expectedMessage: "1/2 replicas loaded the model",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := TrainedModelStatus{}
+			status.PropagateLoadStatus(tc.replicas, tc.loaded, tc.failed, tc.failure)
+			g.Expect(status.Replicas).To(gomega.Equal(tc.replicas))
+			g.Expect(status.LoadedReplicas).To(gomega.Equal(tc.loaded))
+			g.Expect(status.FailedReplicas).To(gomega.Equal(tc.failed))
+			g.Expect(status.GetCondition(Loaded).Status).To(gomega.Equal(tc.expectedLoaded))
+			g.Expect(status.GetCondition(Loaded).Message).To(gomega.Equal(tc.expectedMessage))
+			g.Expect(status.GetCondition(Failed).Status).To(gomega.Equal(tc.expectedFailed))
+			if tc.failed > 0 {
+				g.Expect(status.GetCondition(Failed).Message).To(gomega.ContainSubstring(tc.failure))
+			}
+			// the load status is informational
+			g.Expect(status.IsReady()).To(gomega.BeFalse())
+		})
+	}
+}
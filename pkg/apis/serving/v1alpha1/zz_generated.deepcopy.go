@@ -492,6 +492,11 @@ func (in *InferenceGraphSpec) DeepCopyInto(out *InferenceGraphSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphSpec.
@@ -560,6 +565,16 @@ func (in *InferenceRouter) DeepCopyInto(out *InferenceRouter) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sticky != nil {
+		in, out := &in.Sticky, &out.Sticky
+		*out = new(SplitterStickiness)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceRouter.
@@ -576,11 +591,33 @@ func (in *InferenceRouter) DeepCopy() *InferenceRouter {
 func (in *InferenceStep) DeepCopyInto(out *InferenceStep) {
 	*out = *in
 	out.InferenceTarget = in.InferenceTarget
+	if in.TensorMapping != nil {
+		in, out := &in.TensorMapping, &out.TensorMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Weight != nil {
 		in, out := &in.Weight, &out.Weight
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(InferenceStepRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(InferenceTarget)
+		**out = **in
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(InferenceStepCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStep.
@@ -593,6 +630,56 @@ func (in *InferenceStep) DeepCopy() *InferenceStep {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceStepCache) DeepCopyInto(out *InferenceStepCache) {
+	*out = *in
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStepCache.
+func (in *InferenceStepCache) DeepCopy() *InferenceStepCache {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceStepCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceStepRetry) DeepCopyInto(out *InferenceStepRetry) {
+	*out = *in
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryableStatusCodes != nil {
+		in, out := &in.RetryableStatusCodes, &out.RetryableStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStepRetry.
+func (in *InferenceStepRetry) DeepCopy() *InferenceStepRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceStepRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceTarget) DeepCopyInto(out *InferenceTarget) {
 	*out = *in
@@ -1955,6 +2042,21 @@ func (in *ServingRuntimeStatus) DeepCopy() *ServingRuntimeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplitterStickiness) DeepCopyInto(out *SplitterStickiness) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitterStickiness.
+func (in *SplitterStickiness) DeepCopy() *SplitterStickiness {
+	if in == nil {
+		return nil
+	}
+	out := new(SplitterStickiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageContainerSpec) DeepCopyInto(out *StorageContainerSpec) {
 	*out = *in
@@ -2221,6 +2323,11 @@ func (in *TrainedModelStatus) DeepCopyInto(out *TrainedModelStatus) {
 		*out = new(duckv1.Addressable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Shard != nil {
+		in, out := &in.Shard, &out.Shard
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainedModelStatus.
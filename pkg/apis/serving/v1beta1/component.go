@@ -19,8 +19,10 @@ package v1beta1
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -37,10 +39,14 @@ const (
 	MinReplicasLowerBoundExceededError               = "'MinReplicas' cannot be less than 0"
 	MaxReplicasLowerBoundExceededError               = "'MaxReplicas' cannot be less than 0"
 	ParallelismLowerBoundExceededError               = "parallelism cannot be less than 0"
+	BatcherLowerBoundExceededError                   = "batcher %s cannot be less than 0"
 	UnsupportedStorageURIFormatError                 = "storageUri, must be one of: [%s] or match https://{}.blob.core.windows.net/{}/{} or be an absolute or relative local path. StorageUri [%s] is not supported"
 	UnsupportedStorageSpecFormatError                = "storage.spec.type, must be one of: [%s]. storage.spec.type [%s] is not supported"
 	InvalidLoggerType                                = "invalid logger type"
 	InvalidLoggerStorageConfigError                  = "invalid logger storage configuration"
+	InvalidLoggerDurationError                       = "invalid logger %s duration %q"
+	InvalidLoggerBackoffError                        = "logger retry maxBackoff must not be less than initialBackoff"
+	InvalidLoggerDeadLetterURLError                  = "invalid logger dead letter url %q"
 	InvalidISVCNameFormatError                       = "the InferenceService \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
 	InvalidProtocol                                  = "invalid protocol %s. Must be one of [%s]"
 	MissingStorageURI                                = "the InferenceService %q is invalid: StorageURI must be set for multinode enabled"
@@ -343,6 +349,7 @@ func (s *ComponentExtensionSpec) Validate() error {
 		validateContainerConcurrency(s.ContainerConcurrency),
 		validateReplicas(s.MinReplicas, s.MaxReplicas),
 		validateLogger(s.Logger),
+		validateBatcher(s.Batcher),
 	})
 }
 
@@ -397,9 +404,22 @@ func validateContainerConcurrency(containerConcurrency *int64) error {
 	return nil
 }
 
+func validateBatcher(batcher *Batcher) error {
+	if batcher == nil {
+		return nil
+	}
+	if batcher.TargetLatency != nil && *batcher.TargetLatency < 0 {
+		return fmt.Errorf(BatcherLowerBoundExceededError, "targetLatency")
+	}
+	if batcher.MaxQueueDepth != nil && *batcher.MaxQueueDepth < 0 {
+		return fmt.Errorf(BatcherLowerBoundExceededError, "maxQueueDepth")
+	}
+	return nil
+}
+
 func validateLogger(logger *LoggerSpec) error {
 	if logger != nil {
-		if logger.Mode != LogAll && logger.Mode != LogRequest && logger.Mode != LogResponse {
+		if logger.Mode != LogAll && logger.Mode != LogRequest && logger.Mode != LogResponse && logger.Mode != LogFailures {
 			return errors.New(InvalidLoggerType)
 		}
 		if logger.Storage != nil {
@@ -407,11 +427,46 @@ func validateLogger(logger *LoggerSpec) error {
 				return errors.New(InvalidLoggerStorageConfigError)
 			}
 		}
+		if retry := logger.Retry; retry != nil {
+			initialBackoff, err := parseLoggerDuration("retry.initialBackoff", retry.InitialBackoff)
+			if err != nil {
+				return err
+			}
+			maxBackoff, err := parseLoggerDuration("retry.maxBackoff", retry.MaxBackoff)
+			if err != nil {
+				return err
+			}
+			if initialBackoff > 0 && maxBackoff > 0 && maxBackoff < initialBackoff {
+				return errors.New(InvalidLoggerBackoffError)
+			}
+		}
+		if logger.CircuitBreaker != nil {
+			if _, err := parseLoggerDuration("circuitBreaker.resetTimeout", logger.CircuitBreaker.ResetTimeout); err != nil {
+				return err
+			}
+		}
+		if logger.DeadLetterURL != nil {
+			if u, err := url.Parse(*logger.DeadLetterURL); err != nil || u.Scheme == "" {
+				return fmt.Errorf(InvalidLoggerDeadLetterURLError, *logger.DeadLetterURL)
+			}
+		}
 	}
 
 	return nil
 }
 
+// parseLoggerDuration parses an optional positive duration of the logger spec.
+func parseLoggerDuration(field string, value *string) (time.Duration, error) {
+	if value == nil {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(*value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf(InvalidLoggerDurationError, field, *value)
+	}
+	return duration, nil
+}
+
 func validateExactlyOneImplementation(component Component) error {
 	if len(component.GetImplementations()) != 1 {
 		return ExactlyOneErrorFor(component)
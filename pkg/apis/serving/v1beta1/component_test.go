@@ -155,6 +155,12 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 			},
 			matcher: gomega.BeNil(),
 		},
+		"LoggerWithLogFailuresMode": {
+			logger: &LoggerSpec{
+				Mode: LogFailures,
+			},
+			matcher: gomega.BeNil(),
+		},
 		"LoggerWithHeaderMetadata": {
 			logger: &LoggerSpec{
 				Mode:            LogAll,
@@ -186,6 +192,50 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 			},
 			matcher: gomega.MatchError(errors.New(InvalidLoggerStorageConfigError)),
 		},
+		"LoggerWithRetryAndDeadLetter": {
+			logger: &LoggerSpec{
+				Mode: LogAll,
+				Retry: &LoggerRetrySpec{
+					MaxAttempts:    ptr.To(5),
+					InitialBackoff: ptr.To("200ms"),
+					MaxBackoff:     ptr.To("30s"),
+				},
+				CircuitBreaker: &LoggerCircuitBreakerSpec{
+					FailureThreshold: ptr.To(3),
+					ResetTimeout:     ptr.To("1m"),
+				},
+				DeadLetterURL: ptr.To("s3://bucket/dead-letter"),
+			},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidRetryBackoff": {
+			logger: &LoggerSpec{
+				Mode:  LogAll,
+				Retry: &LoggerRetrySpec{InitialBackoff: ptr.To("fast")},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerDurationError, "retry.initialBackoff", "fast")),
+		},
+		"RetryMaxBackoffLessThanInitial": {
+			logger: &LoggerSpec{
+				Mode:  LogAll,
+				Retry: &LoggerRetrySpec{InitialBackoff: ptr.To("10s"), MaxBackoff: ptr.To("1s")},
+			},
+			matcher: gomega.MatchError(errors.New(InvalidLoggerBackoffError)),
+		},
+		"InvalidCircuitBreakerResetTimeout": {
+			logger: &LoggerSpec{
+				Mode:           LogAll,
+				CircuitBreaker: &LoggerCircuitBreakerSpec{ResetTimeout: ptr.To("-1s")},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerDurationError, "circuitBreaker.resetTimeout", "-1s")),
+		},
+		"DeadLetterURLWithoutScheme": {
+			logger: &LoggerSpec{
+				Mode:          LogAll,
+				DeadLetterURL: ptr.To("dead-letter"),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerDeadLetterURLError, "dead-letter")),
+		},
 	}
 	for name, scenario := range scenarios {
 		t.Run(name, func(t *testing.T) {
@@ -194,6 +244,49 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 	}
 }
 
+func TestComponentExtensionSpec_validateBatcher(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		batcher *Batcher
+		matcher types.GomegaMatcher
+	}{
+		"BatcherIsNil": {
+			batcher: nil,
+			matcher: gomega.BeNil(),
+		},
+		"BatcherWithTargetLatency": {
+			batcher: &Batcher{
+				MaxBatchSize:  ptr.To(32),
+				TargetLatency: ptr.To(100),
+			},
+			matcher: gomega.BeNil(),
+		},
+		"NegativeTargetLatency": {
+			batcher: &Batcher{
+				TargetLatency: ptr.To(-1),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(BatcherLowerBoundExceededError, "targetLatency")),
+		},
+		"BatcherWithMaxQueueDepth": {
+			batcher: &Batcher{
+				MaxQueueDepth: ptr.To(100),
+			},
+			matcher: gomega.BeNil(),
+		},
+		"NegativeMaxQueueDepth": {
+			batcher: &Batcher{
+				MaxQueueDepth: ptr.To(-1),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(BatcherLowerBoundExceededError, "maxQueueDepth")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateBatcher(scenario.batcher)).To(scenario.matcher)
+		})
+	}
+}
+
 func TestFirstNonNilComponent(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	spec := PredictorSpec{
@@ -77,7 +77,7 @@ type LoggerStorageSpec struct {
 }
 
 // LoggerType controls the scope of log publishing
-// +kubebuilder:validation:Enum=all;request;response
+// +kubebuilder:validation:Enum=all;request;response;failures
 type LoggerType string
 
 // LoggerType Enum
@@ -88,8 +88,66 @@ const (
 	LogRequest LoggerType = "request"
 	// LogResponse Logger mode to log only response
 	LogResponse LoggerType = "response"
+	// LogFailures Logger mode to log request and response of failed calls only
+	LogFailures LoggerType = "failures"
 )
 
+// LoggerRedactionMode controls how redacted values are replaced in the logs
+// +kubebuilder:validation:Enum=mask;hash
+type LoggerRedactionMode string
+
+// LoggerRedactionMode Enum
+const (
+	// RedactionMask replaces redacted values with a fixed placeholder
+	RedactionMask LoggerRedactionMode = "mask"
+	// RedactionHash replaces redacted values with their SHA-256 hash, so that equal values can still be correlated
+	RedactionHash LoggerRedactionMode = "hash"
+)
+
+// LoggerRedactionSpec specifies the payload fields and metadata headers to redact before logging
+type LoggerRedactionSpec struct {
+	// GJSON paths of the request and response body fields to redact, e.g. "inputs.#.data" or "messages.#.content".
+	// Bodies which are not valid JSON are redacted as a whole when fields are set.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+	// Names of the metadata HTTP headers to redact.
+	// +optional
+	Headers []string `json:"headers,omitempty"`
+	// Specifies how the redacted values are replaced. <br />
+	// Valid values are: <br />
+	// - "mask" (default): replace with a fixed placeholder; <br />
+	// - "hash": replace with the SHA-256 hash of the value <br />
+	// +optional
+	Mode LoggerRedactionMode `json:"mode,omitempty"`
+}
+
+// LoggerRetrySpec specifies how the failed deliveries of the logger cloud events are retried
+type LoggerRetrySpec struct {
+	// Max number of delivery attempts of an event, including the first one. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// Wait before the first retry (e.g. "100ms"). The wait doubles with every retry, with a random
+	// jitter. Defaults to "100ms".
+	// +optional
+	InitialBackoff *string `json:"initialBackoff,omitempty"`
+	// Max wait between two delivery attempts. Defaults to "10s".
+	// +optional
+	MaxBackoff *string `json:"maxBackoff,omitempty"`
+}
+
+// LoggerCircuitBreakerSpec specifies when the logger stops delivering to an unavailable destination
+type LoggerCircuitBreakerSpec struct {
+	// Number of consecutive failed deliveries to a destination which opens its circuit. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int `json:"failureThreshold,omitempty"`
+	// Time during which the deliveries to a destination with an open circuit fail right away,
+	// after which a trial delivery is let through (e.g. "30s"). Defaults to "30s".
+	// +optional
+	ResetTimeout *string `json:"resetTimeout,omitempty"`
+}
+
 // LoggerSpec specifies optional payload logging available for all components
 type LoggerSpec struct {
 	// URL to send logging events
@@ -99,7 +157,8 @@ type LoggerSpec struct {
 	// Valid values are: <br />
 	// - "all" (default): log both request and response; <br />
 	// - "request": log only request; <br />
-	// - "response": log only response <br />
+	// - "response": log only response; <br />
+	// - "failures": log request and response of the calls which failed <br />
 	// +optional
 	Mode LoggerType `json:"mode,omitempty"`
 	// Matched metadata HTTP headers for propagating to inference logger cloud events.
@@ -122,6 +181,31 @@ type LoggerSpec struct {
 	// Only used when BatchSize > 1. Defaults to "0" (no time-based flushing).
 	// +optional
 	BatchInterval *string `json:"batchInterval,omitempty"`
+	// Max number of bytes of the request and response bodies captured in a log record.
+	// Larger bodies are truncated and the record is marked as truncated. Defaults to no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxBodySize *int `json:"maxBodySize,omitempty"`
+	// Redaction policy applied to the payloads and metadata headers before they are logged.
+	// +optional
+	Redaction *LoggerRedactionSpec `json:"redaction,omitempty"`
+	// Percentage of the calls to log. Sampling is deterministic by request ID, so the request and
+	// the response of a sampled call are always logged together. Defaults to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingPercentage *int `json:"samplingPercentage,omitempty"`
+	// Retry policy of the cloud events which fail to be delivered to the logger URL.
+	// By default, a single delivery attempt is made.
+	// +optional
+	Retry *LoggerRetrySpec `json:"retry,omitempty"`
+	// Circuit breaking of the logger URL destinations.
+	// +optional
+	CircuitBreaker *LoggerCircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+	// Logger URL receiving the cloud events which could not be delivered after all the retries,
+	// e.g. "s3://bucket/dead-letter". Any URL supported by the logger can be used.
+	// +optional
+	DeadLetterURL *string `json:"deadLetterUrl,omitempty"`
 }
 
 // MetricsBackend enum
@@ -152,6 +236,32 @@ type Batcher struct {
 	// Specifies the timeout of a batch
 	// +optional
 	Timeout *int `json:"timeout,omitempty"`
+	// Specifies the target p99 latency of the model server in milliseconds. When set, the batch
+	// size of each model is adjusted between 1 and maxBatchSize to keep the latency under the target.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TargetLatency *int `json:"targetLatency,omitempty"`
+	// Specifies the max number of requests waiting for a batch response per model. Requests
+	// beyond the limit are rejected with 503 and a Retry-After header.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxQueueDepth *int `json:"maxQueueDepth,omitempty"`
+	// Specifies the max batch size and the max latency of the models which are batched differently
+	// from the other models served by the predictor.
+	// +optional
+	ModelBatchConfigs []ModelBatchConfig `json:"modelBatchConfigs,omitempty"`
+}
+
+// ModelBatchConfig overrides the batching configuration of a single model
+type ModelBatchConfig struct {
+	// Name of the model, as in the path of its requests
+	Name string `json:"name"`
+	// Specifies the max number of requests to trigger a batch of the model
+	// +kubebuilder:validation:Minimum=1
+	MaxBatchSize int `json:"maxBatchSize"`
+	// Specifies the max latency to trigger a batch of the model
+	// +kubebuilder:validation:Minimum=1
+	MaxLatency int `json:"maxLatency"`
 }
 
 // InferenceService is the Schema for the InferenceServices API
@@ -634,6 +634,21 @@ func TestBadParallelismValues(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestBadBatcherValues(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Spec.Predictor.Batcher = &Batcher{TargetLatency: ptr.To(-100)}
+	validator := InferenceServiceValidator{}
+	warnings, err := validator.ValidateCreate(t.Context(), &isvc)
+	g.Expect(err).Should(gomega.MatchError(fmt.Sprintf(BatcherLowerBoundExceededError, "targetLatency")))
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	isvc.Spec.Predictor.Batcher = &Batcher{MaxQueueDepth: ptr.To(-1)}
+	warnings, err = validator.ValidateCreate(t.Context(), &isvc)
+	g.Expect(err).Should(gomega.MatchError(fmt.Sprintf(BatcherLowerBoundExceededError, "maxQueueDepth")))
+	g.Expect(warnings).Should(gomega.BeEmpty())
+}
+
 func TestBadReplicaValues(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
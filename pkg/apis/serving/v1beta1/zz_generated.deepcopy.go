@@ -122,6 +122,21 @@ func (in *Batcher) DeepCopyInto(out *Batcher) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.TargetLatency != nil {
+		in, out := &in.TargetLatency, &out.TargetLatency
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxQueueDepth != nil {
+		in, out := &in.MaxQueueDepth, &out.MaxQueueDepth
+		*out = new(int)
+		**out = **in
+	}
+	if in.ModelBatchConfigs != nil {
+		in, out := &in.ModelBatchConfigs, &out.ModelBatchConfigs
+		*out = make([]ModelBatchConfig, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Batcher.
@@ -677,6 +692,86 @@ func (in *LightGBMSpec) DeepCopy() *LightGBMSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerCircuitBreakerSpec) DeepCopyInto(out *LoggerCircuitBreakerSpec) {
+	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int)
+		**out = **in
+	}
+	if in.ResetTimeout != nil {
+		in, out := &in.ResetTimeout, &out.ResetTimeout
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerCircuitBreakerSpec.
+func (in *LoggerCircuitBreakerSpec) DeepCopy() *LoggerCircuitBreakerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerCircuitBreakerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerRedactionSpec) DeepCopyInto(out *LoggerRedactionSpec) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerRedactionSpec.
+func (in *LoggerRedactionSpec) DeepCopy() *LoggerRedactionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerRedactionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerRetrySpec) DeepCopyInto(out *LoggerRetrySpec) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerRetrySpec.
+func (in *LoggerRetrySpec) DeepCopy() *LoggerRetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerRetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggerSpec) DeepCopyInto(out *LoggerSpec) {
 	*out = *in
@@ -715,6 +810,36 @@ func (in *LoggerSpec) DeepCopyInto(out *LoggerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		*out = new(int)
+		**out = **in
+	}
+	if in.Redaction != nil {
+		in, out := &in.Redaction, &out.Redaction
+		*out = new(LoggerRedactionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(LoggerRetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(LoggerCircuitBreakerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeadLetterURL != nil {
+		in, out := &in.DeadLetterURL, &out.DeadLetterURL
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerSpec.
@@ -812,6 +937,21 @@ func (in *MetricsSpec) DeepCopy() *MetricsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelBatchConfig) DeepCopyInto(out *ModelBatchConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelBatchConfig.
+func (in *ModelBatchConfig) DeepCopy() *ModelBatchConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelBatchConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelCopies) DeepCopyInto(out *ModelCopies) {
 	*out = *in
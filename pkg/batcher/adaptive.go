@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// AdaptiveWindow is the number of batches observed before the batch size is adjusted.
+	AdaptiveWindow = 20
+	// adaptiveHeadroom is the fraction of the target latency under which the batch size grows.
+	adaptiveHeadroom = 0.8
+)
+
+// adaptiveSizer grows or shrinks the batch size of a queue from the observed
+// model server latency. Every AdaptiveWindow batches the p99 latency of the
+// window is compared with the target: the batch size is cut by a quarter when
+// the target is exceeded, and grows by one while there is enough headroom.
+type adaptiveSizer struct {
+	size      int
+	max       int
+	target    time.Duration
+	latencies []time.Duration
+}
+
+func newAdaptiveSizer(maxBatchSize int, target time.Duration) *adaptiveSizer {
+	return &adaptiveSizer{
+		size:      maxBatchSize,
+		max:       maxBatchSize,
+		target:    target,
+		latencies: make([]time.Duration, 0, AdaptiveWindow),
+	}
+}
+
+func (s *adaptiveSizer) observe(latency time.Duration) {
+	s.latencies = append(s.latencies, latency)
+	if len(s.latencies) < AdaptiveWindow {
+		return
+	}
+	p99 := percentile(s.latencies, 0.99)
+	switch {
+	case p99 > s.target:
+		s.size = max(1, s.size*3/4)
+	case float64(p99) < float64(s.target)*adaptiveHeadroom:
+		s.size = min(s.max, s.size+1)
+	}
+	// Start a new window so that the next adjustment only sees the new batch size.
+	s.latencies = s.latencies[:0]
+}
+
+// percentile returns the nearest-rank percentile of the latencies.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	rank := int(float64(len(sorted))*p+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestAdaptiveSizer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	sizer := newAdaptiveSizer(32, 100*time.Millisecond)
+	g.Expect(sizer.size).To(gomega.Equal(32))
+
+	// a single slow batch in the window exceeds the target p99
+	for i := range AdaptiveWindow {
+		latency := 10 * time.Millisecond
+		if i == 0 {
+			latency = 200 * time.Millisecond
+		}
+		sizer.observe(latency)
+	}
+	g.Expect(sizer.size).To(gomega.Equal(24))
+
+	// latency within the target but without headroom keeps the batch size
+	for range AdaptiveWindow {
+		sizer.observe(90 * time.Millisecond)
+	}
+	g.Expect(sizer.size).To(gomega.Equal(24))
+
+	// fast batches grow the batch size up to the max batch size
+	for range 20 * AdaptiveWindow {
+		sizer.observe(10 * time.Millisecond)
+	}
+	g.Expect(sizer.size).To(gomega.Equal(32))
+
+	// slow batches shrink the batch size down to 1
+	for range 20 * AdaptiveWindow {
+		sizer.observe(time.Second)
+	}
+	g.Expect(sizer.size).To(gomega.Equal(1))
+}
+
+func TestPercentile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	g.Expect(percentile(latencies, 0.99)).To(gomega.Equal(99 * time.Millisecond))
+	g.Expect(percentile(latencies, 0.5)).To(gomega.Equal(50 * time.Millisecond))
+	g.Expect(percentile(latencies[:1], 0.99)).To(gomega.Equal(100 * time.Millisecond))
+}
@@ -20,20 +20,36 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/constants"
 )
 
 const (
-	SleepTime    = time.Microsecond * 100
 	MaxBatchSize = 32
 	MaxLatency   = 5000
+	// QueueIdleTimeout is the time after which the queue of a model path which received no request is removed
+	QueueIdleTimeout = time.Minute
+)
+
+// errQueueFull is returned when a request arrives while the queue of its model is full.
+var errQueueFull = errors.New("batcher queue is full")
+
+var (
+	predictVerb = regexp.MustCompile(`([^/:]+):predict$`)
+	inferPath   = regexp.MustCompile(`^/v2/models/([^/]+)(/versions/[^/]+)?/infer$`)
 )
 
 type Request struct {
@@ -43,19 +59,28 @@ type Request struct {
 type Input struct {
 	ContextInput *context.Context
 	Path         string
+	Protocol     constants.InferenceServiceProtocol
 	Instances    *[]interface{}
+	InferRequest *V2Request
+	BatchSize    int
+	Arrival      time.Time
 	ChannelOut   *chan Response
 }
 
 type InputInfo struct {
 	ChannelOut *chan Response
 	Index      []int
+	Arrival    time.Time
 }
 
 type Response struct {
 	Message     string        `json:"message"`
 	BatchID     string        `json:"batchId"`
 	Predictions []interface{} `json:"predictions"`
+	// InferResponse holds the caller's share of a v2 batch response.
+	InferResponse *V2Response `json:"-"`
+	// StatusCode is the status code returned to v2 callers when Message reports an error.
+	StatusCode int `json:"-"`
 }
 
 type ResponseError struct {
@@ -68,14 +93,22 @@ type PredictionResponse struct {
 
 type BatcherInfo struct {
 	Path               string
+	Protocol           constants.InferenceServiceProtocol
 	BatchID            string
 	Request            *http.Request
 	Instances          []interface{}
 	PredictionResponse PredictionResponse
-	ContextMap         map[*context.Context]InputInfo
-	Start              time.Time
-	Now                time.Time
-	CurrentInputLen    int
+	InferRequest       V2Request
+	InferResponse      V2Response
+	// Signature identifies the v2 requests which can be concatenated with the current batch.
+	Signature string
+	// Pending holds the requests of the current batch so that the batch can be
+	// rebuilt without the callers which gave up before it is dispatched.
+	Pending         []Input
+	ContextMap      map[*context.Context]InputInfo
+	Start           time.Time
+	Now             time.Time
+	CurrentInputLen int
 }
 
 func GetNowTime() time.Time {
@@ -91,23 +124,86 @@ func (batcherInfo *BatcherInfo) InitializeInfo() {
 	batcherInfo.CurrentInputLen = 0
 	batcherInfo.Instances = make([]interface{}, 0)
 	batcherInfo.PredictionResponse = PredictionResponse{}
+	batcherInfo.InferRequest = V2Request{}
+	batcherInfo.InferResponse = V2Response{}
+	batcherInfo.Signature = ""
+	batcherInfo.Pending = make([]Input, 0)
 	batcherInfo.ContextMap = make(map[*context.Context]InputInfo)
 	batcherInfo.Start = GetNowTime()
 	batcherInfo.Now = batcherInfo.Start
 }
 
-func (handler *BatchHandler) batchPredict() {
+// sendError reports the same error to every request of the current batch.
+func (queue *batchQueue) sendError(message string, statusCode int) {
+	for _, v := range queue.batcherInfo.ContextMap {
+		res := Response{
+			Message:    message,
+			BatchID:    queue.batcherInfo.BatchID,
+			StatusCode: statusCode,
+		}
+		*v.ChannelOut <- res
+	}
+}
+
+func (queue *batchQueue) batchInfer() {
+	queue.batcherInfo.BatchID = GenerateUUID()
+	queue.batcherInfo.InferRequest.Id = queue.batcherInfo.BatchID
+	jsonStr, _ := json.Marshal(queue.batcherInfo.InferRequest)
+	r := httptest.NewRequest(http.MethodPost, queue.batcherInfo.Path, bytes.NewReader(jsonStr))
+	r.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	queue.next.ServeHTTP(rr, r)
+	responseBody := rr.Body.Bytes()
+	if rr.Code != http.StatusOK {
+		queue.log.Errorf("error response with code %v", rr)
+		queue.sendError(string(responseBody), rr.Code)
+		return
+	}
+	if err := json.Unmarshal(responseBody, &queue.batcherInfo.InferResponse); err != nil {
+		queue.sendError(err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Split every output first so that a malformed response fails all the callers alike.
+	outputs := make(map[*context.Context][]V2Tensor, len(queue.batcherInfo.ContextMap))
+	for k, v := range queue.batcherInfo.ContextMap {
+		split, err := splitOutputs(queue.batcherInfo.InferResponse.Outputs, queue.batcherInfo.CurrentInputLen, v.Index)
+		if err != nil {
+			queue.sendError(err.Error(), http.StatusInternalServerError)
+			return
+		}
+		outputs[k] = split
+	}
+	for k, v := range queue.batcherInfo.ContextMap {
+		res := Response{
+			BatchID: queue.batcherInfo.BatchID,
+			InferResponse: &V2Response{
+				ModelName:    queue.batcherInfo.InferResponse.ModelName,
+				ModelVersion: queue.batcherInfo.InferResponse.ModelVersion,
+				Parameters:   queue.batcherInfo.InferResponse.Parameters,
+				Outputs:      outputs[k],
+			},
+		}
+		*v.ChannelOut <- res
+	}
+}
+
+func (queue *batchQueue) batchPredict() {
+	if queue.batcherInfo.Protocol == constants.ProtocolV2 {
+		queue.batchInfer()
+		queue.batcherInfo.InitializeInfo()
+		return
+	}
 	jsonStr, _ := json.Marshal(Request{
-		handler.batcherInfo.Instances,
+		queue.batcherInfo.Instances,
 	})
 	reader := bytes.NewReader(jsonStr)
-	r := httptest.NewRequest(http.MethodPost, handler.batcherInfo.Path, reader)
+	r := httptest.NewRequest(http.MethodPost, queue.batcherInfo.Path, reader)
 	rr := httptest.NewRecorder()
-	handler.next.ServeHTTP(rr, r)
+	queue.next.ServeHTTP(rr, r)
 	responseBody := rr.Body.Bytes()
 	if rr.Code != http.StatusOK {
-		handler.log.Errorf("error response with code %v", rr)
-		for _, v := range handler.batcherInfo.ContextMap {
+		queue.log.Errorf("error response with code %v", rr)
+		for _, v := range queue.batcherInfo.ContextMap {
 			res := Response{
 				Message:     string(responseBody),
 				BatchID:     "",
@@ -116,34 +212,34 @@ func (handler *BatchHandler) batchPredict() {
 			*v.ChannelOut <- res
 		}
 	} else {
-		handler.batcherInfo.BatchID = GenerateUUID()
-		err := json.Unmarshal(responseBody, &handler.batcherInfo.PredictionResponse)
+		queue.batcherInfo.BatchID = GenerateUUID()
+		err := json.Unmarshal(responseBody, &queue.batcherInfo.PredictionResponse)
 		if err != nil {
-			for _, v := range handler.batcherInfo.ContextMap {
+			for _, v := range queue.batcherInfo.ContextMap {
 				res := Response{
 					Message: err.Error(),
-					BatchID: handler.batcherInfo.BatchID,
+					BatchID: queue.batcherInfo.BatchID,
 				}
 				*v.ChannelOut <- res
 			}
 		} else {
-			if len(handler.batcherInfo.PredictionResponse.Predictions) != len(handler.batcherInfo.Instances) {
-				for _, v := range handler.batcherInfo.ContextMap {
+			if len(queue.batcherInfo.PredictionResponse.Predictions) != len(queue.batcherInfo.Instances) {
+				for _, v := range queue.batcherInfo.ContextMap {
 					res := Response{
 						Message: "size of prediction is not equal to the size of instances",
-						BatchID: handler.batcherInfo.BatchID,
+						BatchID: queue.batcherInfo.BatchID,
 					}
 					*v.ChannelOut <- res
 				}
 			} else {
-				for _, v := range handler.batcherInfo.ContextMap {
+				for _, v := range queue.batcherInfo.ContextMap {
 					predictions := make([]interface{}, 0, len(v.Index))
 					for _, i := range v.Index {
-						predictions = append(predictions, handler.batcherInfo.PredictionResponse.Predictions[i])
+						predictions = append(predictions, queue.batcherInfo.PredictionResponse.Predictions[i])
 					}
 					res := Response{
 						Message:     "",
-						BatchID:     handler.batcherInfo.BatchID,
+						BatchID:     queue.batcherInfo.BatchID,
 						Predictions: predictions,
 					}
 					*v.ChannelOut <- res
@@ -151,78 +247,279 @@ func (handler *BatchHandler) batchPredict() {
 			}
 		}
 	}
-	handler.batcherInfo.InitializeInfo()
+	queue.batcherInfo.InitializeInfo()
+}
+
+// compatible reports whether the request can be added to the current batch. v2 requests
+// whose tensors cannot be concatenated with the batched ones have to wait for the
+// current batch to be sent.
+func (queue *batchQueue) compatible(req Input) bool {
+	if queue.batcherInfo.CurrentInputLen == 0 {
+		return true
+	}
+	return req.Protocol != constants.ProtocolV2 || req.InferRequest.signature() == queue.batcherInfo.Signature
+}
+
+func (queue *batchQueue) add(req Input) {
+	if queue.batcherInfo.CurrentInputLen == 0 {
+		queue.batcherInfo.Start = GetNowTime()
+	}
+	queue.batcherInfo.Pending = append(queue.batcherInfo.Pending, req)
+	queue.appendInput(req)
+	queueDepth.WithLabelValues(queue.model).Observe(float64(len(queue.batcherInfo.ContextMap)))
+}
+
+// appendInput appends the instances or input tensors of the request to the current batch.
+func (queue *batchQueue) appendInput(req Input) {
+	queue.batcherInfo.Path = req.Path
+	queue.batcherInfo.Protocol = req.Protocol
+	if req.Protocol == constants.ProtocolV2 {
+		if queue.batcherInfo.CurrentInputLen == 0 {
+			queue.batcherInfo.Signature = req.InferRequest.signature()
+			queue.batcherInfo.InferRequest.Parameters = req.InferRequest.Parameters
+			queue.batcherInfo.InferRequest.Outputs = req.InferRequest.Outputs
+		}
+		queue.batcherInfo.InferRequest.Inputs = concatInputs(queue.batcherInfo.InferRequest.Inputs, req.InferRequest.Inputs)
+	} else {
+		queue.batcherInfo.Instances = append(queue.batcherInfo.Instances, *req.Instances...)
+	}
+	index := make([]int, 0, req.BatchSize)
+	for i := range req.BatchSize {
+		index = append(index, queue.batcherInfo.CurrentInputLen+i)
+	}
+	queue.batcherInfo.ContextMap[req.ContextInput] = InputInfo{
+		ChannelOut: req.ChannelOut,
+		Index:      index,
+		Arrival:    req.Arrival,
+	}
+	queue.batcherInfo.CurrentInputLen += req.BatchSize
+}
+
+// prune removes the requests whose caller has gone away, either cancelled or timed
+// out, from the current batch so that their instances are not sent to the model server.
+func (queue *batchQueue) prune() {
+	pending := make([]Input, 0, len(queue.batcherInfo.Pending))
+	for _, req := range queue.batcherInfo.Pending {
+		if (*req.ContextInput).Err() == nil {
+			pending = append(pending, req)
+		}
+	}
+	dropped := len(queue.batcherInfo.Pending) - len(pending)
+	if dropped == 0 {
+		return
+	}
+	queue.log.Infof("dropping %d cancelled requests from the batch %s", dropped, queue.path)
+	cancelledRequests.WithLabelValues(queue.model).Add(float64(dropped))
+	queue.batcherInfo.Pending = pending
+	queue.batcherInfo.Instances = make([]interface{}, 0)
+	queue.batcherInfo.InferRequest.Inputs = nil
+	queue.batcherInfo.ContextMap = make(map[*context.Context]InputInfo)
+	queue.batcherInfo.CurrentInputLen = 0
+	for _, req := range pending {
+		queue.appendInput(req)
+	}
+}
+
+// dispatch sends the current batch to the model server and adjusts the batch size
+// from the observed model server latency when adaptive batching is enabled.
+func (queue *batchQueue) dispatch() {
+	queue.prune()
+	if queue.batcherInfo.CurrentInputLen == 0 {
+		queue.batcherInfo.InitializeInfo()
+		return
+	}
+	queue.log.Infof("batch predict with size %d %s", queue.batcherInfo.CurrentInputLen, queue.batcherInfo.Path)
+	start := GetNowTime()
+	batchSize.WithLabelValues(queue.model).Observe(float64(queue.batcherInfo.CurrentInputLen))
+	for _, v := range queue.batcherInfo.ContextMap {
+		waitTime.WithLabelValues(queue.model).Observe(start.Sub(v.Arrival).Seconds())
+	}
+	queue.batchPredict()
+	if queue.sizer != nil {
+		queue.sizer.observe(GetNowTime().Sub(start))
+		effectiveBatchSize.WithLabelValues(queue.model).Set(float64(queue.sizer.size))
+	}
+}
+
+// maxBatchSize returns the batch size which triggers a prediction, which is
+// adjusted by the adaptive sizer when it is enabled.
+func (queue *batchQueue) maxBatchSize() int {
+	if queue.sizer != nil {
+		return queue.sizer.size
+	}
+	return queue.MaxBatchSize
 }
 
-func (handler *BatchHandler) batch() {
-	handler.log.Infof("Starting batch loop maxLatency:%d, maxBatchSize:%d",
-		handler.MaxLatency, handler.MaxBatchSize)
+// batch accumulates the requests of the queue into batches. It blocks until a request arrives while the
+// batch is empty, and waits at most until the max latency of the batch otherwise. The loop stops once the
+// queue has been idle for the idle timeout and the handler removed it.
+func (queue *batchQueue) batch() {
+	queue.log.Infof("Starting batch loop for %s maxLatency:%d, maxBatchSize:%d",
+		queue.path, queue.MaxLatency, queue.MaxBatchSize)
 	for {
-		select {
-		case req := <-handler.channelIn:
-			if len(handler.batcherInfo.Instances) == 0 {
-				handler.batcherInfo.Start = GetNowTime()
-			}
-			handler.batcherInfo.Path = req.Path
-			handler.batcherInfo.CurrentInputLen = len(handler.batcherInfo.Instances)
-			handler.batcherInfo.Instances = append(handler.batcherInfo.Instances, *req.Instances...)
-			index := make([]int, 0, len(*req.Instances))
-			for i := range len(*req.Instances) {
-				index = append(index, handler.batcherInfo.CurrentInputLen+i)
+		if queue.batcherInfo.CurrentInputLen == 0 {
+			idle := time.NewTimer(queue.idleTimeout)
+			select {
+			case req := <-queue.channelIn:
+				idle.Stop()
+				queue.add(req)
+			case <-idle.C:
+				if queue.remove() {
+					queue.log.Infof("Stopping batch loop for idle %s", queue.path)
+					return
+				}
+				continue
 			}
-			handler.batcherInfo.ContextMap[req.ContextInput] = InputInfo{
-				req.ChannelOut,
-				index,
+		} else {
+			deadline := time.NewTimer(queue.batcherInfo.Start.Add(time.Duration(queue.MaxLatency) * time.Millisecond).Sub(GetNowTime()))
+			select {
+			case req := <-queue.channelIn:
+				deadline.Stop()
+				if !queue.compatible(req) {
+					queue.dispatch()
+				}
+				queue.add(req)
+			case <-deadline.C:
 			}
-			handler.batcherInfo.CurrentInputLen = len(handler.batcherInfo.Instances)
-		case <-time.After(SleepTime):
 		}
-		handler.batcherInfo.Now = GetNowTime()
-		if handler.batcherInfo.CurrentInputLen >= handler.MaxBatchSize ||
-			(handler.batcherInfo.Now.Sub(handler.batcherInfo.Start).Milliseconds() >= int64(handler.MaxLatency) &&
-				handler.batcherInfo.CurrentInputLen > 0) {
-			handler.log.Infof("batch predict with size %d %s", len(handler.batcherInfo.Instances), handler.batcherInfo.Path)
-			handler.batchPredict()
+		queue.batcherInfo.Now = GetNowTime()
+		if queue.batcherInfo.CurrentInputLen >= queue.maxBatchSize() ||
+			(queue.batcherInfo.Now.Sub(queue.batcherInfo.Start).Milliseconds() >= int64(queue.MaxLatency) &&
+				queue.batcherInfo.CurrentInputLen > 0) {
+			queue.dispatch()
 		}
 	}
 }
 
-func (handler *BatchHandler) Consume() {
-	if handler.MaxBatchSize <= 0 {
-		handler.MaxBatchSize = MaxBatchSize
-	}
-	if handler.MaxLatency <= 0 {
-		handler.MaxLatency = MaxLatency
-	}
-	handler.batcherInfo.InitializeInfo()
-	handler.batch()
+// QueueConfig overrides the batching configuration of a single model.
+type QueueConfig struct {
+	MaxBatchSize int
+	MaxLatency   int
 }
 
-type BatchHandler struct {
+// batchQueue accumulates the requests for one model path into batches.
+type batchQueue struct {
+	path string
+	// model labels the metrics of the queue, which is the name of the model for the models with a
+	// batch config, and otherModel for the others
+	model        string
 	next         http.Handler
 	log          *zap.SugaredLogger
 	channelIn    chan Input
 	MaxBatchSize int
 	MaxLatency   int
 	batcherInfo  BatcherInfo
+	sizer        *adaptiveSizer
+	idleTimeout  time.Duration
+	// remove removes the queue from the handler, unless a request is about to be sent to it.
+	remove func() bool
+	// waiting counts the requests accepted by the queue which are still waiting for a response.
+	waiting atomic.Int64
+}
+
+type BatchHandler struct {
+	next         http.Handler
+	log          *zap.SugaredLogger
+	MaxBatchSize int
+	MaxLatency   int
+	// TargetLatency enables adaptive batching when set. The batch size of every
+	// queue is then adjusted so that the p99 latency of the model server, in
+	// milliseconds, stays under this target.
+	TargetLatency int
+	// ModelConfigs overrides MaxBatchSize and MaxLatency per model name.
+	ModelConfigs map[string]QueueConfig
+	// MaxQueueDepth limits the number of requests waiting in the queue of every model.
+	// Requests beyond the limit are rejected with 503 and a Retry-After header. The
+	// queues are unbounded when it is not set.
+	MaxQueueDepth int
+	// IdleTimeout is the time after which the queue of a model path which received no request is
+	// removed, QueueIdleTimeout by default. The paths come from the clients, so the queues of the
+	// paths which are no longer requested must not be kept.
+	IdleTimeout time.Duration
+	mu          sync.Mutex
+	queues      map[string]*batchQueue
 }
 
 func New(maxBatchSize int, maxLatency int, handler http.Handler, logger *zap.SugaredLogger) *BatchHandler {
-	batchHandler := BatchHandler{
+	if maxBatchSize <= 0 {
+		maxBatchSize = MaxBatchSize
+	}
+	if maxLatency <= 0 {
+		maxLatency = MaxLatency
+	}
+	return &BatchHandler{
 		next:         handler,
 		log:          logger,
-		channelIn:    make(chan Input),
 		MaxBatchSize: maxBatchSize,
 		MaxLatency:   maxLatency,
+		queues:       map[string]*batchQueue{},
+	}
+}
+
+// getQueue returns the batch queue of the given model path, starting a new one
+// on the first request for the path. The request is counted as waiting on the queue
+// before the handler lock is released, so that the queue is not removed before the
+// request is sent to it, and the count of waiting requests including it is returned.
+func (handler *BatchHandler) getQueue(path string, modelName string) (*batchQueue, int64) {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if queue, ok := handler.queues[path]; ok {
+		return queue, queue.waiting.Add(1)
+	}
+	queue := &batchQueue{
+		path:         path,
+		model:        otherModel,
+		next:         handler.next,
+		log:          handler.log,
+		channelIn:    make(chan Input),
+		MaxBatchSize: handler.MaxBatchSize,
+		MaxLatency:   handler.MaxLatency,
+		idleTimeout:  handler.IdleTimeout,
+	}
+	if queue.idleTimeout <= 0 {
+		queue.idleTimeout = QueueIdleTimeout
+	}
+	if config, ok := handler.ModelConfigs[modelName]; ok {
+		queue.model = modelName
+		if config.MaxBatchSize > 0 {
+			queue.MaxBatchSize = config.MaxBatchSize
+		}
+		if config.MaxLatency > 0 {
+			queue.MaxLatency = config.MaxLatency
+		}
+	}
+	if handler.TargetLatency > 0 {
+		queue.sizer = newAdaptiveSizer(queue.MaxBatchSize, time.Duration(handler.TargetLatency)*time.Millisecond)
+	}
+	queue.remove = func() bool {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		if queue.waiting.Load() > 0 {
+			return false
+		}
+		delete(handler.queues, path)
+		for _, other := range handler.queues {
+			if other.model == queue.model {
+				return true
+			}
+		}
+		deleteMetrics(queue.model)
+		return true
 	}
-	go batchHandler.Consume()
-	return &batchHandler
+	queue.batcherInfo.InitializeInfo()
+	handler.queues[path] = queue
+	go queue.batch()
+	return queue, queue.waiting.Add(1)
 }
 
 func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// only batch predict requests
-	predictVerb := regexp.MustCompile(`:predict$`)
-	if !predictVerb.MatchString(r.URL.Path) {
+	// only batch predict and infer requests
+	if match := inferPath.FindStringSubmatch(r.URL.Path); match != nil {
+		handler.serveInfer(w, r, match[1])
+		return
+	}
+	match := predictVerb.FindStringSubmatch(r.URL.Path)
+	if match == nil {
 		handler.next.ServeHTTP(w, r)
 		return
 	}
@@ -243,17 +540,19 @@ func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	handler.log.Infof("serving request %s", r.URL.Path)
-	ctx := context.Background()
-	chl := make(chan Response)
-	handler.channelIn <- Input{
-		&ctx,
-		r.URL.Path,
-		&req.Instances,
-		&chl,
+	queue, waiting := handler.getQueue(r.URL.Path, match[1])
+	response, err := handler.enqueue(r.Context(), queue, waiting, Input{
+		Path:      r.URL.Path,
+		Protocol:  constants.ProtocolV1,
+		Instances: &req.Instances,
+		BatchSize: len(req.Instances),
+	})
+	if err != nil {
+		if statusCode := handler.rejectionStatus(w, queue, err); statusCode != 0 {
+			http.Error(w, err.Error(), statusCode)
+		}
+		return
 	}
-
-	response := <-chl
-	close(chl)
 	rspbytes, err := json.Marshal(response)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -264,3 +563,108 @@ func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// serveInfer batches Open Inference Protocol (v2) requests. The input tensors are
+// concatenated along the batch dimension and the output tensors are split back
+// so that every caller receives the rows of its own inputs.
+func (handler *BatchHandler) serveInfer(w http.ResponseWriter, r *http.Request, modelName string) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeInferError(w, "can't read body", http.StatusBadRequest)
+		return
+	}
+	var req V2Request
+	if err = json.Unmarshal(body, &req); err != nil {
+		writeInferError(w, "can't Unmarshal body", http.StatusBadRequest)
+		return
+	}
+	batchSize, err := req.validate()
+	if err != nil {
+		writeInferError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	handler.log.Infof("serving request %s", r.URL.Path)
+	queue, waiting := handler.getQueue(r.URL.Path, modelName)
+	response, err := handler.enqueue(r.Context(), queue, waiting, Input{
+		Path:         r.URL.Path,
+		Protocol:     constants.ProtocolV2,
+		InferRequest: &req,
+		BatchSize:    batchSize,
+	})
+	if err != nil {
+		if statusCode := handler.rejectionStatus(w, queue, err); statusCode != 0 {
+			writeInferError(w, err.Error(), statusCode)
+		}
+		return
+	}
+	if response.InferResponse == nil {
+		statusCode := response.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+		writeInferError(w, response.Message, statusCode)
+		return
+	}
+	response.InferResponse.Id = req.Id
+	rspbytes, err := json.Marshal(response.InferResponse)
+	if err != nil {
+		writeInferError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = w.Write(rspbytes); err != nil {
+		handler.log.Errorf("failed to write response: %v", err)
+	}
+}
+
+// enqueue sends the request to the batch queue and waits for its share of the batch
+// response. It gives up as soon as the caller's context is done, in which case the
+// request is dropped from the batch or its result is discarded. waiting is the count of
+// requests waiting on the queue returned by getQueue, which includes this one.
+func (handler *BatchHandler) enqueue(ctx context.Context, queue *batchQueue, waiting int64, req Input) (Response, error) {
+	defer queue.waiting.Add(-1)
+	if handler.MaxQueueDepth > 0 && waiting > int64(handler.MaxQueueDepth) {
+		rejectedRequests.WithLabelValues(queue.model).Inc()
+		return Response{}, errQueueFull
+	}
+	// The channel is buffered so that the batch loop never blocks on a caller which went away.
+	chl := make(chan Response, 1)
+	req.ContextInput = &ctx
+	req.Arrival = GetNowTime()
+	req.ChannelOut = &chl
+	select {
+	case queue.channelIn <- req:
+	case <-ctx.Done():
+		return Response{}, ctx.Err()
+	}
+	select {
+	case response := <-chl:
+		return response, nil
+	case <-ctx.Done():
+		return Response{}, ctx.Err()
+	}
+}
+
+// rejectionStatus returns the status code for a request which did not get a response
+// from the batch queue, or 0 when the caller has disconnected and nothing should be written.
+func (handler *BatchHandler) rejectionStatus(w http.ResponseWriter, queue *batchQueue, err error) int {
+	switch {
+	case errors.Is(err, errQueueFull):
+		handler.log.Warnf("rejecting request %s, queue is full", queue.path)
+		// Callers can retry once the queued requests have been dispatched, which takes at most MaxLatency.
+		retryAfter := int(math.Ceil(float64(queue.MaxLatency) / 1000))
+		w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return 0
+	}
+}
+
+func writeInferError(w http.ResponseWriter, message string, statusCode int) {
+	rspbytes, _ := json.Marshal(V2ResponseError{Error: message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(rspbytes)
+}
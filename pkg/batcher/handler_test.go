@@ -18,6 +18,7 @@ package batcher
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,10 +26,13 @@ import (
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	pkglogging "knative.dev/pkg/logging"
 )
 
@@ -172,3 +176,306 @@ func TestBatcherDefaults(t *testing.T) {
 	g.Expect(batchHandler.MaxBatchSize).To(gomega.Equal(MaxBatchSize))
 	g.Expect(batchHandler.MaxLatency).To(gomega.Equal(MaxLatency))
 }
+
+func serveInferRequest(batchHandler *BatchHandler, wg *sync.WaitGroup, index int, results chan<- V2Response) {
+	defer wg.Done()
+	request := fmt.Sprintf(`{"id": "%d", "inputs": [{"name": "input-0", "shape": [1, 3], "datatype": "FP32", "data": [[%d, %d, %d]]}]}`,
+		index, index, index, index)
+	r := httptest.NewRequest(http.MethodPost, "/v2/models/test/infer", bytes.NewReader([]byte(request)))
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	var res V2Response
+	_ = json.Unmarshal(b, &res)
+	results <- res
+}
+
+// Tests batching of Open Inference Protocol (v2) requests
+func TestBatcherV2(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	// Start a local HTTP server which returns its inputs as outputs
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		var request V2Request
+		err = json.Unmarshal(b, &request)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(request.Inputs).To(gomega.HaveLen(1))
+		g.Expect(request.Inputs[0].Data).To(gomega.HaveLen(int(elementCount(request.Inputs[0].Shape))))
+		logger.Infof("Get request %v", string(b))
+		response := V2Response{
+			ModelName: "test",
+			Id:        request.Id,
+			Outputs: []V2Tensor{{
+				Name:     "output-0",
+				Shape:    request.Inputs[0].Shape,
+				Datatype: request.Inputs[0].Datatype,
+				Data:     request.Inputs[0].Data,
+			}},
+		}
+		responseBytes, err := json.Marshal(response)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	batchHandler := New(32, 50, httpProxy, logger)
+	results := make(chan V2Response, 10)
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go serveInferRequest(batchHandler, &wg, i, results)
+	}
+	wg.Wait()
+	close(results)
+	for res := range results {
+		g.Expect(res.ModelName).To(gomega.Equal("test"))
+		g.Expect(res.Outputs).To(gomega.HaveLen(1))
+		g.Expect(res.Outputs[0].Shape).To(gomega.Equal([]int64{1, 3}))
+		// every caller gets back the rows of its own inputs
+		index, err := strconv.Atoi(res.Id)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(res.Outputs[0].Data).To(gomega.Equal([]interface{}{float64(index), float64(index), float64(index)}))
+	}
+}
+
+// Tests that malformed v2 requests are rejected before batching
+func TestBatcherV2InvalidRequest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	batchHandler := New(32, 50, http.NotFoundHandler(), logger)
+	scenarios := map[string]string{
+		"no inputs":           `{"inputs": []}`,
+		"batch size mismatch": `{"inputs": [{"name": "a", "shape": [2], "datatype": "FP32", "data": [1, 2]}, {"name": "b", "shape": [1], "datatype": "FP32", "data": [1]}]}`,
+		"shape mismatch":      `{"inputs": [{"name": "a", "shape": [1, 3], "datatype": "FP32", "data": [1, 2]}]}`,
+		"missing datatype":    `{"inputs": [{"name": "a", "shape": [1], "data": [1]}]}`,
+	}
+	for name, request := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v2/models/test/infer", bytes.NewReader([]byte(request)))
+			w := httptest.NewRecorder()
+			batchHandler.ServeHTTP(w, r)
+			g.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest))
+			var res V2ResponseError
+			g.Expect(json.Unmarshal(w.Body.Bytes(), &res)).To(gomega.Succeed())
+			g.Expect(res.Error).ToNot(gomega.BeEmpty())
+		})
+	}
+}
+
+// Tests that requests for different model paths are batched separately
+func TestBatcherPerModelQueues(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var mu sync.Mutex
+	batches := map[string][]int{}
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		var request Request
+		err = json.Unmarshal(b, &request)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		mu.Lock()
+		batches[req.URL.Path] = append(batches[req.URL.Path], len(request.Instances))
+		mu.Unlock()
+		responseBytes, err := json.Marshal(Response{Predictions: request.Instances})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	batchHandler := New(32, 500, httpProxy, logger)
+	batchHandler.ModelConfigs = map[string]QueueConfig{
+		"small": {MaxBatchSize: 2, MaxLatency: 500},
+	}
+	var wg sync.WaitGroup
+	for i := range 4 {
+		for _, model := range []string{"small", "large"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				request := fmt.Sprintf(`{"instances": [[%d]]}`, i)
+				r := httptest.NewRequest(http.MethodPost, "/v1/models/"+model+":predict", bytes.NewReader([]byte(request)))
+				w := httptest.NewRecorder()
+				batchHandler.ServeHTTP(w, r)
+				var res Response
+				g.Expect(json.Unmarshal(w.Body.Bytes(), &res)).To(gomega.Succeed())
+				g.Expect(res.Predictions).To(gomega.Equal([]interface{}{[]interface{}{float64(i)}}))
+			}()
+		}
+	}
+	wg.Wait()
+	g.Expect(batches["/v1/models/small:predict"]).To(gomega.Equal([]int{2, 2}))
+	g.Expect(batches["/v1/models/large:predict"]).To(gomega.Equal([]int{4}))
+	g.Expect(batchHandler.queues).To(gomega.HaveLen(2))
+	g.Expect(batchHandler.queues["/v1/models/small:predict"].MaxBatchSize).To(gomega.Equal(2))
+	g.Expect(batchHandler.queues["/v1/models/large:predict"].MaxBatchSize).To(gomega.Equal(32))
+	// the metrics are labelled by the models with a batch config only
+	g.Expect(batchHandler.queues["/v1/models/small:predict"].model).To(gomega.Equal("small"))
+	g.Expect(batchHandler.queues["/v1/models/large:predict"].model).To(gomega.Equal(otherModel))
+}
+
+// Tests that the instances of a caller which gave up are not sent to the model server
+func TestBatcherCancelledRequest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	batches := make(chan int, 2)
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		var request Request
+		err = json.Unmarshal(b, &request)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		batches <- len(request.Instances)
+		responseBytes, err := json.Marshal(Response{Predictions: request.Instances})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	batchHandler := New(32, 300, httpProxy, logger)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/v1/models/test:predict", bytes.NewReader([]byte(`{"instances": [[1], [2]]}`)))
+		w := httptest.NewRecorder()
+		batchHandler.ServeHTTP(w, r)
+		g.Expect(w.Code).To(gomega.Equal(http.StatusGatewayTimeout))
+	}()
+	go func() {
+		defer wg.Done()
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", bytes.NewReader([]byte(`{"instances": [[3]]}`)))
+		w := httptest.NewRecorder()
+		batchHandler.ServeHTTP(w, r)
+		var res Response
+		g.Expect(json.Unmarshal(w.Body.Bytes(), &res)).To(gomega.Succeed())
+		g.Expect(res.Predictions).To(gomega.Equal([]interface{}{[]interface{}{float64(3)}}))
+	}()
+	wg.Wait()
+	g.Expect(<-batches).To(gomega.Equal(1))
+	g.Expect(batches).To(gomega.BeEmpty())
+}
+
+// Tests that requests are rejected with Retry-After when the queue is full
+func TestBatcherQueueFull(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	release := make(chan struct{})
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		var request Request
+		err = json.Unmarshal(b, &request)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		<-release
+		responseBytes, err := json.Marshal(Response{Predictions: request.Instances})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	batchHandler := New(32, 50, httpProxy, logger)
+	batchHandler.MaxQueueDepth = 1
+
+	done := make(chan int)
+	go func() {
+		r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", bytes.NewReader([]byte(`{"instances": [[1]]}`)))
+		w := httptest.NewRecorder()
+		batchHandler.ServeHTTP(w, r)
+		done <- w.Code
+	}()
+	g.Eventually(func() int64 {
+		batchHandler.mu.Lock()
+		defer batchHandler.mu.Unlock()
+		if queue, ok := batchHandler.queues["/v1/models/test:predict"]; ok {
+			return queue.waiting.Load()
+		}
+		return 0
+	}).Should(gomega.Equal(int64(1)))
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", bytes.NewReader([]byte(`{"instances": [[2]]}`)))
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(w.Header().Get("Retry-After")).To(gomega.Equal("1"))
+
+	close(release)
+	g.Expect(<-done).To(gomega.Equal(http.StatusOK))
+}
+
+func TestBatcherIdleQueueRemoved(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		var request Request
+		err = json.Unmarshal(b, &request)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		responseBytes, err := json.Marshal(Response{Predictions: request.Instances})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
+	batchHandler := New(32, 10, httpProxy, logger)
+	batchHandler.IdleTimeout = time.Second
+	queues := func() int {
+		batchHandler.mu.Lock()
+		defer batchHandler.mu.Unlock()
+		return len(batchHandler.queues)
+	}
+
+	predict := func(path string) int {
+		r := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(`{"instances": [[1]]}`)))
+		w := httptest.NewRecorder()
+		batchHandler.ServeHTTP(w, r)
+		return w.Code
+	}
+	for i := range 10 {
+		g.Expect(predict(fmt.Sprintf("/v1/models/model%d:predict", i))).To(gomega.Equal(http.StatusOK))
+	}
+	g.Expect(queues()).To(gomega.Equal(10))
+	// the paths without a batch config share the metrics of the other models
+	g.Expect(testutil.CollectAndCount(batchSize)).To(gomega.BeNumerically("<=", 2))
+	// the queues of the paths which are no longer requested are removed
+	g.Eventually(queues, 5*time.Second).Should(gomega.Equal(0))
+
+	// a removed queue is started again by the next request for its path
+	g.Expect(predict("/v1/models/model0:predict")).To(gomega.Equal(http.StatusOK))
+	g.Expect(queues()).To(gomega.Equal(1))
+}
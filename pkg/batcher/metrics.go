@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// otherModel labels the metrics of the models without a batch config. The model paths come from the
+// clients, so they cannot label the metrics without making their cardinality unbounded.
+const otherModel = "other"
+
+var (
+	batchSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_batcher_batch_size",
+		Help:    "Number of instances sent to the model server in a batch",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"model"})
+	queueDepth = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_batcher_queue_depth",
+		Help:    "Number of requests waiting in the batch queue when a request is queued",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"model"})
+	waitTime = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_batcher_wait_time_seconds",
+		Help:    "Time a request waits in the batch queue before its batch is sent to the model server",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"model"})
+	effectiveBatchSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kserve_batcher_effective_batch_size",
+		Help: "Batch size chosen by adaptive batching",
+	}, []string{"model"})
+	rejectedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_batcher_rejected_requests_total",
+		Help: "Number of requests rejected because the batch queue was full",
+	}, []string{"model"})
+	cancelledRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_batcher_cancelled_requests_total",
+		Help: "Number of requests dropped from a batch because the caller went away",
+	}, []string{"model"})
+)
+
+func init() {
+	prometheus.MustRegister(batchSize, queueDepth, waitTime, effectiveBatchSize, rejectedRequests, cancelledRequests)
+}
+
+// deleteMetrics removes the metrics of a model once the last of its queues is removed.
+func deleteMetrics(model string) {
+	batchSize.DeleteLabelValues(model)
+	queueDepth.DeleteLabelValues(model)
+	waitTime.DeleteLabelValues(model)
+	effectiveBatchSize.DeleteLabelValues(model)
+	rejectedRequests.DeleteLabelValues(model)
+	cancelledRequests.DeleteLabelValues(model)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// V2Tensor is an input or output tensor of the Open Inference Protocol (v2) REST API.
+type V2Tensor struct {
+	Name       string                 `json:"name"`
+	Shape      []int64                `json:"shape"`
+	Datatype   string                 `json:"datatype"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Data       []interface{}          `json:"data"`
+}
+
+// V2RequestedOutput is an output requested by the caller of a v2 inference request.
+type V2RequestedOutput struct {
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type V2Request struct {
+	Id         string                 `json:"id,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Inputs     []V2Tensor             `json:"inputs"`
+	Outputs    []V2RequestedOutput    `json:"outputs,omitempty"`
+}
+
+type V2Response struct {
+	ModelName    string                 `json:"model_name"`
+	ModelVersion string                 `json:"model_version,omitempty"`
+	Id           string                 `json:"id,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Outputs      []V2Tensor             `json:"outputs"`
+}
+
+type V2ResponseError struct {
+	Error string `json:"error"`
+}
+
+// flattenData flattens nested tensor data into row-major order.
+func flattenData(data []interface{}) []interface{} {
+	flat := make([]interface{}, 0, len(data))
+	for _, d := range data {
+		if nested, ok := d.([]interface{}); ok {
+			flat = append(flat, flattenData(nested)...)
+		} else {
+			flat = append(flat, d)
+		}
+	}
+	return flat
+}
+
+// elementCount returns the number of elements of a tensor with the given shape.
+func elementCount(shape []int64) int64 {
+	count := int64(1)
+	for _, dim := range shape {
+		count *= dim
+	}
+	return count
+}
+
+// validate checks that all the inputs of the request share the same batch
+// dimension and that the data matches the declared shapes. The tensor data
+// is flattened in place and the batch size is returned.
+func (r *V2Request) validate() (int, error) {
+	if len(r.Inputs) == 0 {
+		return 0, errors.New("no inputs in the request")
+	}
+	batchSize := int64(-1)
+	names := map[string]bool{}
+	for i := range r.Inputs {
+		input := &r.Inputs[i]
+		if input.Name == "" {
+			return 0, errors.New("input tensor has no name")
+		}
+		if names[input.Name] {
+			return 0, fmt.Errorf("duplicate input tensor %s", input.Name)
+		}
+		names[input.Name] = true
+		if input.Datatype == "" {
+			return 0, fmt.Errorf("input tensor %s has no datatype", input.Name)
+		}
+		if len(input.Shape) == 0 || input.Shape[0] <= 0 {
+			return 0, fmt.Errorf("input tensor %s has no batch dimension", input.Name)
+		}
+		if batchSize != -1 && input.Shape[0] != batchSize {
+			return 0, fmt.Errorf("input tensor %s has batch size %d, expected %d", input.Name, input.Shape[0], batchSize)
+		}
+		batchSize = input.Shape[0]
+		input.Data = flattenData(input.Data)
+		if int64(len(input.Data)) != elementCount(input.Shape) {
+			return 0, fmt.Errorf("input tensor %s has %d elements, expected %d for shape %v",
+				input.Name, len(input.Data), elementCount(input.Shape), input.Shape)
+		}
+	}
+	return int(batchSize), nil
+}
+
+// signature identifies the requests that can be concatenated into the same batch:
+// the input names, datatypes and shapes without the batch dimension, the requested
+// outputs and the request parameters must all match.
+func (r *V2Request) signature() string {
+	inputs := make([]string, 0, len(r.Inputs))
+	for _, input := range r.Inputs {
+		inputs = append(inputs, fmt.Sprintf("%s:%s:%v", input.Name, input.Datatype, input.Shape[1:]))
+	}
+	sort.Strings(inputs)
+	outputs, _ := json.Marshal(r.Outputs)
+	parameters, _ := json.Marshal(r.Parameters)
+	return strings.Join(inputs, ",") + "|" + string(outputs) + "|" + string(parameters)
+}
+
+// concatInputs appends the inputs of a request to the batched inputs along the batch dimension.
+func concatInputs(batched []V2Tensor, inputs []V2Tensor) []V2Tensor {
+	if len(batched) == 0 {
+		batched = make([]V2Tensor, 0, len(inputs))
+		for _, input := range inputs {
+			batched = append(batched, V2Tensor{
+				Name:       input.Name,
+				Shape:      append([]int64{0}, input.Shape[1:]...),
+				Datatype:   input.Datatype,
+				Parameters: input.Parameters,
+			})
+		}
+	}
+	for _, input := range inputs {
+		for i := range batched {
+			if batched[i].Name == input.Name {
+				batched[i].Shape[0] += input.Shape[0]
+				batched[i].Data = append(batched[i].Data, input.Data...)
+				break
+			}
+		}
+	}
+	return batched
+}
+
+// splitOutputs returns the rows of the batched output tensors which belong to the given
+// batch indices. Every output must have the batch size as its first dimension.
+func splitOutputs(outputs []V2Tensor, batchSize int, index []int) ([]V2Tensor, error) {
+	split := make([]V2Tensor, 0, len(outputs))
+	if len(index) == 0 {
+		return split, nil
+	}
+	start, rows := index[0], len(index)
+	for _, output := range outputs {
+		if len(output.Shape) == 0 || output.Shape[0] != int64(batchSize) {
+			return nil, fmt.Errorf("output tensor %s has shape %v, expected batch size %d", output.Name, output.Shape, batchSize)
+		}
+		data := flattenData(output.Data)
+		if int64(len(data)) != elementCount(output.Shape) {
+			return nil, fmt.Errorf("output tensor %s has %d elements, expected %d for shape %v",
+				output.Name, len(data), elementCount(output.Shape), output.Shape)
+		}
+		rowSize := int(elementCount(output.Shape[1:]))
+		split = append(split, V2Tensor{
+			Name:       output.Name,
+			Shape:      append([]int64{int64(rows)}, output.Shape[1:]...),
+			Datatype:   output.Datatype,
+			Parameters: output.Parameters,
+			Data:       data[start*rowSize : (start+rows)*rowSize],
+		})
+	}
+	return split, nil
+}
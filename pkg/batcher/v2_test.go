@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestConcatAndSplitTensors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	first := V2Request{Inputs: []V2Tensor{
+		{Name: "a", Shape: []int64{1, 2}, Datatype: "INT32", Data: []interface{}{[]interface{}{1, 2}}},
+		{Name: "b", Shape: []int64{1}, Datatype: "BYTES", Data: []interface{}{"x"}},
+	}}
+	second := V2Request{Inputs: []V2Tensor{
+		{Name: "b", Shape: []int64{2}, Datatype: "BYTES", Data: []interface{}{"y", "z"}},
+		{Name: "a", Shape: []int64{2, 2}, Datatype: "INT32", Data: []interface{}{3, 4, 5, 6}},
+	}}
+	size, err := first.validate()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(size).To(gomega.Equal(1))
+	size, err = second.validate()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(size).To(gomega.Equal(2))
+	g.Expect(first.signature()).To(gomega.Equal(second.signature()))
+
+	batched := concatInputs(nil, first.Inputs)
+	batched = concatInputs(batched, second.Inputs)
+	g.Expect(batched).To(gomega.Equal([]V2Tensor{
+		{Name: "a", Shape: []int64{3, 2}, Datatype: "INT32", Data: []interface{}{1, 2, 3, 4, 5, 6}},
+		{Name: "b", Shape: []int64{3}, Datatype: "BYTES", Data: []interface{}{"x", "y", "z"}},
+	}))
+
+	split, err := splitOutputs(batched, 3, []int{1, 2})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(split).To(gomega.Equal([]V2Tensor{
+		{Name: "a", Shape: []int64{2, 2}, Datatype: "INT32", Data: []interface{}{3, 4, 5, 6}},
+		{Name: "b", Shape: []int64{2}, Datatype: "BYTES", Data: []interface{}{"y", "z"}},
+	}))
+
+	_, err = splitOutputs(batched, 4, []int{0})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestSignatureMismatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	fp32 := V2Request{Inputs: []V2Tensor{{Name: "a", Shape: []int64{1, 2}, Datatype: "FP32", Data: []interface{}{1, 2}}}}
+	int32 := V2Request{Inputs: []V2Tensor{{Name: "a", Shape: []int64{1, 2}, Datatype: "INT32", Data: []interface{}{1, 2}}}}
+	wide := V2Request{Inputs: []V2Tensor{{Name: "a", Shape: []int64{1, 3}, Datatype: "FP32", Data: []interface{}{1, 2, 3}}}}
+	g.Expect(fp32.signature()).ToNot(gomega.Equal(int32.signature()))
+	g.Expect(fp32.signature()).ToNot(gomega.Equal(wide.signature()))
+}
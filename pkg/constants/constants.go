@@ -72,6 +72,11 @@ const (
 	RouterTimeoutsServerRead     = 60
 	RouterTimeoutServerWrite     = 60
 	RouterTimeoutServerIdle      = 180
+	// RouterMetricsPort serves the router metrics apart from the graph, which may require authorization
+	RouterMetricsPort = 9091
+	// RouterSplitterStepHeader pins the step of a Splitter node in a request, and tells the step
+	// chosen by a Splitter node in a response, as <node name>=<step name, or index when unnamed>
+	RouterSplitterStepHeader = "KServe-Splitter-Step"
 )
 
 // TrainedModel Constants
@@ -82,6 +87,8 @@ var (
 // InferenceService MultiModel Constants
 var (
 	ModelConfigFileName = "models.json"
+	// PredictorShardLabel is the id of the shard served by a predictor of a multi-model InferenceService
+	PredictorShardLabel = KServeAPIGroupName + "/" + "predictor-shard"
 )
 
 // Remote Storage URI
@@ -97,14 +104,24 @@ const (
 	AgentConfigDirArgName     = "--config-dir"
 	AgentModelDirArgName      = "--model-dir"
 	AgentComponentPortArgName = "--component-port"
+	AgentModelPvcVolumePrefix = "agent-model-pvc"
 )
 
 // InferenceLogger Constants
 const (
 	LoggerCaBundleVolume            = "agent-ca-bundle"
 	LoggerCaCertMountPath           = "/etc/tls/logger"
+	LoggerSpoolVolume               = "agent-log-spool"
+	LoggerSpoolMountPath            = "/var/spool/kserve/logger"
+	LoggerPvcVolume                 = "agent-log-pvc"
+	LoggerPvcMountPath              = "/mnt/logs"
 	LoggerDefaultFormat             = "json"
+	LoggerDefaultRetryMaxAttempts   = 3
+	LoggerDefaultBreakerThreshold   = 5
 	LoggerFormatKey                 = "format"
+	LoggerKafkaAcksKey              = "acks"
+	LoggerMaxFileSizeKey            = "maxFileSize"
+	LoggerMaxFileAgeKey             = "maxFileAge"
 	LoggerDefaultStorageKey         = "credentials"
 	LoggerDefaultServiceAccountName = "logger-sa"
 )
@@ -168,10 +185,14 @@ var (
 	BatcherInternalAnnotationKey                     = InferenceServiceInternalAnnotationsPrefix + "/batcher"
 	BatcherMaxBatchSizeInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-batchsize"
 	BatcherMaxLatencyInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-latency"
+	BatcherTargetLatencyInternalAnnotationKey        = InferenceServiceInternalAnnotationsPrefix + "/batcher-target-latency"
+	BatcherMaxQueueDepthInternalAnnotationKey        = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-queue-depth"
+	BatcherModelBatchConfigInternalAnnotationKey     = InferenceServiceInternalAnnotationsPrefix + "/batcher-model-batch-config"
 	AgentShouldInjectAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/agent"
 	AgentModelConfigVolumeNameAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/configVolumeName"
 	AgentModelConfigMountPathAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/configMountPath"
 	AgentModelDirAnnotationKey                       = InferenceServiceInternalAnnotationsPrefix + "/modelDir"
+	AgentPvcClaimsAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/agent-pvc-claims"
 	PredictorHostAnnotationKey                       = InferenceServiceInternalAnnotationsPrefix + "/predictor-host"
 	PredictorProtocolAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/predictor-protocol"
 	LocalModelLabel                                  = InferenceServiceInternalAnnotationsPrefix + "/localmodel"
@@ -393,11 +414,13 @@ const (
 
 // InferenceService Endpoint Ports
 const (
-	InferenceServiceDefaultHttpPort     = "8080"
-	InferenceServiceDefaultAgentPortStr = "9081"
-	InferenceServiceDefaultAgentPort    = 9081
-	CommonDefaultHttpPort               = 80
-	AggregateMetricsPortName            = "aggr-metric"
+	InferenceServiceDefaultHttpPort         = "8080"
+	InferenceServiceDefaultAgentPortStr     = "9081"
+	InferenceServiceDefaultAgentPort        = 9081
+	InferenceServiceDefaultAgentMetricsPort = 9082
+	CommonDefaultHttpPort                   = 80
+	AggregateMetricsPortName                = "aggr-metric"
+	AgentMetricsPortName                    = "agent-metrics"
 )
 
 // Labels to put on kservice
@@ -531,6 +554,8 @@ const (
 	ModelDirVolumeName    = "model-dir"
 	ModelConfigDir        = "/mnt/configs"
 	ModelDir              = DefaultModelLocalMountPath
+	// AgentModelStatusPath is the path of the model agent endpoint reporting the state of the models of its replica
+	AgentModelStatusPath = "/v1/models/status"
 )
 
 var (
@@ -807,6 +832,12 @@ func PredictorServiceName(name string, predictorName ...string) string {
 	return name + "-" + string(Predictor)
 }
 
+// PredictorShardServiceName returns the name of the predictor serving a shard other than the first one
+// of a multi-model InferenceService, the first shard being served by the predictor of the InferenceService.
+func PredictorShardServiceName(name string, shardId int) string {
+	return fmt.Sprintf("%s-%s-shard-%d", name, Predictor, shardId)
+}
+
 func PredictorWorkerServiceName(name string) string {
 	return name + "-" + string(Predictor) + "-" + WorkerNodeSuffix
 }
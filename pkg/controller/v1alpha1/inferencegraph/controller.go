@@ -203,26 +203,16 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 	// resolve service urls
 	if !forceStopRuntime {
-		for node, router := range graph.Spec.Nodes {
-			for i, route := range router.Steps {
-				isvc := v1beta1.InferenceService{}
-				if route.ServiceName == "" {
-					continue
+		for _, router := range graph.Spec.Nodes {
+			for i := range router.Steps {
+				step := &router.Steps[i]
+				if err := r.resolveServiceUrl(ctx, graph.Namespace, &step.InferenceTarget); err != nil {
+					return reconcile.Result{Requeue: true}, err
 				}
-				err := r.Get(ctx, types.NamespacedName{Namespace: graph.Namespace, Name: route.ServiceName}, &isvc)
-				if err == nil {
-					if graph.Spec.Nodes[node].Steps[i].ServiceURL == "" {
-						serviceUrl, err := isvcutils.GetPredictorEndpoint(ctx, r.Client, &isvc)
-						if err == nil {
-							graph.Spec.Nodes[node].Steps[i].ServiceURL = serviceUrl
-						} else {
-							r.Log.Info("inference service is not ready", "name", route.ServiceName)
-							return reconcile.Result{Requeue: true}, errors.Wrapf(err, "service %s is not ready", route.ServiceName)
-						}
+				if step.Fallback != nil {
+					if err := r.resolveServiceUrl(ctx, graph.Namespace, step.Fallback); err != nil {
+						return reconcile.Result{Requeue: true}, err
 					}
-				} else {
-					r.Log.Info("inference service is not found", "name", route.ServiceName)
-					return reconcile.Result{Requeue: true}, errors.Wrapf(err, "Failed to find graph service %s", route.ServiceName)
 				}
 			}
 		}
@@ -427,6 +417,43 @@ func inferenceGraphReadiness(status v1alpha1.InferenceGraphStatus) bool {
 		status.GetCondition(apis.ConditionReady).Status == corev1.ConditionTrue
 }
 
+// resolveServiceUrl sets the url of a target referring to an InferenceService by name
+func (r *InferenceGraphReconciler) resolveServiceUrl(ctx context.Context, namespace string, target *v1alpha1.InferenceTarget) error {
+	if target.ServiceName == "" {
+		return nil
+	}
+	isvc := v1beta1.InferenceService{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: target.ServiceName}, &isvc); err != nil {
+		r.Log.Info("inference service is not found", "name", target.ServiceName)
+		return errors.Wrapf(err, "Failed to find graph service %s", target.ServiceName)
+	}
+	// InferenceServices whose predictor is served over gRPC are called over gRPC, unless a
+	// transformer, which is always served over REST, is in front of it
+	if target.Protocol == "" && isvc.Spec.Transformer == nil &&
+		isvc.Spec.Predictor.GetImplementation().GetProtocol() == constants.ProtocolGRPCV2 {
+		target.Protocol = constants.ProtocolGRPCV2
+	}
+	if target.ServiceURL != "" {
+		return nil
+	}
+	if target.Protocol == constants.ProtocolGRPCV2 {
+		// the router takes the model to call from the path of the url
+		if isvc.Status.Address == nil || isvc.Status.Address.URL == nil {
+			r.Log.Info("inference service is not ready", "name", target.ServiceName)
+			return fmt.Errorf("service %s is not ready", target.ServiceName)
+		}
+		target.ServiceURL = isvc.Status.Address.URL.String() + constants.PredictPath(isvcutils.GetModelName(&isvc), constants.ProtocolV2)
+		return nil
+	}
+	serviceUrl, err := isvcutils.GetPredictorEndpoint(ctx, r.Client, &isvc)
+	if err != nil {
+		r.Log.Info("inference service is not ready", "name", target.ServiceName)
+		return errors.Wrapf(err, "service %s is not ready", target.ServiceName)
+	}
+	target.ServiceURL = serviceUrl
+	return nil
+}
+
 func (r *InferenceGraphReconciler) onDeleteCleanup(ctx context.Context, graph *v1alpha1.InferenceGraph) error {
 	if err := removeAuthPrivilegesFromGraphServiceAccount(ctx, r.Clientset, graph); err != nil {
 		return err
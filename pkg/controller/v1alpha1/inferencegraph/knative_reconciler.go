@@ -279,6 +279,8 @@ func createKnativeService(
 			service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0].Env,
 			propagateEnv)
 	}
+
+	injectRouterTelemetry(graph, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0])
 	return service
 }
 
@@ -131,6 +131,8 @@ func createInferenceGraphPodSpec(graph *v1alpha1.InferenceGraph, config *RouterC
 		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, propagateEnv)
 	}
 
+	injectRouterTelemetry(graph, &podSpec.Containers[0])
+
 	// If auth is enabled for the InferenceGraph:
 	// * Add --enable-auth argument, to properly secure kserve-router
 	// * Add the --inferencegraph-name argument, so that the router is aware of its name
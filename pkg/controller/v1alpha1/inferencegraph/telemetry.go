@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	defaultRouterServiceName       = "inferencegraph-router"
+	defaultTracingExporterEndpoint = "http://otel-collector:4317"
+	defaultTracingSampler          = "parentbased_traceidratio"
+	defaultTracingSamplerArg       = "0.05"
+	defaultTracingExporter         = "otlp"
+)
+
+// injectRouterTelemetry injects the telemetry args and env vars of the graph into the router
+// container. The router uses the --enable-tracing flag and the standard OTEL_* env vars for
+// the traces, with the TracingSpec defaults for the fields which are not set, and the
+// --enable-metrics flag to expose its Prometheus metrics.
+func injectRouterTelemetry(graph *v1alpha1.InferenceGraph, container *corev1.Container) {
+	if graph.Spec.EnableMetrics {
+		container.Args = append(container.Args, "--enable-metrics")
+	}
+
+	t := graph.Spec.Tracing
+	if t == nil {
+		return
+	}
+	container.Args = append(container.Args, "--enable-tracing")
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: defaultRouterServiceName},
+		corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: ptr.Deref(t.ExporterEndpoint, defaultTracingExporterEndpoint)},
+		corev1.EnvVar{Name: "OTEL_TRACES_EXPORTER", Value: ptr.Deref(t.Exporter, defaultTracingExporter)},
+		corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER", Value: ptr.Deref(t.Sampler, defaultTracingSampler)},
+		corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: ptr.Deref(t.SamplerArg, defaultTracingSamplerArg)},
+	)
+	container.Env = append(container.Env, utils.OtelResourceAttributeEnvVars(graph.Namespace, "inferencegraph.name", graph.Name)...)
+}
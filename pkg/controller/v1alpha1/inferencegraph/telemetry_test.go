@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+func TestInjectRouterTelemetry(t *testing.T) {
+	resourceAttributes := utils.OtelResourceAttributeEnvVars("default", "inferencegraph.name", "graph")
+	scenarios := []struct {
+		name         string
+		spec         InferenceGraphSpec
+		expectedArgs []string
+		expectedEnv  []corev1.EnvVar
+	}{
+		{
+			name:         "telemetry disabled",
+			spec:         InferenceGraphSpec{},
+			expectedArgs: []string{"--graph-json", "{}"},
+		},
+		{
+			name:         "metrics",
+			spec:         InferenceGraphSpec{EnableMetrics: true},
+			expectedArgs: []string{"--graph-json", "{}", "--enable-metrics"},
+		},
+		{
+			name:         "tracing defaults",
+			spec:         InferenceGraphSpec{Tracing: &TracingSpec{}},
+			expectedArgs: []string{"--graph-json", "{}", "--enable-tracing"},
+			expectedEnv: append([]corev1.EnvVar{
+				{Name: "OTEL_SERVICE_NAME", Value: "inferencegraph-router"},
+				{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://otel-collector:4317"},
+				{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+				{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+				{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.05"},
+			}, resourceAttributes...),
+		},
+		{
+			name: "tracing and metrics",
+			spec: InferenceGraphSpec{
+				EnableMetrics: true,
+				Tracing: &TracingSpec{
+					ExporterEndpoint: ptr.To("http://collector.observability:4317"),
+					Sampler:          ptr.To("always_on"),
+				},
+			},
+			expectedArgs: []string{"--graph-json", "{}", "--enable-metrics", "--enable-tracing"},
+			expectedEnv: append([]corev1.EnvVar{
+				{Name: "OTEL_SERVICE_NAME", Value: "inferencegraph-router"},
+				{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://collector.observability:4317"},
+				{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+				{Name: "OTEL_TRACES_SAMPLER", Value: "always_on"},
+				{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.05"},
+			}, resourceAttributes...),
+		},
+	}
+
+	for _, tt := range scenarios {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &InferenceGraph{
+				ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			container := &corev1.Container{Args: []string{"--graph-json", "{}"}}
+			injectRouterTelemetry(graph, container)
+			if diff := cmp.Diff(tt.expectedArgs, container.Args); diff != "" {
+				t.Errorf("Test %q unexpected args (-want +got): %v", t.Name(), diff)
+			}
+			if diff := cmp.Diff(tt.expectedEnv, container.Env); diff != "" {
+				t.Errorf("Test %q unexpected env (-want +got): %v", t.Name(), diff)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ limitations under the License.
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;update
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 package trainedmodel
 
@@ -37,8 +38,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/network"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -48,6 +51,7 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/reconcilers/modelconfig"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/sharding/memory"
 	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/utils"
 )
@@ -64,6 +68,9 @@ var log = logf.Log.WithName("TrainedModel controller")
 // TrainedModelReconciler reconciles a TrainedModel object
 type TrainedModelReconciler struct {
 	client.Client
+	// APIReader lists the TrainedModels of an InferenceService from the API server rather than the cache,
+	// so that a model is assigned to a shard with the assignments of the models reconciled just before it
+	APIReader             client.Reader
 	Clientset             kubernetes.Interface
 	Log                   logr.Logger
 	Scheme                *runtime.Scheme
@@ -128,6 +135,10 @@ func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			if err := r.ModelConfigReconciler.Reconcile(ctx, req, tm); err != nil {
 				return reconcile.Result{}, err
 			}
+			// move the models of the shards which can be emptied to the other shards
+			if err := r.rebalanceShards(ctx, isvc, tm); err != nil {
+				return reconcile.Result{}, err
+			}
 			// remove our finalizer from the list and update it.
 			tm.SetFinalizers(utils.RemoveString(tm.GetFinalizers(), tmFinalizerName))
 			if err := r.Update(ctx, tm); err != nil {
@@ -144,6 +155,12 @@ func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return reconcile.Result{}, err
 	}
 
+	// Check whether the predictor replicas of the shard loaded the model
+	requeueAfter, err := r.updateLoadStatus(ctx, isvc, tm)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// update URL and Address of TrainedModel
 	if err := r.updateStatus(ctx, req, tm); err != nil {
 		return ctrl.Result{}, err
@@ -151,9 +168,15 @@ func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Reconcile modelconfig to add this TrainedModel to its parent InferenceService's configmap
 	if err := r.ModelConfigReconciler.Reconcile(ctx, req, tm); err != nil {
+		if errors.IsNotFound(err) {
+			// The modelconfig of a new shard is created by the InferenceService controller
+			log.Info("Waiting for the modelconfig of the shard of the TrainedModel", "TrainedModel", tm.Name, "InferenceService", isvc.Name)
+			return ctrl.Result{RequeueAfter: modelConfigRequeueInterval}, nil
+		}
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	// The model agents do not notify the controller, so the load status is polled
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *TrainedModelReconciler) updateStatus(ctx context.Context, req ctrl.Request, desiredModel *v1alpha1.TrainedModel) error {
@@ -189,6 +212,19 @@ func (r *TrainedModelReconciler) updateStatus(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	// The models of the other shards than the first one are only served by the predictor of their shard
+	if shardId, _ := memory.ShardOf(desiredModel); shardId > 0 {
+		host := network.GetServiceHostname(constants.PredictorShardServiceName(isvc.Name, shardId), isvc.Namespace)
+		shardURL, err := apis.ParseURL("http://" + host + constants.PredictPath(desiredModel.Name, isvc.Spec.Predictor.GetImplementation().GetProtocol()))
+		if err != nil {
+			return err
+		}
+		desiredModel.Status.URL = shardURL
+		desiredModel.Status.Address = &duckv1.Addressable{
+			URL: shardURL,
+		}
+	}
+
 	// Get the current model
 	existingModel := &v1alpha1.TrainedModel{}
 	if err := r.Get(ctx, req.NamespacedName, existingModel); err != nil {
@@ -256,18 +292,15 @@ func (r *TrainedModelReconciler) updateConditions(ctx context.Context, req ctrl.
 
 	// Get trained models with same inference service
 	var trainedModels v1alpha1.TrainedModelList
-	if err := r.List(ctx, &trainedModels, client.InNamespace(tm.Namespace), client.MatchingLabels{constants.ParentInferenceServiceLabel: isvc.Name, constants.TrainedModelAllocated: isvc.Name}); err != nil {
+	if err := r.APIReader.List(ctx, &trainedModels, client.InNamespace(tm.Namespace), client.MatchingLabels{constants.ParentInferenceServiceLabel: isvc.Name}); err != nil {
 		return err
 	}
 
-	if _, ok := tm.Labels[constants.TrainedModelAllocated]; !ok {
-		trainedModels.Items = append(trainedModels.Items, *tm)
-	}
-
-	totalReqMemory := trainedModels.TotalRequestedMemory()
-	// Update Inference Service Resource Available condition
-	if v1beta1utils.IsMemoryResourceAvailable(isvc, totalReqMemory) {
-		log.Info("Parent InferenceService memory resources are available", "TrainedModel", tm.Name, "InferenceService", isvc.Name)
+	// Update Inference Service Resource Available condition, the TrainedModel being assigned to a shard
+	// of the InferenceService with enough memory left
+	shardStrategy := memory.NewMemoryStrategy(isvc, trainedModels.Items)
+	if shardId, ok := shardStrategy.GetOrAssignShard(tm); ok {
+		log.Info("Parent InferenceService memory resources are available", "TrainedModel", tm.Name, "InferenceService", isvc.Name, "shard", shardId)
 		if _, ok := tm.Labels[constants.TrainedModelAllocated]; !ok {
 			tm.Labels[constants.TrainedModelAllocated] = isvc.Name
 			if updateErr := r.Update(ctx, tm); updateErr != nil {
@@ -275,6 +308,7 @@ func (r *TrainedModelReconciler) updateConditions(ctx context.Context, req ctrl.
 				return updateErr
 			}
 		}
+		tm.Status.Shard = ptr.To(int32(shardId)) // #nosec G115
 
 		tm.Status.SetCondition(v1alpha1.MemoryResourceAvailable, &apis.Condition{
 			Status: corev1.ConditionTrue,
@@ -301,6 +335,45 @@ func (r *TrainedModelReconciler) updateConditions(ctx context.Context, req ctrl.
 	return conditionErr
 }
 
+// rebalanceShards moves the models of the last shards of the InferenceService to its other shards, once
+// a TrainedModel is deleted, when they all fit in the memory left so that the emptied shards are removed.
+func (r *TrainedModelReconciler) rebalanceShards(ctx context.Context, isvc *v1beta1.InferenceService, deleted *v1alpha1.TrainedModel) error {
+	var trainedModels v1alpha1.TrainedModelList
+	if err := r.APIReader.List(ctx, &trainedModels, client.InNamespace(deleted.Namespace), client.MatchingLabels{constants.ParentInferenceServiceLabel: isvc.Name}); err != nil {
+		return err
+	}
+	remaining := make([]v1alpha1.TrainedModel, 0, len(trainedModels.Items))
+	for _, item := range trainedModels.Items {
+		if item.Name != deleted.Name && item.DeletionTimestamp.IsZero() {
+			remaining = append(remaining, item)
+		}
+	}
+
+	moves := memory.NewMemoryStrategy(isvc, remaining).Rebalance()
+	for i := range remaining {
+		moved := &remaining[i]
+		shardId, ok := moves[moved.Name]
+		if !ok {
+			continue
+		}
+		previousShardId, _ := memory.ShardOf(moved)
+		log.Info("Moving TrainedModel to another shard", "TrainedModel", moved.Name, "InferenceService", isvc.Name, "from", previousShardId, "to", shardId)
+		moved.Status.Shard = ptr.To(int32(shardId)) // #nosec G115
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: moved.Namespace, Name: moved.Name}}
+		// The model is added to its new shard before it is removed from the previous one
+		if err := r.ModelConfigReconciler.Reconcile(ctx, req, moved); err != nil {
+			return err
+		}
+		if err := r.ModelConfigReconciler.RemoveFromShard(ctx, req, moved, previousShardId); err != nil {
+			return err
+		}
+		if err := r.Status().Update(ctx, moved); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *TrainedModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.TrainedModel{}).
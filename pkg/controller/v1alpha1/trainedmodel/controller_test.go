@@ -780,4 +780,242 @@ var _ = Describe("v1beta1 TrainedModel controller", func() {
 			}, timeout).Should(BeTrue())
 		})
 	})
+
+	Context("When deleting a TrainedModel of a sharded InferenceService", func() {
+		It("Should move the models of the last shard to the emptied shard", func() {
+			parentInferenceService := "model-shards-parent"
+			shardModelConfigName := constants.ModelConfigName(parentInferenceService, 1)
+			firstConfigmapKey := types.NamespacedName{Name: constants.ModelConfigName(parentInferenceService, 0), Namespace: namespace}
+			shardConfigmapKey := types.NamespacedName{Name: shardModelConfigName, Namespace: namespace}
+			shardMemory := resource.MustParse("1.5G")
+
+			// Create InferenceService configmap
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+
+			// Create the parent InferenceService, whose predictor replicas only fit one of the models
+			serviceKey := types.NamespacedName{Name: parentInferenceService, Namespace: namespace}
+			ctx := context.Background()
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceKey.Name,
+					Namespace: serviceKey.Namespace,
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: ptr.To(int32(1)),
+							MaxReplicas: 3,
+						},
+						Tensorflow: &v1beta1.TFServingSpec{
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								RuntimeVersion: proto.String("1.14.0"),
+								Container: corev1.Container{
+									Name:      constants.InferenceServiceContainerName,
+									Resources: defaultResource,
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, isvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, isvc)
+
+			inferenceService := &v1beta1.InferenceService{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, serviceKey, inferenceService)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			inferenceService.Status.Status = readyConditions
+			inferenceService.Status.ModelStatus = modelStatus
+			inferenceService.Status.DeploymentMode = string(constants.Standard)
+			Expect(k8sClient.Status().Update(context.TODO(), inferenceService)).To(Succeed())
+
+			firstModelConfig := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: firstConfigmapKey.Name, Namespace: namespace},
+				Data: map[string]string{
+					constants.ModelConfigFileName: "",
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), firstModelConfig)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), firstModelConfig)
+
+			makeTrainedModel := func(name string) *v1alpha1.TrainedModel {
+				return &v1alpha1.TrainedModel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+					Spec: v1alpha1.TrainedModelSpec{
+						InferenceService: parentInferenceService,
+						Model: v1alpha1.ModelSpec{
+							StorageURI: storageUri,
+							Framework:  framework,
+							Memory:     shardMemory,
+						},
+					},
+				}
+			}
+			shardOf := func(name string) int32 {
+				tm := &v1alpha1.TrainedModel{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, tm); err != nil || tm.Status.Shard == nil {
+					return -1
+				}
+				return *tm.Status.Shard
+			}
+			modelConfigData := func(key types.NamespacedName) string {
+				cm := &corev1.ConfigMap{}
+				if err := k8sClient.Get(ctx, key, cm); err != nil {
+					return ""
+				}
+				return cm.Data[constants.ModelConfigFileName]
+			}
+
+			firstModel := makeTrainedModel("model-shard-first")
+			Expect(k8sClient.Create(ctx, firstModel)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(ctx, firstModel)
+			Eventually(func() int32 { return shardOf(firstModel.Name) }, timeout, interval).Should(Equal(int32(0)))
+
+			// The second model is assigned to a new shard, and waits for its modelconfig
+			secondModel := makeTrainedModel("model-shard-second")
+			Expect(k8sClient.Create(ctx, secondModel)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(ctx, secondModel)
+			Eventually(func() int32 { return shardOf(secondModel.Name) }, timeout, interval).Should(Equal(int32(1)))
+
+			shardModelConfig := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: shardModelConfigName, Namespace: namespace},
+				Data: map[string]string{
+					constants.ModelConfigFileName: "",
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), shardModelConfig)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), shardModelConfig)
+			Eventually(func() string { return modelConfigData(shardConfigmapKey) }, timeout, interval).
+				Should(Equal(`[{"modelName":"model-shard-second","modelSpec":{"storageUri":"s3//model1","framework":"tensorflow","memory":"1500M"}}]`))
+
+			// Deleting the model of the first shard moves the model of the second shard to it
+			Expect(k8sClient.Delete(ctx, firstModel)).NotTo(HaveOccurred())
+			Eventually(func() int32 { return shardOf(secondModel.Name) }, timeout, interval).Should(Equal(int32(0)))
+			Eventually(func() string { return modelConfigData(firstConfigmapKey) }, timeout, interval).
+				Should(Equal(`[{"modelName":"model-shard-second","modelSpec":{"storageUri":"s3//model1","framework":"tensorflow","memory":"1500M"}}]`))
+			Eventually(func() string { return modelConfigData(shardConfigmapKey) }, timeout, interval).Should(Equal("[]"))
+		})
+	})
+
+	Context("When creating two TrainedModels of a sharded InferenceService back to back", func() {
+		It("Should assign them to different shards", func() {
+			parentInferenceService := "model-shards-back-to-back"
+			shardMemory := resource.MustParse("1.5G")
+
+			// Create InferenceService configmap
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+
+			// Create the parent InferenceService, whose predictor replicas only fit one of the models
+			serviceKey := types.NamespacedName{Name: parentInferenceService, Namespace: namespace}
+			ctx := context.Background()
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceKey.Name,
+					Namespace: serviceKey.Namespace,
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: ptr.To(int32(1)),
+							MaxReplicas: 3,
+						},
+						Tensorflow: &v1beta1.TFServingSpec{
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								RuntimeVersion: proto.String("1.14.0"),
+								Container: corev1.Container{
+									Name:      constants.InferenceServiceContainerName,
+									Resources: defaultResource,
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, isvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, isvc)
+
+			inferenceService := &v1beta1.InferenceService{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, serviceKey, inferenceService)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			inferenceService.Status.Status = readyConditions
+			inferenceService.Status.ModelStatus = modelStatus
+			inferenceService.Status.DeploymentMode = string(constants.Standard)
+			Expect(k8sClient.Status().Update(context.TODO(), inferenceService)).To(Succeed())
+
+			firstModelConfig := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.ModelConfigName(parentInferenceService, 0), Namespace: namespace},
+				Data: map[string]string{
+					constants.ModelConfigFileName: "",
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), firstModelConfig)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), firstModelConfig)
+
+			shardOf := func(name string) int32 {
+				tm := &v1alpha1.TrainedModel{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, tm); err != nil || tm.Status.Shard == nil {
+					return -1
+				}
+				return *tm.Status.Shard
+			}
+
+			makeTrainedModel := func(name string) *v1alpha1.TrainedModel {
+				return &v1alpha1.TrainedModel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+					Spec: v1alpha1.TrainedModelSpec{
+						InferenceService: parentInferenceService,
+						Model: v1alpha1.ModelSpec{
+							StorageURI: storageUri,
+							Framework:  framework,
+							Memory:     shardMemory,
+						},
+					},
+				}
+			}
+
+			// The second model is created without waiting for the first one to be assigned to a shard
+			names := []string{"model-back-to-back-first", "model-back-to-back-second"}
+			firstModel := makeTrainedModel(names[0])
+			Expect(k8sClient.Create(ctx, firstModel)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(ctx, firstModel)
+			secondModel := makeTrainedModel(names[1])
+			Expect(k8sClient.Create(ctx, secondModel)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(ctx, secondModel)
+
+			Eventually(func() []int32 {
+				return []int32{shardOf(names[0]), shardOf(names[1])}
+			}, timeout, interval).Should(ConsistOf(int32(0), int32(1)))
+			Consistently(func() []int32 {
+				return []int32{shardOf(names[0]), shardOf(names[1])}
+			}, time.Second, interval).Should(ConsistOf(int32(0), int32(1)))
+		})
+	})
 })
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/sharding/memory"
+	"github.com/kserve/kserve/pkg/modelconfig"
+)
+
+const (
+	// loadingRequeueInterval is how often the load status is polled while the model is being loaded
+	loadingRequeueInterval = 10 * time.Second
+	// loadedRequeueInterval is how often the load status is polled once the model is loaded or failed
+	loadedRequeueInterval = 5 * time.Minute
+	// modelConfigRequeueInterval is how often the modelconfig of a new shard is checked until it is created
+	modelConfigRequeueInterval = 5 * time.Second
+	// agentStatusTTL is how long the statuses reported by a model agent are reused, so that the trained
+	// models of an InferenceService reconciled together poll every agent once
+	agentStatusTTL = 5 * time.Second
+	// maxAgentRequests bounds the model agents polled in parallel
+	maxAgentRequests = 10
+)
+
+var agentClient = &http.Client{Timeout: 5 * time.Second}
+
+var agentStatuses = &agentStatusCache{entries: map[types.UID]agentStatusEntry{}}
+
+// agentStatusCache holds the model statuses last reported by the model agent of every predictor pod
+type agentStatusCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]agentStatusEntry
+}
+
+type agentStatusEntry struct {
+	statuses modelconfig.ModelStatuses
+	err      error
+	expires  time.Time
+}
+
+// get returns the model statuses reported by the model agent of the pod, polling the agent unless
+// it was polled within agentStatusTTL. The entries of the pods which are gone expire with it.
+func (c *agentStatusCache) get(ctx context.Context, pod *corev1.Pod) (modelconfig.ModelStatuses, error) {
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[pod.UID]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.statuses, entry.err
+	}
+
+	statuses, err := getModelStatuses(ctx, pod.Status.PodIP)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for uid, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, uid)
+		}
+	}
+	c.entries[pod.UID] = agentStatusEntry{statuses: statuses, err: err, expires: now.Add(agentStatusTTL)}
+	return statuses, err
+}
+
+// replicaStatus is the state of the trained model reported by a predictor replica
+type replicaStatus struct {
+	pod    string
+	status *modelconfig.ModelStatus
+	err    error
+}
+
+// loadStatus is the state of the trained model summarized over the predictor replicas of its shard
+type loadStatus struct {
+	replicas int32
+	loaded   int32
+	failed   int32
+	failure  string
+}
+
+// settled returns true when every replica loaded or failed to load the model
+func (s loadStatus) settled() bool {
+	return s.replicas > 0 && s.loaded+s.failed == s.replicas
+}
+
+// updateLoadStatus polls the model agents of the predictor replicas of the shard of the TrainedModel and
+// propagates the state of the model to its status, returning when the load status should be polled again.
+func (r *TrainedModelReconciler) updateLoadStatus(ctx context.Context, isvc *v1beta1.InferenceService, tm *v1alpha1.TrainedModel) (time.Duration, error) {
+	shardId, ok := memory.ShardOf(tm)
+	if !ok {
+		return 0, nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(isvc.Namespace), client.MatchingLabels{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+		constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+	}); err != nil {
+		return 0, err
+	}
+
+	shardPods := []*corev1.Pod{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || isCanary(isvc, pod) || !inShard(pod, shardId) {
+			continue
+		}
+		shardPods = append(shardPods, pod)
+	}
+
+	// the agents are polled in parallel, each replica writing its own status
+	replicas := make([]replicaStatus, len(shardPods))
+	semaphore := make(chan struct{}, maxAgentRequests)
+	wg := sync.WaitGroup{}
+	for i, pod := range shardPods {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			statuses, err := agentStatuses.get(ctx, pod)
+			replicas[i] = replicaStatus{pod: pod.Name, status: statuses.Get(tm.Name), err: err}
+		}()
+	}
+	wg.Wait()
+	summary := summarize(replicas)
+	tm.Status.PropagateLoadStatus(summary.replicas, summary.loaded, summary.failed, summary.failure)
+	if summary.settled() {
+		return loadedRequeueInterval, nil
+	}
+	return loadingRequeueInterval, nil
+}
+
+// inShard returns true when the predictor pod serves the shard, the pods of the first shard having no shard label
+func inShard(pod *corev1.Pod, shardId int) bool {
+	label, ok := pod.Labels[constants.PredictorShardLabel]
+	if !ok {
+		return shardId == 0
+	}
+	return label == strconv.Itoa(shardId)
+}
+
+// isCanary returns true when the pod belongs to a canary predictor of the InferenceService, which has
+// no shard label but does not serve the first shard either.
+func isCanary(isvc *v1beta1.InferenceService, pod *corev1.Pod) bool {
+	app := pod.Labels["app"]
+	for _, canary := range isvc.Spec.Canary {
+		if app == constants.GetRawServiceLabel(constants.PredictorServiceName(isvc.Name, canary.Predictor.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getModelStatuses returns the states of the models reported by the model agent of a predictor replica.
+func getModelStatuses(ctx context.Context, podIP string) (modelconfig.ModelStatuses, error) {
+	url := "http://" + net.JoinHostPort(podIP, constants.InferenceServiceDefaultAgentPortStr) + constants.AgentModelStatusPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return modelconfig.ModelStatuses{}, err
+	}
+	resp, err := agentClient.Do(req)
+	if err != nil {
+		return modelconfig.ModelStatuses{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return modelconfig.ModelStatuses{}, fmt.Errorf("model agent returned status %d", resp.StatusCode)
+	}
+	statuses := modelconfig.ModelStatuses{}
+	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+		return modelconfig.ModelStatuses{}, err
+	}
+	return statuses, nil
+}
+
+// summarize counts the replicas which loaded or failed to load the model. The replicas whose agent cannot
+// be reached are counted as still loading the model, since they are usually starting.
+func summarize(replicas []replicaStatus) loadStatus {
+	summary := loadStatus{replicas: int32(len(replicas))} // #nosec G115
+	failures := []string{}
+	for _, replica := range replicas {
+		if replica.err != nil {
+			log.V(1).Info("Failed to get the model status from the model agent", "pod", replica.pod, "error", replica.err)
+			continue
+		}
+		if replica.status == nil {
+			continue
+		}
+		switch replica.status.State {
+		case modelconfig.ModelLoaded:
+			summary.loaded++
+		case modelconfig.ModelFailed:
+			summary.failed++
+			failures = append(failures, replica.pod+": "+replica.status.Error)
+		case modelconfig.ModelLoading:
+		}
+	}
+	summary.failure = strings.Join(failures, "; ")
+	return summary
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+)
+
+func TestSummarize(t *testing.T) {
+	scenarios := map[string]struct {
+		replicas        []replicaStatus
+		expected        loadStatus
+		expectedSettled bool
+	}{
+		"no replicas": {
+			replicas: []replicaStatus{},
+			expected: loadStatus{},
+		},
+		"loaded": {
+			replicas: []replicaStatus{
+				{pod: "a", status: &modelconfig.ModelStatus{State: modelconfig.ModelLoaded}},
+				{pod: "b", status: &modelconfig.ModelStatus{State: modelconfig.ModelLoaded}},
+			},
+			expected:        loadStatus{replicas: 2, loaded: 2},
+			expectedSettled: true,
+		},
+		"loading": {
+			replicas: []replicaStatus{
+				{pod: "a", status: &modelconfig.ModelStatus{State: modelconfig.ModelLoaded}},
+				{pod: "b", status: &modelconfig.ModelStatus{State: modelconfig.ModelLoading}},
+				{pod: "c"},
+				{pod: "d", err: errors.New("connection refused")},
+			},
+			expected: loadStatus{replicas: 4, loaded: 1},
+		},
+		"failed": {
+			replicas: []replicaStatus{
+				{pod: "a", status: &modelconfig.ModelStatus{State: modelconfig.ModelLoaded}},
+				{pod: "b", status: &modelconfig.ModelStatus{State: modelconfig.ModelFailed, Error: "no space left"}},
+				{pod: "c", status: &modelconfig.ModelStatus{State: modelconfig.ModelFailed, Error: "out of memory"}},
+			},
+			expected:        loadStatus{replicas: 3, loaded: 1, failed: 2, failure: "b: no space left; c: out of memory"},
+			expectedSettled: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			summary := summarize(scenario.replicas)
+			assert.Equal(t, scenario.expected, summary)
+			assert.Equal(t, scenario.expectedSettled, summary.settled())
+		})
+	}
+}
+
+func TestInShard(t *testing.T) {
+	pod := func(labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+	}
+	assert.True(t, inShard(pod(nil), 0))
+	assert.False(t, inShard(pod(nil), 1))
+	assert.True(t, inShard(pod(map[string]string{constants.PredictorShardLabel: "1"}), 1))
+	assert.False(t, inShard(pod(map[string]string{constants.PredictorShardLabel: "2"}), 0))
+}
+
+func TestIsCanary(t *testing.T) {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "mms"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Canary: []v1beta1.CanarySpec{{}},
+		},
+	}
+	isvc.Spec.Canary[0].Predictor.Name = "v2"
+	pod := func(app string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}}}
+	}
+	assert.True(t, isCanary(isvc, pod(constants.GetRawServiceLabel(constants.PredictorServiceName("mms", "v2")))))
+	assert.False(t, isCanary(isvc, pod(constants.GetRawServiceLabel(constants.PredictorServiceName("mms")))))
+	assert.False(t, isCanary(&v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "mms"}}, pod("")))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAgentStatusCache(t *testing.T) {
+	requests := map[string]int{}
+	previous := agentClient
+	defer func() { agentClient = previous }()
+	agentClient = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requests[req.URL.Hostname()]++
+		body := `{"models": [{"name": "model1", "state": "Loaded"}]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}}, nil
+	})}
+
+	cache := &agentStatusCache{entries: map[types.UID]agentStatusEntry{}}
+	pod := func(uid, ip string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid)}, Status: corev1.PodStatus{PodIP: ip}}
+	}
+	for range 3 {
+		statuses, err := cache.get(t.Context(), pod("a", "10.0.0.1"))
+		require.NoError(t, err)
+		assert.Equal(t, modelconfig.ModelLoaded, statuses.Get("model1").State)
+	}
+	_, err := cache.get(t.Context(), pod("b", "10.0.0.2"))
+	require.NoError(t, err)
+	// every agent is polled once within the TTL
+	assert.Equal(t, map[string]int{"10.0.0.1": 1, "10.0.0.2": 1}, requests)
+
+	// the expired entries are polled again and the entries of the pods which are gone are dropped
+	cache.entries["a"] = agentStatusEntry{expires: time.Now().Add(-time.Second)}
+	cache.entries["gone"] = agentStatusEntry{expires: time.Now().Add(-time.Second)}
+	_, err = cache.get(t.Context(), pod("a", "10.0.0.1"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests["10.0.0.1"])
+	assert.NotContains(t, cache.entries, types.UID("gone"))
+}
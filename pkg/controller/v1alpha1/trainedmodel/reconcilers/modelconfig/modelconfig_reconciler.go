@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -51,44 +53,59 @@ func NewModelConfigReconciler(client client.Client, clientset kubernetes.Interfa
 
 func (c *ModelConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request, tm *v1alpha1.TrainedModel) error {
 	log.Info("Reconciling TrainedModel", "apiVersion", tm.APIVersion, "trainedmodel", tm.Spec)
-	shardStrategy := memory.MemoryStrategy{}
-	shardId := shardStrategy.GetOrAssignShard(tm)
+	// The TrainedModel is served by the shard it is assigned to, whose modelConfig is created by the InferenceService controller
+	shardId, _ := memory.ShardOf(tm)
+	if tm.DeletionTimestamp != nil {
+		// A TrainedModel is being deleted, remove the model from the model configmap
+		return c.RemoveFromShard(ctx, req, tm, shardId)
+	}
+	desiredModelConfig, err := c.getModelConfig(ctx, req, tm, shardId)
+	if err != nil {
+		return err
+	}
+	// A TrainedModel is created or updated, add or update the model from the model configmap
+	modelConfig := modelconfig.ModelConfig{Name: tm.Name, Spec: tm.Spec.Model}
+	updatedConfigs := []modelconfig.ModelConfig{modelConfig}
+	configDelta := modelconfig.NewConfigsDelta(updatedConfigs, nil)
+	if err := configDelta.Process(desiredModelConfig); err != nil {
+		return fmt.Errorf("Can not add or update a model %v from config because of error %w", tm.Name, err)
+	}
+	// Update the model Config created by the InferenceService controller
+	return c.client.Update(ctx, desiredModelConfig)
+}
+
+// RemoveFromShard removes the TrainedModel from the model configmap of a shard, when it is deleted
+// or moved to another shard.
+func (c *ModelConfigReconciler) RemoveFromShard(ctx context.Context, req ctrl.Request, tm *v1alpha1.TrainedModel, shardId int) error {
+	desiredModelConfig, err := c.getModelConfig(ctx, req, tm, shardId)
+	if apierrors.IsNotFound(err) {
+		// The modelconfig of a removed shard is deleted with it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	deletedConfigs := []string{tm.Name}
+	configDelta := modelconfig.NewConfigsDelta([]modelconfig.ModelConfig{}, deletedConfigs)
+	if err := configDelta.Process(desiredModelConfig); err != nil {
+		return fmt.Errorf("Can not remove model %v from config because of error %w", tm.Name, err)
+	}
+	// Update the model Config created by the InferenceService controller
+	return c.client.Update(ctx, desiredModelConfig)
+}
+
+func (c *ModelConfigReconciler) getModelConfig(ctx context.Context, req ctrl.Request, tm *v1alpha1.TrainedModel, shardId int) (*corev1.ConfigMap, error) {
 	// Use tm's parent InferenceService field to get the model modelConfig
 	modelConfigName := constants.ModelConfigName(tm.Spec.InferenceService, shardId)
 	log.Info("Reconciling modelConfig", "modelConfigName", modelConfigName, "namespace", req.Namespace)
 	desiredModelConfig, err := c.clientset.CoreV1().ConfigMaps(req.Namespace).Get(ctx, modelConfigName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, err
+	}
 	if err != nil {
 		log.Error(err, "Failed to find model ConfigMap to reconcile for InferenceService", "name", tm.Spec.Model, "namespace", req.Namespace)
 		// Error reading the object - requeue the request.
-		return err
-	}
-	if tm.DeletionTimestamp != nil {
-		// A TrainedModel is being deleted, remove the model from the model configmap
-		deletedConfigs := []string{tm.Name}
-		configDelta := modelconfig.NewConfigsDelta([]modelconfig.ModelConfig{}, deletedConfigs)
-		err := configDelta.Process(desiredModelConfig)
-		if err != nil {
-			return fmt.Errorf("Can not remove model %v from config because of error %w", tm.Name, err)
-		}
-		// Update the model Config created by the InferenceService controller
-		err = c.client.Update(ctx, desiredModelConfig)
-		if err != nil {
-			return err
-		}
-	} else {
-		// A TrainedModel is created or updated, add or update the model from the model configmap
-		modelConfig := modelconfig.ModelConfig{Name: tm.Name, Spec: tm.Spec.Model}
-		updatedConfigs := []modelconfig.ModelConfig{modelConfig}
-		configDelta := modelconfig.NewConfigsDelta(updatedConfigs, nil)
-		err := configDelta.Process(desiredModelConfig)
-		if err != nil {
-			return fmt.Errorf("Can not add or update a model %v from config because of error %w", tm.Name, err)
-		}
-		// Update the model Config created by the InferenceService controller
-		err = c.client.Update(ctx, desiredModelConfig)
-		if err != nil {
-			return err
-		}
+		return nil, err
 	}
-	return nil
+	return desiredModelConfig, nil
 }
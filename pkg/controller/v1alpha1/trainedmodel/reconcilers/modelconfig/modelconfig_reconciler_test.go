@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
 )
 
 //nolint:unparam // keeping param for test flexibility
@@ -94,6 +96,26 @@ func TestModelConfigReconciler_Reconcile(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("configmap of a new shard not created yet", func(t *testing.T) {
+		tm := makeTrainedModel(tmName, isvc, model, false)
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+		reconciler := NewModelConfigReconciler(client, k8sfake.NewSimpleClientset(), scheme)
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: tmName}}
+
+		err := reconciler.Reconcile(t.Context(), req, tm)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("configmap of a removed shard", func(t *testing.T) {
+		tm := makeTrainedModel(tmName, isvc, model, true)
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+		reconciler := NewModelConfigReconciler(client, k8sfake.NewSimpleClientset(), scheme)
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: tmName}}
+
+		err := reconciler.Reconcile(t.Context(), req, tm)
+		assert.NoError(t, err)
+	})
+
 	t.Run("update error", func(t *testing.T) {
 		tm := makeTrainedModel(tmName, isvc, model, false)
 		cm := makeConfigMap(configName, ns, map[string]string{})
@@ -175,13 +197,13 @@ func TestModelConfigReconciler_Reconcile_ProcessError_AddOrUpdate(t *testing.T)
 		model      = "gs://foo/bar"
 		tmName     = "tm1"
 		shardId    = "0"
-		configName = "my-isvc-0-modelconfig"
+		configName = "modelconfig-my-isvc-0"
 	)
 
 	tm := makeTrainedModel(tmName, isvc, model, false)
 	// Intentionally create a configmap with invalid data to cause Process to fail
 	cm := makeConfigMap(configName, ns, map[string]string{
-		"invalid": string([]byte{0xff, 0xfe}),
+		constants.ModelConfigFileName: "invalid",
 	})
 
 	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
@@ -205,13 +227,13 @@ func TestModelConfigReconciler_Reconcile_ProcessError_Delete(t *testing.T) {
 		model      = "gs://foo/bar"
 		tmName     = "tm1"
 		shardId    = "0"
-		configName = "my-isvc-0-modelconfig"
+		configName = "modelconfig-my-isvc-0"
 	)
 
 	tm := makeTrainedModel(tmName, isvc, model, true)
 	// Intentionally create a configmap with invalid data to cause Process to fail
 	cm := makeConfigMap(configName, ns, map[string]string{
-		"invalid": string([]byte{0xff, 0xfe}),
+		constants.ModelConfigFileName: "invalid",
 	})
 
 	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
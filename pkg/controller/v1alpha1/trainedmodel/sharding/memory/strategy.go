@@ -17,22 +17,175 @@ limitations under the License.
 package memory
 
 import (
+	"context"
+	"slices"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
 )
 
-// TODO MemoryStrategy will be implemented in another PR
-type MemoryStrategy struct{}
+// MemoryStrategy bin-packs the TrainedModels of a multi-model InferenceService into shards. Each shard
+// is served by its own set of predictor replicas, so the models of a shard share the memory of a
+// predictor replica, which is the memory limit of the predictor container.
+type MemoryStrategy struct {
+	// capacity is the memory of a predictor replica
+	capacity resource.Quantity
+	// multiShard is false when the InferenceService can only be served by a single shard
+	multiShard bool
+	// shards are the models assigned to each shard
+	shards map[int][]*v1alpha1.TrainedModel
+}
+
+// NewMemoryStrategy returns the sharding strategy of the InferenceService given its TrainedModels.
+// Only the InferenceServices in Standard deployment mode are split in several shards, since the
+// additional predictor sets are plain deployments.
+func NewMemoryStrategy(isvc *v1beta1.InferenceService, trainedModels []v1alpha1.TrainedModel) *MemoryStrategy {
+	strategy := &MemoryStrategy{
+		multiShard: constants.ParseDeploymentMode(isvc.Status.DeploymentMode) == constants.Standard,
+		shards:     map[int][]*v1alpha1.TrainedModel{},
+	}
+	if isvc.Spec.Predictor.GetExtensions() != nil && len(isvc.Spec.Predictor.GetImplementations()) > 0 {
+		container := isvc.Spec.Predictor.GetImplementation().GetContainer(isvc.ObjectMeta, isvc.Spec.Predictor.GetExtensions(), nil)
+		strategy.capacity = *container.Resources.Limits.Memory()
+	}
+	for i := range trainedModels {
+		if shardId, ok := ShardOf(&trainedModels[i]); ok {
+			strategy.shards[shardId] = append(strategy.shards[shardId], &trainedModels[i])
+		}
+	}
+	return strategy
+}
+
+// ListShards returns the shards of the InferenceService from the assignments of its TrainedModels.
+func ListShards(ctx context.Context, c client.Client, isvc *v1beta1.InferenceService) ([]int, error) {
+	var trainedModels v1alpha1.TrainedModelList
+	if err := c.List(ctx, &trainedModels, client.InNamespace(isvc.Namespace), client.MatchingLabels{constants.ParentInferenceServiceLabel: isvc.Name}); err != nil {
+		return nil, err
+	}
+	return NewMemoryStrategy(isvc, trainedModels.Items).GetShard(), nil
+}
+
+// ShardOf returns the shard a TrainedModel is assigned to. The models allocated before they were
+// sharded are served by the first shard.
+func ShardOf(tm *v1alpha1.TrainedModel) (int, bool) {
+	if tm.Status.Shard != nil {
+		return int(*tm.Status.Shard), true
+	}
+	if _, ok := tm.Labels[constants.TrainedModelAllocated]; ok {
+		return 0, true
+	}
+	return 0, false
+}
+
+// GetOrAssignShard returns the shard of the TrainedModel, or assigns it to the first shard with
+// enough memory left, or else to a new shard. It returns false when the model does not fit in the
+// memory of a predictor replica, or in the single shard of the InferenceService.
+func (v *MemoryStrategy) GetOrAssignShard(tm *v1alpha1.TrainedModel) (int, bool) {
+	if shardId, ok := v.shardOfModel(tm.Name); ok {
+		return shardId, true
+	}
+	shardId, ok := ShardOf(tm)
+	if !ok {
+		shardId, ok = v.fit(tm.Spec.Model.Memory, v.GetShard(), nil, v.multiShard)
+	}
+	if !ok {
+		return 0, false
+	}
+	v.shards[shardId] = append(v.shards[shardId], tm)
+	return shardId, true
+}
+
+// GetShard returns the ids of the shards in use in increasing order, the first shard always
+// being in use.
+func (v *MemoryStrategy) GetShard() []int {
+	shards := []int{0}
+	for shardId, models := range v.shards {
+		if shardId != 0 && len(models) > 0 {
+			shards = append(shards, shardId)
+		}
+	}
+	sort.Ints(shards)
+	return shards
+}
+
+// Rebalance empties the last shards whose models all fit in the memory left by the other shards,
+// so that their predictor sets can be removed, and returns the new shard of the moved models.
+// The models of a shard are only moved together, since moving a model reloads it.
+func (v *MemoryStrategy) Rebalance() map[string]int {
+	moves := map[string]int{}
+	shards := v.GetShard()
+	for i := len(shards) - 1; i > 0; i-- {
+		planned := map[int]resource.Quantity{}
+		targets := map[string]int{}
+		for _, tm := range v.shards[shards[i]] {
+			shardId, ok := v.fit(tm.Spec.Model.Memory, shards[:i], planned, false)
+			if !ok {
+				break
+			}
+			used := planned[shardId]
+			used.Add(tm.Spec.Model.Memory)
+			planned[shardId] = used
+			targets[tm.Name] = shardId
+		}
+		if len(targets) < len(v.shards[shards[i]]) {
+			continue
+		}
+		for _, tm := range v.shards[shards[i]] {
+			v.shards[targets[tm.Name]] = append(v.shards[targets[tm.Name]], tm)
+			moves[tm.Name] = targets[tm.Name]
+		}
+		delete(v.shards, shards[i])
+	}
+	return moves
+}
+
+// fit returns the first of the shards with enough memory left for the model, given the memory
+// planned on top of their models, or else a new shard when it is allowed.
+func (v *MemoryStrategy) fit(memory resource.Quantity, shards []int, planned map[int]resource.Quantity, newShard bool) (int, bool) {
+	if v.capacity.Cmp(memory) < 0 {
+		return 0, false
+	}
+	for _, shardId := range shards {
+		used := v.usedMemory(shardId)
+		if extra, ok := planned[shardId]; ok {
+			used.Add(extra)
+		}
+		used.Add(memory)
+		if v.capacity.Cmp(used) >= 0 {
+			return shardId, true
+		}
+	}
+	if !newShard {
+		return 0, false
+	}
+	// the ids of the removed shards are reused
+	for shardId := 1; ; shardId++ {
+		if !slices.Contains(shards, shardId) {
+			return shardId, true
+		}
+	}
+}
 
-// Return a TrainedModel's shardId
-func (v *MemoryStrategy) GetOrAssignShard(tm *v1alpha1.TrainedModel) int {
-	// TODO to be implemented in another PR
-	// Currently each InferenceService only has one shard with id=0
-	return 0
+func (v *MemoryStrategy) usedMemory(shardId int) resource.Quantity {
+	used := resource.Quantity{}
+	for _, tm := range v.shards[shardId] {
+		used.Add(tm.Spec.Model.Memory)
+	}
+	return used
 }
 
-func (v *MemoryStrategy) GetShard(isvc *v1beta1.InferenceService) []int {
-	// TODO to be implemented in another PR
-	// Currently each InferenceService only has one shard with id=0
-	return []int{0}
+func (v *MemoryStrategy) shardOfModel(name string) (int, bool) {
+	for shardId, models := range v.shards {
+		for _, tm := range models {
+			if tm.Name == name {
+				return shardId, true
+			}
+		}
+	}
+	return 0, false
 }
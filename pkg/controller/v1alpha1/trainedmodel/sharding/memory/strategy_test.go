@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeInferenceService(memory string, deploymentMode constants.DeploymentModeType) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "mms", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				Tensorflow: &v1beta1.TFServingSpec{
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						Container: corev1.Container{
+							Name: constants.InferenceServiceContainerName,
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{DeploymentMode: string(deploymentMode)},
+	}
+}
+
+func makeTrainedModel(name string, memory string, shard *int32) v1alpha1.TrainedModel {
+	return v1alpha1.TrainedModel{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.TrainedModelSpec{
+			InferenceService: "mms",
+			Model:            v1alpha1.ModelSpec{Memory: resource.MustParse(memory)},
+		},
+		Status: v1alpha1.TrainedModelStatus{Shard: shard},
+	}
+}
+
+func TestGetOrAssignShard(t *testing.T) {
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		trainedModels  []v1alpha1.TrainedModel
+		model          v1alpha1.TrainedModel
+		expectedShard  int
+		expectedOk     bool
+		expectedShards []int
+	}{
+		"first shard with enough memory": {
+			deploymentMode: constants.Standard,
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "1Gi", ptr.To(int32(0))),
+				makeTrainedModel("b", "512Mi", ptr.To(int32(1))),
+			},
+			model:          makeTrainedModel("c", "1Gi", nil),
+			expectedShard:  0,
+			expectedOk:     true,
+			expectedShards: []int{0, 1},
+		},
+		"next shard with enough memory": {
+			deploymentMode: constants.Standard,
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "1536Mi", ptr.To(int32(0))),
+				makeTrainedModel("b", "512Mi", ptr.To(int32(1))),
+			},
+			model:          makeTrainedModel("c", "1Gi", nil),
+			expectedShard:  1,
+			expectedOk:     true,
+			expectedShards: []int{0, 1},
+		},
+		"new shard": {
+			deploymentMode: constants.Standard,
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "1536Mi", ptr.To(int32(0))),
+				makeTrainedModel("b", "1536Mi", ptr.To(int32(2))),
+			},
+			model:          makeTrainedModel("c", "1Gi", nil),
+			expectedShard:  1,
+			expectedOk:     true,
+			expectedShards: []int{0, 1, 2},
+		},
+		"assigned model": {
+			deploymentMode: constants.Standard,
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "1Gi", ptr.To(int32(0))),
+				makeTrainedModel("c", "1Gi", ptr.To(int32(1))),
+			},
+			model:          makeTrainedModel("c", "1Gi", nil),
+			expectedShard:  1,
+			expectedOk:     true,
+			expectedShards: []int{0, 1},
+		},
+		"model allocated before sharding": {
+			deploymentMode: constants.Standard,
+			trainedModels: []v1alpha1.TrainedModel{
+				func() v1alpha1.TrainedModel {
+					tm := makeTrainedModel("a", "1536Mi", nil)
+					tm.Labels = map[string]string{constants.TrainedModelAllocated: "mms"}
+					return tm
+				}(),
+			},
+			model:          makeTrainedModel("c", "1Gi", nil),
+			expectedShard:  1,
+			expectedOk:     true,
+			expectedShards: []int{0, 1},
+		},
+		"model larger than a replica": {
+			deploymentMode: constants.Standard,
+			model:          makeTrainedModel("c", "3Gi", nil),
+			expectedOk:     false,
+			expectedShards: []int{0},
+		},
+		"single shard in Knative deployment mode": {
+			deploymentMode: constants.Knative,
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "1536Mi", ptr.To(int32(0))),
+			},
+			model:          makeTrainedModel("c", "1Gi", nil),
+			expectedOk:     false,
+			expectedShards: []int{0},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			strategy := NewMemoryStrategy(makeInferenceService("2Gi", scenario.deploymentMode), scenario.trainedModels)
+			shard, ok := strategy.GetOrAssignShard(&scenario.model)
+			assert.Equal(t, scenario.expectedOk, ok)
+			if scenario.expectedOk {
+				assert.Equal(t, scenario.expectedShard, shard)
+			}
+			assert.Equal(t, scenario.expectedShards, strategy.GetShard())
+		})
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	scenarios := map[string]struct {
+		trainedModels  []v1alpha1.TrainedModel
+		expectedMoves  map[string]int
+		expectedShards []int
+	}{
+		"empty the last shard": {
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "1536Mi", ptr.To(int32(0))),
+				makeTrainedModel("b", "1Gi", ptr.To(int32(1))),
+				makeTrainedModel("c", "512Mi", ptr.To(int32(2))),
+				makeTrainedModel("d", "512Mi", ptr.To(int32(2))),
+			},
+			expectedMoves:  map[string]int{"c": 0, "d": 1},
+			expectedShards: []int{0, 1},
+		},
+		"empty several shards": {
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "512Mi", ptr.To(int32(0))),
+				makeTrainedModel("b", "512Mi", ptr.To(int32(1))),
+				makeTrainedModel("c", "512Mi", ptr.To(int32(2))),
+			},
+			expectedMoves:  map[string]int{"b": 0, "c": 0},
+			expectedShards: []int{0},
+		},
+		"shard which cannot be emptied": {
+			trainedModels: []v1alpha1.TrainedModel{
+				makeTrainedModel("a", "1536Mi", ptr.To(int32(0))),
+				makeTrainedModel("b", "512Mi", ptr.To(int32(1))),
+				makeTrainedModel("c", "1Gi", ptr.To(int32(1))),
+			},
+			expectedMoves:  map[string]int{},
+			expectedShards: []int{0, 1},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			strategy := NewMemoryStrategy(makeInferenceService("2Gi", constants.Standard), scenario.trainedModels)
+			assert.Equal(t, scenario.expectedMoves, strategy.Rebalance())
+			assert.Equal(t, scenario.expectedShards, strategy.GetShard())
+		})
+	}
+}
@@ -59,6 +59,7 @@ var _ = BeforeSuite(func(ctx SpecContext) {
 
 		return (&TrainedModelReconciler{
 			Client:                mgr.GetClient(),
+			APIReader:             mgr.GetAPIReader(),
 			Clientset:             clientset,
 			Scheme:                mgr.GetScheme(),
 			Log:                   ctrl.Log.WithName("v1beta1TrainedModelController"),
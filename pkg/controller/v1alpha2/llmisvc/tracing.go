@@ -21,6 +21,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha2"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 const (
@@ -28,39 +29,6 @@ const (
 	defaultServerServiceName    = "inference-server"
 )
 
-// otelResourceAttributeEnvVars returns the standard k8s resource attribute env
-// vars that are always injected when tracing is enabled. These use the downward
-// API to populate node and pod names, then compose OTEL_RESOURCE_ATTRIBUTES.
-func otelResourceAttributeEnvVars(namespace, llmisvcName string) []corev1.EnvVar {
-	return []corev1.EnvVar{
-		{
-			Name: "OTEL_RESOURCE_ATTRIBUTES_NODE_NAME",
-			ValueFrom: &corev1.EnvVarSource{
-				FieldRef: &corev1.ObjectFieldSelector{
-					APIVersion: "v1",
-					FieldPath:  "spec.nodeName",
-				},
-			},
-		},
-		{
-			Name: "OTEL_RESOURCE_ATTRIBUTES_POD_NAME",
-			ValueFrom: &corev1.EnvVarSource{
-				FieldRef: &corev1.ObjectFieldSelector{
-					APIVersion: "v1",
-					FieldPath:  "metadata.name",
-				},
-			},
-		},
-		{
-			Name: "OTEL_RESOURCE_ATTRIBUTES",
-			Value: "k8s.namespace.name=" + namespace +
-				",k8s.node.name=$(OTEL_RESOURCE_ATTRIBUTES_NODE_NAME)" +
-				",k8s.pod.name=$(OTEL_RESOURCE_ATTRIBUTES_POD_NAME)" +
-				",llmisvc.name=" + llmisvcName,
-		},
-	}
-}
-
 // injectSchedulerTracing injects tracing args and env vars into the scheduler
 // (EPP) deployment's main container. The scheduler uses the --tracing=true flag
 // and standard OTEL_* env vars. Returns true if the container was mutated.
@@ -77,7 +45,7 @@ func injectSchedulerTracing(t *v1alpha2.TracingSpec, namespace, llmisvcName stri
 		container.Args = append(container.Args, "--tracing=true")
 	}
 
-	resourceAttrs := otelResourceAttributeEnvVars(namespace, llmisvcName)
+	resourceAttrs := utils.OtelResourceAttributeEnvVars(namespace, "llmisvc.name", llmisvcName)
 	tracingEnvVars := make([]corev1.EnvVar, 0, 5+len(resourceAttrs))
 	tracingEnvVars = append(tracingEnvVars,
 		corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: defaultSchedulerServiceName},
@@ -118,7 +86,7 @@ func injectServerTracing(t *v1alpha2.TracingSpec, namespace, llmisvcName, roleSu
 	}
 
 	serviceName := defaultServerServiceName + roleSuffix
-	resourceAttrs := otelResourceAttributeEnvVars(namespace, llmisvcName)
+	resourceAttrs := utils.OtelResourceAttributeEnvVars(namespace, "llmisvc.name", llmisvcName)
 
 	tracingEnvVars := make([]corev1.EnvVar, 0, 5+len(resourceAttrs))
 	tracingEnvVars = append(tracingEnvVars,
@@ -239,25 +239,6 @@ func TestInjectServerTracingIntoPodSpec_NoMainContainer(t *testing.T) {
 	g.Expect(podSpec.Containers[0].Env).To(BeEmpty())
 }
 
-func TestOtelResourceAttributeEnvVars(t *testing.T) {
-	g := NewGomegaWithT(t)
-	envVars := otelResourceAttributeEnvVars("my-namespace", "my-service")
-
-	g.Expect(envVars).To(HaveLen(3))
-
-	g.Expect(envVars[0].Name).To(Equal("OTEL_RESOURCE_ATTRIBUTES_NODE_NAME"))
-	g.Expect(envVars[0].ValueFrom.FieldRef.FieldPath).To(Equal("spec.nodeName"))
-
-	g.Expect(envVars[1].Name).To(Equal("OTEL_RESOURCE_ATTRIBUTES_POD_NAME"))
-	g.Expect(envVars[1].ValueFrom.FieldRef.FieldPath).To(Equal("metadata.name"))
-
-	g.Expect(envVars[2].Name).To(Equal("OTEL_RESOURCE_ATTRIBUTES"))
-	g.Expect(envVars[2].Value).To(ContainSubstring("k8s.namespace.name=my-namespace"))
-	g.Expect(envVars[2].Value).To(ContainSubstring("llmisvc.name=my-service"))
-	g.Expect(envVars[2].Value).To(ContainSubstring("k8s.node.name=$(OTEL_RESOURCE_ATTRIBUTES_NODE_NAME)"))
-	g.Expect(envVars[2].Value).To(ContainSubstring("k8s.pod.name=$(OTEL_RESOURCE_ATTRIBUTES_POD_NAME)"))
-}
-
 func TestMergeEnvVars(t *testing.T) {
 	g := NewGomegaWithT(t)
 
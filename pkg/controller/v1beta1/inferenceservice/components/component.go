@@ -32,7 +32,6 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
-	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/sharding/memory"
 	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/utils"
@@ -92,19 +91,32 @@ func addBatcherAnnotations(batcher *v1beta1.Batcher, annotations map[string]stri
 			s := strconv.Itoa(*batcher.MaxLatency)
 			annotations[constants.BatcherMaxLatencyInternalAnnotationKey] = s
 		}
+		if batcher.TargetLatency != nil {
+			s := strconv.Itoa(*batcher.TargetLatency)
+			annotations[constants.BatcherTargetLatencyInternalAnnotationKey] = s
+		}
+		if batcher.MaxQueueDepth != nil {
+			s := strconv.Itoa(*batcher.MaxQueueDepth)
+			annotations[constants.BatcherMaxQueueDepthInternalAnnotationKey] = s
+		}
+		if len(batcher.ModelBatchConfigs) > 0 {
+			// in the format of the --model-batch-config flag of the agent
+			modelConfigs := make([]string, 0, len(batcher.ModelBatchConfigs))
+			for _, config := range batcher.ModelBatchConfigs {
+				modelConfigs = append(modelConfigs, fmt.Sprintf("%s=%d:%d", config.Name, config.MaxBatchSize, config.MaxLatency))
+			}
+			annotations[constants.BatcherModelBatchConfigInternalAnnotationKey] = strings.Join(modelConfigs, ",")
+		}
 	}
 }
 
 func addAgentAnnotations(isvc *v1beta1.InferenceService, annotations map[string]string) bool {
 	if v1beta1utils.IsMMSPredictor(&isvc.Spec.Predictor) {
 		annotations[constants.AgentShouldInjectAnnotationKey] = "true"
-		shardStrategy := memory.MemoryStrategy{}
-		for _, id := range shardStrategy.GetShard(isvc) {
-			multiModelConfigMapName := constants.ModelConfigName(isvc.Name, id)
-			annotations[constants.AgentModelConfigVolumeNameAnnotationKey] = multiModelConfigMapName
-			annotations[constants.AgentModelConfigMountPathAnnotationKey] = constants.ModelConfigDir
-			annotations[constants.AgentModelDirAnnotationKey] = constants.ModelDir
-		}
+		// The predictor serves the first shard, the other shards are served by their own predictors
+		annotations[constants.AgentModelConfigVolumeNameAnnotationKey] = constants.ModelConfigName(isvc.Name, 0)
+		annotations[constants.AgentModelConfigMountPathAnnotationKey] = constants.ModelConfigDir
+		annotations[constants.AgentModelDirAnnotationKey] = constants.ModelDir
 		return true
 	}
 	return false
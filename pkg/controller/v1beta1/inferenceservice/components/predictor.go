@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -46,6 +47,7 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/sharding/memory"
 	knutils "github.com/kserve/kserve/pkg/controller/v1alpha1/utils"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/knative"
 	modelconfig "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
@@ -108,7 +110,17 @@ func (p *Predictor) buildPredictorResources(ctx context.Context, isvc *v1beta1.I
 	// Add ModelStorageSpec annotations so mutator will mount storage credentials to InferenceService's predictor
 	addStorageSpecAnnotations(isvc.Spec.Predictor.GetImplementation().GetStorageSpec(), annotations)
 	// Add agent annotations so mutator will mount model agent to multi-model InferenceService's predictor
-	addAgentAnnotations(isvc, annotations)
+	if addAgentAnnotations(isvc, annotations) {
+		// The claims of the pvc:// TrainedModels are mounted on the model agent of every shard, so that
+		// the models keep their claim when they move to another shard
+		claims, err := p.trainedModelClaims(ctx, isvc)
+		if err != nil {
+			return nil, err
+		}
+		if len(claims) > 0 {
+			annotations[constants.AgentPvcClaimsAnnotationKey] = strings.Join(claims, ",")
+		}
+	}
 
 	predictor := isvc.Spec.Predictor.GetImplementation()
 
@@ -232,10 +244,15 @@ func (p *Predictor) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 	if p.deploymentMode == constants.Standard {
 		rawDeployment = true
 		podLabelKey = constants.RawDeploymentAppLabel
+		// Reconcile the predictors of the shards of a multi-model InferenceService
+		shardNames, err := p.reconcileShardDeployments(ctx, isvc)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile shard deployments")
+		}
 		// Reconcile canary first so CanaryStatuses is fresh when the stable
 		// minReplicas reduction decision is made below. This ensures the
 		// stable Deployment is not scaled down until canary pods are Ready.
-		if err := p.reconcileCanaryDeployments(ctx, isvc); err != nil {
+		if err := p.reconcileCanaryDeployments(ctx, isvc, shardNames); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile canary deployments")
 		}
 		// This is main RawKubeReconciler to create objects (deployment, svc, scaler)
@@ -934,9 +951,12 @@ func buildCanaryPredictor(stable v1beta1.PredictorSpec, canary v1beta1.CanarySpe
 	return canaryPredictor, nil
 }
 
-func (p *Predictor) reconcileCanaryDeployments(ctx context.Context, isvc *v1beta1.InferenceService) error {
+func (p *Predictor) reconcileCanaryDeployments(ctx context.Context, isvc *v1beta1.InferenceService, shardNames []string) error {
 	stableName := constants.PredictorServiceName(isvc.Name, isvc.Spec.Predictor.Name)
 	expectedNames := map[string]bool{stableName: true}
+	for _, shardName := range shardNames {
+		expectedNames[shardName] = true
+	}
 
 	stablePredictor := isvc.Spec.Predictor
 
@@ -1055,3 +1075,85 @@ func (p *Predictor) reconcileCanaryDeployments(ctx context.Context, isvc *v1beta
 
 	return nil
 }
+
+// trainedModelClaims returns the sorted claims of the pvc:// storage URIs of the TrainedModels of the InferenceService.
+func (p *Predictor) trainedModelClaims(ctx context.Context, isvc *v1beta1.InferenceService) ([]string, error) {
+	var trainedModels v1alpha1.TrainedModelList
+	if err := p.client.List(ctx, &trainedModels, client.InNamespace(isvc.Namespace), client.MatchingLabels{constants.ParentInferenceServiceLabel: isvc.Name}); err != nil {
+		return nil, errors.Wrapf(err, "fails to list the trained models of %s", isvc.Name)
+	}
+	var claims []string
+	for _, tm := range trainedModels.Items {
+		if !strings.HasPrefix(tm.Spec.Model.StorageURI, constants.PvcURIPrefix) {
+			continue
+		}
+		claim, _, err := utils.ParsePvcURI(tm.Spec.Model.StorageURI)
+		if err != nil || claim == "" {
+			p.Log.Info("Skipping the invalid pvc storage uri of a trained model", "trainedmodel", tm.Name, "storageUri", tm.Spec.Model.StorageURI)
+			continue
+		}
+		if !utils.Includes(claims, claim) {
+			claims = append(claims, claim)
+		}
+	}
+	sort.Strings(claims)
+	return claims, nil
+}
+
+// reconcileShardDeployments reconciles a predictor for each shard of a multi-model InferenceService
+// other than the first one, which is served by the predictor of the InferenceService, and returns
+// their names. The predictors of the removed shards are deleted with the orphaned predictors, and
+// their model configs are deleted here.
+func (p *Predictor) reconcileShardDeployments(ctx context.Context, isvc *v1beta1.InferenceService) ([]string, error) {
+	if !isvcutils.IsMMSPredictor(&isvc.Spec.Predictor) {
+		return nil, nil
+	}
+	shards, err := memory.ListShards(ctx, p.client, isvc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fails to list the shards of %s", isvc.Name)
+	}
+
+	shardNames := make([]string, 0, len(shards)-1)
+	for _, shardId := range shards[1:] {
+		res, err := p.buildPredictorResources(ctx, isvc, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fails to build resources for shard %d", shardId)
+		}
+		shardName := constants.PredictorShardServiceName(isvc.Name, shardId)
+		res.objectMeta.Name = shardName
+		res.objectMeta.Labels[constants.PredictorShardLabel] = strconv.Itoa(shardId)
+		res.objectMeta.Annotations[constants.AgentModelConfigVolumeNameAnnotationKey] = constants.ModelConfigName(isvc.Name, shardId)
+
+		// every shard is served by as many replicas as the first one
+		componentExt := isvc.Spec.Predictor.ComponentExtensionSpec
+		r, err := raw.NewRawKubeReconciler(ctx, p.client, p.clientset, p.scheme, constants.InferenceServiceResource, res.objectMeta, metav1.ObjectMeta{},
+			&componentExt, &res.podSpec, nil, nil, nil, nil, nil, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fails to create shard reconciler for shard %d", shardId)
+		}
+		if _, err := r.Reconcile(ctx, isvc); err != nil {
+			return nil, errors.Wrapf(err, "fails to reconcile shard %d", shardId)
+		}
+		shardNames = append(shardNames, shardName)
+	}
+
+	deployList := &appsv1.DeploymentList{}
+	if err := p.client.List(ctx, deployList, client.InNamespace(isvc.Namespace), client.MatchingLabels{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+		constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+	}, client.HasLabels{constants.PredictorShardLabel}); err != nil {
+		return nil, errors.Wrapf(err, "fails to list shard deployments for cleanup")
+	}
+	for _, deploy := range deployList.Items {
+		shardId, err := strconv.Atoi(deploy.Labels[constants.PredictorShardLabel])
+		if err != nil || utils.Includes(shards, shardId) {
+			continue
+		}
+		modelConfigName := constants.ModelConfigName(isvc.Name, shardId)
+		p.Log.Info("Deleting the model config of a removed shard", "configmap", modelConfigName)
+		if err := p.clientset.CoreV1().ConfigMaps(isvc.Namespace).Delete(ctx, modelConfigName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "fails to delete the model config of shard %d", shardId)
+		}
+	}
+	return shardNames, nil
+}
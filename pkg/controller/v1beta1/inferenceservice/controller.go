@@ -39,6 +39,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -85,7 +86,7 @@ import (
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;delete
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
@@ -599,6 +600,47 @@ func podInitContainersPredicate() predicate.Funcs {
 	}
 }
 
+// trainedModelFunc reconciles the parent InferenceService of a TrainedModel, whose predictors serve
+// the shards the TrainedModels are assigned to.
+func (r *InferenceServiceReconciler) trainedModelFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	tm, ok := obj.(*v1alpha1.TrainedModel)
+	if !ok || tm == nil {
+		return nil
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: tm.Namespace,
+				Name:      tm.Spec.InferenceService,
+			},
+		},
+	}
+}
+
+// trainedModelShardPredicate returns a predicate that filters TrainedModel events to only include
+// the shard assignments and the deletions, which may add or remove a shard of their InferenceService,
+// and the storage URI changes, which may add or remove a claim mounted on the model agents.
+func trainedModelShardPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldModel, ok := e.ObjectOld.(*v1alpha1.TrainedModel)
+			if !ok {
+				return false
+			}
+			newModel, ok := e.ObjectNew.(*v1alpha1.TrainedModel)
+			if !ok {
+				return false
+			}
+			return !ptr.Equal(oldModel.Status.Shard, newModel.Status.Shard) ||
+				oldModel.Spec.Model.StorageURI != newModel.Spec.Model.StorageURI
+		},
+		// a TrainedModel is created before it is assigned to a shard
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
 func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployConfig *v1beta1.DeployConfig, ingressConfig *v1beta1.IngressConfig) error {
 	r.ClientConfig = mgr.GetConfig()
 	ctx := context.Background()
@@ -733,6 +775,16 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		r.Log.Info("The InferenceService controller won't watch serving.kserve.io/v1alpha1/ClusterServingRuntime resources because the CRD is not available.")
 	}
 
+	tmFound, err := utils.IsCrdAvailable(r.ClientConfig, v1alpha1.SchemeGroupVersion.String(), "TrainedModel")
+	if err != nil {
+		return err
+	}
+	if tmFound {
+		ctrlBuilder = ctrlBuilder.Watches(&v1alpha1.TrainedModel{}, handler.EnqueueRequestsFromMapFunc(r.trainedModelFunc), builder.WithPredicates(trainedModelShardPredicate()))
+	} else {
+		r.Log.Info("The InferenceService controller won't watch serving.kserve.io/v1alpha1/TrainedModel resources because the CRD is not available.")
+	}
+
 	return ctrlBuilder.Complete(r)
 }
 
@@ -55,8 +55,11 @@ func (c *ModelConfigReconciler) Reconcile(ctx context.Context, isvc *v1beta1.Inf
 		// Create an empty modelConfig for every InferenceService shard
 		// An InferenceService without storageUri is an empty model server with for multi-model serving so a modelConfig configmap should be created
 		// An InferenceService with storageUri is considered as multi-model InferenceService with only one model, a modelConfig configmap should be created as well
-		shardStrategy := memory.MemoryStrategy{}
-		for _, id := range shardStrategy.GetShard(isvc) {
+		shards, err := memory.ListShards(ctx, c.client, isvc)
+		if err != nil {
+			return err
+		}
+		for _, id := range shards {
 			modelConfigName := constants.ModelConfigName(isvc.Name, id)
 			_, err := c.clientset.CoreV1().ConfigMaps(isvc.Namespace).Get(ctx, modelConfigName, metav1.GetOptions{})
 			if err != nil {
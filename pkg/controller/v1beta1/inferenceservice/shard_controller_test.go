@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var _ = Describe("Shard deployment controller", func() {
+	configs := getRawKubeTestConfigs()
+
+	Context("When a TrainedModel is assigned to a new shard", func() {
+		It("Should create the predictor of the shard and remove it with its last model", func() {
+			configMap := createInferenceServiceConfigMap(configs)
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+
+			servingRuntime := getServingRuntime("tf-shards", "default")
+			servingRuntime.Spec.MultiModel = ptr.To(true)
+			Expect(k8sClient.Create(context.TODO(), &servingRuntime)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), &servingRuntime)
+
+			serviceName := "shards-test"
+			ctx := context.Background()
+
+			// An InferenceService without storageUri serves the TrainedModels assigned to it
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        serviceName,
+					Namespace:   "default",
+					Annotations: getDefaultAnnotations(constants.AutoscalerClassNone),
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: ptr.To(int32(2)),
+						},
+						Model: &v1beta1.ModelSpec{
+							ModelFormat: v1beta1.ModelFormat{Name: "tensorflow"},
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								RuntimeVersion: proto.String("0.14.0"),
+								Container: corev1.Container{
+									Name:      constants.InferenceServiceContainerName,
+									Resources: defaultResource,
+								},
+							},
+						},
+					},
+				},
+			}
+			isvc.DefaultInferenceService(nil, nil, &v1beta1.SecurityConfig{AutoMountServiceAccountToken: false}, nil, nil)
+			Expect(k8sClient.Create(ctx, isvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, isvc)
+
+			stableKey := types.NamespacedName{Name: constants.PredictorServiceName(serviceName), Namespace: "default"}
+			shardKey := types.NamespacedName{Name: constants.PredictorShardServiceName(serviceName, 1), Namespace: "default"}
+			shardModelConfigKey := types.NamespacedName{Name: constants.ModelConfigName(serviceName, 1), Namespace: "default"}
+
+			Eventually(func() error {
+				return k8sClient.Get(ctx, stableKey, &appsv1.Deployment{})
+			}, timeout, interval).Should(Succeed())
+
+			tm := &v1alpha1.TrainedModel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shards-test-model",
+					Namespace: "default",
+					Labels:    map[string]string{constants.ParentInferenceServiceLabel: serviceName},
+				},
+				Spec: v1alpha1.TrainedModelSpec{
+					InferenceService: serviceName,
+					Model: v1alpha1.ModelSpec{
+						StorageURI: "pvc://models-claim/model",
+						Framework:  "tensorflow",
+						Memory:     resource.MustParse("1G"),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tm)).Should(Succeed())
+			defer k8sClient.Delete(ctx, tm)
+
+			// Assign the TrainedModel to a new shard, as the TrainedModel controller does
+			tm.Status.Shard = ptr.To(int32(1))
+			Expect(k8sClient.Status().Update(ctx, tm)).Should(Succeed())
+
+			// The shard is served by its own predictor, with as many replicas as the first shard
+			Eventually(func() int32 {
+				deploy := &appsv1.Deployment{}
+				if err := k8sClient.Get(ctx, shardKey, deploy); err != nil {
+					return -1
+				}
+				return *deploy.Spec.Replicas
+			}, timeout, interval).Should(Equal(int32(2)))
+
+			shardDeploy := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, shardKey, shardDeploy)).Should(Succeed())
+			Expect(shardDeploy.Labels[constants.PredictorShardLabel]).To(Equal("1"))
+			Expect(k8sClient.Get(ctx, shardModelConfigKey, &corev1.ConfigMap{})).Should(Succeed())
+
+			// The claim of the model is mounted on the model agents of every shard
+			Expect(shardDeploy.Spec.Template.Annotations[constants.AgentPvcClaimsAnnotationKey]).To(Equal("models-claim"))
+			Eventually(func() string {
+				deploy := &appsv1.Deployment{}
+				if err := k8sClient.Get(ctx, stableKey, deploy); err != nil {
+					return ""
+				}
+				return deploy.Spec.Template.Annotations[constants.AgentPvcClaimsAnnotationKey]
+			}, timeout, interval).Should(Equal("models-claim"))
+
+			// Removing the last model of the shard removes its predictor and its model config
+			Expect(k8sClient.Delete(ctx, tm)).Should(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, shardKey, &appsv1.Deployment{})
+				return apierr.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, shardModelConfigKey, &corev1.ConfigMap{})
+				return apierr.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(k8sClient.Get(ctx, stableKey, &appsv1.Deployment{})).Should(Succeed())
+		})
+	})
+})
@@ -35,7 +35,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -84,17 +83,6 @@ func IsMMSPredictor(predictor *v1beta1.PredictorSpec) bool {
 	}
 }
 
-func IsMemoryResourceAvailable(isvc *v1beta1.InferenceService, totalReqMemory resource.Quantity) bool {
-	if isvc.Spec.Predictor.GetExtensions() == nil || len(isvc.Spec.Predictor.GetImplementations()) == 0 {
-		return false
-	}
-
-	container := isvc.Spec.Predictor.GetImplementation().GetContainer(isvc.ObjectMeta, isvc.Spec.Predictor.GetExtensions(), nil)
-
-	predictorMemoryLimit := container.Resources.Limits.Memory()
-	return predictorMemoryLimit.Cmp(totalReqMemory) >= 0
-}
-
 func getModelNameFromArgs(args []string) string {
 	modelName := ""
 	for i, arg := range args {
@@ -195,515 +195,6 @@ func TestIsMMSPredictor(t *testing.T) {
 	}
 }
 
-func TestIsMemoryResourceAvailable(t *testing.T) {
-	g := gomega.NewGomegaWithT(t)
-
-	reqResourcesScenarios := map[string]struct {
-		resource corev1.ResourceRequirements
-		expected bool
-	}{
-		"EnoughMemoryResource": {
-			// Enough memory
-			resource: corev1.ResourceRequirements{
-				Limits: corev1.ResourceList{
-					"cpu":    resource.MustParse("100m"),
-					"memory": resource.MustParse("100Gi"),
-				},
-				Requests: corev1.ResourceList{
-					"cpu":    resource.MustParse("90m"),
-					"memory": resource.MustParse("100Gi"),
-				},
-			},
-			expected: true,
-		},
-		"NotEnoughMemoryResource": {
-			// Enough memory
-			resource: corev1.ResourceRequirements{
-				Limits: corev1.ResourceList{
-					"cpu":    resource.MustParse("100m"),
-					"memory": resource.MustParse("1Mi"),
-				},
-				Requests: corev1.ResourceList{
-					"cpu":    resource.MustParse("90m"),
-					"memory": resource.MustParse("1Mi"),
-				},
-			},
-			expected: false,
-		},
-	}
-
-	totalReqMemory := resource.MustParse("1Gi")
-
-	protocolV1 := constants.ProtocolV1
-	protocolV2 := constants.ProtocolV2
-
-	for _, reqResourcesScenario := range reqResourcesScenarios {
-		scenarios := map[string]struct {
-			isvc InferenceService
-		}{
-			"LightGBM": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "lightgbm",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							LightGBM: &LightGBMSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									Container: corev1.Container{
-										Image:     "customImage:0.1.0",
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"LightGBMWithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "lightgbmWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							LightGBM: &LightGBMSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI: proto.String("gs://someUri"),
-									Container: corev1.Container{
-										Image:     "customImage:0.1.0",
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"Onnx": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "onnx",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							ONNX: &ONNXRuntimeSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									Container: corev1.Container{
-										Image:     "mcr.microsoft.com/onnxruntime/server:v0.5.0",
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"OnnxWithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "onnxWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							ONNX: &ONNXRuntimeSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI: proto.String("gs://someUri"),
-									Container: corev1.Container{
-										Image:     "mcr.microsoft.com/onnxruntime/server:v0.5.0",
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"Pmml": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "pmml",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							PMML: &PMMLSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"PmmlWithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "pmmlWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							PMML: &PMMLSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI: proto.String("gs://someUri"),
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"SKLearnV1": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "sklearn",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							SKLearn: &SKLearnSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									ProtocolVersion: &protocolV1,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"SKLearnV2": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "sklearn2",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							SKLearn: &SKLearnSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									ProtocolVersion: &protocolV2,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"SKLearnV1WithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "sklearnWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							SKLearn: &SKLearnSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI:      proto.String("gs://someUri"),
-									ProtocolVersion: &protocolV1,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"SKLearnV2WithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "sklearn2WithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							SKLearn: &SKLearnSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI:      proto.String("gs://someUri"),
-									ProtocolVersion: &protocolV2,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"tfserving": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "tfserving",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							Tensorflow: &TFServingSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"TfservingWithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "tfservingWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							Tensorflow: &TFServingSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI: proto.String("gs://someUri"),
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"PyTorchV1": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "pytorch",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							PyTorch: &TorchServeSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									ProtocolVersion: &protocolV1,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"PyTorchV2": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "pytorch2",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							PyTorch: &TorchServeSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									ProtocolVersion: &protocolV2,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"PyTorchV1WithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "pytorchWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							PyTorch: &TorchServeSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI:      proto.String("gs://someUri"),
-									ProtocolVersion: &protocolV1,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"PyTorchV2WithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "pytorch2WithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							PyTorch: &TorchServeSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI:      proto.String("gs://someUri"),
-									ProtocolVersion: &protocolV2,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"Triton": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "triton",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							Triton: &TritonSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									ProtocolVersion: &protocolV1,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"TritonWithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "tritonWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							Triton: &TritonSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI:      proto.String("gs://someUri"),
-									ProtocolVersion: &protocolV1,
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"XGBoostV1": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "xgboost",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							XGBoost: &XGBoostSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									RuntimeVersion: proto.String("0.1.0"),
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"XGBoostV2": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "xgboost2",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							XGBoost: &XGBoostSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									ProtocolVersion: &protocolV2,
-									RuntimeVersion:  proto.String("0.1.0"),
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"XGBoostV1WithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "xgboostWithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							XGBoost: &XGBoostSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI:     proto.String("gs://someUri"),
-									RuntimeVersion: proto.String("0.1.0"),
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"XGBoostV2WithURI": {
-				isvc: InferenceService{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "xgboost2WithURI",
-					},
-					Spec: InferenceServiceSpec{
-						Predictor: PredictorSpec{
-							XGBoost: &XGBoostSpec{
-								PredictorExtensionSpec: PredictorExtensionSpec{
-									StorageURI:      proto.String("gs://someUri"),
-									ProtocolVersion: &protocolV2,
-									RuntimeVersion:  proto.String("0.1.0"),
-									Container: corev1.Container{
-										Name:      constants.InferenceServiceContainerName,
-										Resources: reqResourcesScenario.resource,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-
-		for name, scenario := range scenarios {
-			t.Run(name, func(t *testing.T) {
-				scenario.isvc.Spec.Predictor.GetImplementation().Default(nil)
-				res := IsMemoryResourceAvailable(&scenario.isvc, totalReqMemory)
-				if !g.Expect(res).To(gomega.Equal(reqResourcesScenario.expected)) {
-					t.Errorf("got %t, want %t with memory %s vs %s", res, reqResourcesScenario.expected, reqResourcesScenario.resource.Limits.Memory().String(), totalReqMemory.String())
-				}
-			})
-		}
-	}
-}
-
 func TestMergeRuntimeContainers(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
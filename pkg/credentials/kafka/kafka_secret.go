@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The secret keys are exposed to the logger as environment variables of the same name.
+const (
+	// KafkaSecurityProtocol is one of PLAINTEXT, SSL, SASL_PLAINTEXT or SASL_SSL
+	KafkaSecurityProtocol = "KAFKA_SECURITY_PROTOCOL"
+	// KafkaSaslMechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512
+	KafkaSaslMechanism = "KAFKA_SASL_MECHANISM"
+	KafkaSaslUsername  = "KAFKA_SASL_USERNAME"
+	KafkaSaslPassword  = "KAFKA_SASL_PASSWORD"
+	// KafkaCACert, KafkaClientCert and KafkaClientKey are PEM encoded
+	KafkaCACert     = "KAFKA_CA_CERT"
+	KafkaClientCert = "KAFKA_CLIENT_CERT"
+	KafkaClientKey  = "KAFKA_CLIENT_KEY"
+)
+
+var secretKeys = []string{
+	KafkaSecurityProtocol,
+	KafkaSaslMechanism,
+	KafkaSaslUsername,
+	KafkaSaslPassword,
+	KafkaCACert,
+	KafkaClientCert,
+	KafkaClientKey,
+}
+
+func BuildSecretEnvs(secret *corev1.Secret) []corev1.EnvVar {
+	envs := make([]corev1.EnvVar, 0, len(secretKeys))
+	for _, key := range secretKeys {
+		if _, ok := secret.Data[key]; !ok {
+			continue
+		}
+		envs = append(envs, corev1.EnvVar{
+			Name: key,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: key,
+				},
+			},
+		})
+	}
+	return envs
+}
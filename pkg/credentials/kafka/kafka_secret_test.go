@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func secretEnv(secretName string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: key,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
+func TestBuildSecretEnvs_SaslSsl(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kafka-secret",
+		},
+		Data: map[string][]byte{
+			KafkaSecurityProtocol: []byte("SASL_SSL"),
+			KafkaSaslMechanism:    []byte("SCRAM-SHA-512"),
+			KafkaSaslUsername:     []byte("logger"),
+			KafkaSaslPassword:     []byte("password"),
+			KafkaCACert:           []byte("-----BEGIN CERTIFICATE-----"),
+			"unrelated":           []byte("value"),
+		},
+	}
+
+	envs := BuildSecretEnvs(secret)
+
+	assert.Equal(t, []corev1.EnvVar{
+		secretEnv("kafka-secret", KafkaSecurityProtocol),
+		secretEnv("kafka-secret", KafkaSaslMechanism),
+		secretEnv("kafka-secret", KafkaSaslUsername),
+		secretEnv("kafka-secret", KafkaSaslPassword),
+		secretEnv("kafka-secret", KafkaCACert),
+	}, envs)
+}
+
+func TestBuildSecretEnvs_Empty(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "empty-secret",
+		},
+		Data: map[string][]byte{},
+	}
+
+	envs := BuildSecretEnvs(secret)
+
+	assert.Empty(t, envs)
+}
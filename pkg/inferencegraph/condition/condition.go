@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package condition implements the conditions of the InferenceGraph steps.
+//
+// A condition is a gjson path (https://github.com/tidwall/gjson/blob/master/SYNTAX.md) which
+// matches when it selects a value, unless it starts with the cel: prefix, in which case the rest
+// of it is a CEL expression (https://github.com/google/cel-spec):
+//
+//	cel:request.body.score > 0.5 && request.headers["x-tier"] == "premium"
+//
+// request.body is the JSON request received by the node, and request.headers are its headers,
+// with lower case names and the values of a repeated header joined with commas. In Sequence
+// nodes, response is the JSON response of the previous step, the request for the first step.
+// The gjson paths select from the request in Switch nodes and from the response in Sequence nodes.
+package condition
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/tidwall/gjson"
+)
+
+// Scope is the kind of node a condition belongs to, which decides the variables it can refer to.
+type Scope string
+
+const (
+	// SwitchScope is the scope of the conditions of Switch nodes, which select the step to route the request to.
+	SwitchScope Scope = "Switch"
+	// SequenceScope is the scope of the conditions of Sequence nodes, which decide whether the sequence goes on with the step.
+	SequenceScope Scope = "Sequence"
+)
+
+// ExpressionPrefix is the prefix of the conditions which are CEL expressions rather than gjson paths.
+const ExpressionPrefix = "cel:"
+
+const (
+	responseVariable = "response"
+	bodyVariable     = "request.body"
+	headersVariable  = "request.headers"
+	// costLimit bounds the evaluation cost of an expression, so that a condition cannot hold up the router.
+	costLimit = 1000000
+)
+
+var environments = sync.OnceValues(func() (map[Scope]*cel.Env, error) {
+	options := []cel.EnvOption{
+		cel.Variable(bodyVariable, cel.DynType),
+		cel.Variable(headersVariable, cel.MapType(cel.StringType, cel.StringType)),
+		cel.CrossTypeNumericComparisons(true),
+	}
+	switchEnv, err := cel.NewEnv(options...)
+	if err != nil {
+		return nil, err
+	}
+	sequenceEnv, err := switchEnv.Extend(cel.Variable(responseVariable, cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	return map[Scope]*cel.Env{SwitchScope: switchEnv, SequenceScope: sequenceEnv}, nil
+})
+
+// Input holds the request and response a condition is evaluated against.
+type Input struct {
+	Body     []byte
+	Headers  http.Header
+	Response []byte
+
+	variables map[string]any
+}
+
+func (in *Input) activation() map[string]any {
+	if in.variables == nil {
+		headers := make(map[string]string, len(in.Headers))
+		for name, values := range in.Headers {
+			headers[strings.ToLower(name)] = strings.Join(values, ",")
+		}
+		in.variables = map[string]any{
+			bodyVariable:     decodeJSON(in.Body),
+			headersVariable:  headers,
+			responseVariable: decodeJSON(in.Response),
+		}
+	}
+	return in.variables
+}
+
+// decodeJSON returns the decoded document, or nil when it is not valid JSON.
+func decodeJSON(document []byte) any {
+	var decoded any
+	if err := json.Unmarshal(document, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
+
+// Condition is a compiled step condition.
+type Condition struct {
+	scope   Scope
+	path    string
+	program cel.Program
+}
+
+// Compile compiles and type checks the condition of a step of a node of the given scope.
+func Compile(text string, scope Scope) (*Condition, error) {
+	expression, ok := strings.CutPrefix(text, ExpressionPrefix)
+	if !ok {
+		return &Condition{scope: scope, path: text}, nil
+	}
+	envs, err := environments()
+	if err != nil {
+		return nil, err
+	}
+	env, ok := envs[scope]
+	if !ok {
+		return nil, fmt.Errorf("conditions are not supported in %s nodes", scope)
+	}
+	checked, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if kind := checked.OutputType().Kind(); kind != types.BoolKind && kind != types.DynKind {
+		return nil, fmt.Errorf("the condition evaluates to %s instead of bool", checked.OutputType())
+	}
+	program, err := env.Program(checked, cel.CostLimit(costLimit))
+	if err != nil {
+		return nil, err
+	}
+	return &Condition{scope: scope, program: program}, nil
+}
+
+// IsExpression returns whether the condition is a CEL expression rather than a gjson path.
+func (c *Condition) IsExpression() bool {
+	return c.program != nil
+}
+
+// Match returns whether the condition holds for the input. The gjson paths fail on documents
+// which are not valid JSON, and the expressions fail when they cannot be evaluated, for example
+// when they select a field which does not exist without checking it with has() first.
+func (c *Condition) Match(in *Input) (bool, error) {
+	if c.program == nil {
+		document := in.Body
+		if c.scope == SequenceScope {
+			document = in.Response
+		}
+		if !gjson.ValidBytes(document) {
+			return false, errors.New("invalid JSON document")
+		}
+		return gjson.GetBytes(document, c.path).Exists(), nil
+	}
+	result, _, err := c.program.Eval(in.activation())
+	if err != nil {
+		return false, err
+	}
+	matched, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("the condition evaluated to %v instead of a bool", result)
+	}
+	return matched, nil
+}
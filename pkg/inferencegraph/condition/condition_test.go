@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package condition
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestCompile(t *testing.T) {
+	scenarios := map[string]struct {
+		condition  string
+		scope      Scope
+		expression bool
+		err        string
+	}{
+		"gjson path": {
+			condition: "instances",
+			scope:     SwitchScope,
+		},
+		"gjson query": {
+			condition: `predictions.#(@>0.5)`,
+			scope:     SequenceScope,
+		},
+		"gjson path of a request field": {
+			condition: "response.ok",
+			scope:     SequenceScope,
+		},
+		"request expression": {
+			condition:  `cel:request.body.score > 0.5 && request.headers["x-tier"] == "premium"`,
+			scope:      SwitchScope,
+			expression: true,
+		},
+		"response expression": {
+			condition:  `cel:has(response.predictions) && size(response.predictions) > 0`,
+			scope:      SequenceScope,
+			expression: true,
+		},
+		"response in a switch node": {
+			condition: `cel:response.predictions[0] > 0.5`,
+			scope:     SwitchScope,
+			err:       "undeclared reference to 'response'",
+		},
+		"type error": {
+			condition: `cel:request.headers["x-tier"] > 1`,
+			scope:     SwitchScope,
+			err:       "no matching overload",
+		},
+		"not a bool": {
+			condition: `cel:request.headers["x-tier"]`,
+			scope:     SwitchScope,
+			err:       "evaluates to string instead of bool",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			c, err := Compile(scenario.condition, scenario.scope)
+			if scenario.err != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(scenario.err)))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(c.IsExpression()).To(gomega.Equal(scenario.expression))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	input := &Input{
+		Body:     []byte(`{"score": 0.75, "count": 3, "instances": [1]}`),
+		Headers:  http.Header{"X-Tier": {"premium"}, "X-Region": {"eu", "us"}},
+		Response: []byte(`{"predictions": [0.25]}`),
+	}
+	scenarios := map[string]struct {
+		condition string
+		scope     Scope
+		matched   bool
+		err       bool
+	}{
+		"request path":            {condition: "instances", scope: SwitchScope, matched: true},
+		"missing request path":    {condition: "predictions", scope: SwitchScope, matched: false},
+		"response path":           {condition: "predictions", scope: SequenceScope, matched: true},
+		"path like an expression": {condition: "request.body", scope: SwitchScope, matched: false},
+		"threshold":               {condition: `cel:request.body.score > 0.5`, scope: SwitchScope, matched: true},
+		"integer comparison":      {condition: `cel:request.body.count == 3 && request.body.count < 4`, scope: SwitchScope, matched: true},
+		"header":                  {condition: `cel:request.headers["x-tier"] == "premium"`, scope: SwitchScope, matched: true},
+		"repeated header":         {condition: `cel:request.headers["x-region"] == "eu,us"`, scope: SwitchScope, matched: true},
+		"response threshold":      {condition: `cel:response.predictions[0] > 0.5`, scope: SequenceScope, matched: false},
+		"checked missing field":   {condition: `cel:has(request.body.label) && request.body.label == "cat"`, scope: SwitchScope, matched: false},
+		"unchecked missing field": {condition: `cel:request.body.label == "cat"`, scope: SwitchScope, err: true},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			c, err := Compile(scenario.condition, scenario.scope)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			matched, err := c.Match(input)
+			if scenario.err {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(matched).To(gomega.Equal(scenario.matched))
+		})
+	}
+
+	c, err := Compile("predictions", SequenceScope)
+	gomega.NewGomegaWithT(t).Expect(err).ToNot(gomega.HaveOccurred())
+	_, err = c.Match(&Input{Response: []byte("not json")})
+	gomega.NewGomegaWithT(t).Expect(err).To(gomega.HaveOccurred())
+}
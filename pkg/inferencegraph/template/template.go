@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package template implements the input and output templates of the InferenceGraph steps.
+//
+// A template is a JSON document in which every {{ expression }} placeholder is replaced with
+// the JSON value it selects, so that placeholders stand for whole values and are not quoted:
+//
+//	{"instances": {{ $request.instances }}, "scores": {{ $steps.classifier.predictions.0 }}}
+//
+// An expression starts with $request, $response or $steps.<step name>, optionally followed
+// by a gjson path (https://github.com/tidwall/gjson/blob/master/SYNTAX.md) into that document.
+// A path which selects nothing is replaced with null.
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+type Source string
+
+const (
+	// RequestSource is the request received by the node of the step.
+	RequestSource Source = "$request"
+	// ResponseSource is the response of the previous step, or the step response in output templates.
+	ResponseSource Source = "$response"
+	// StepsSource is the output of an earlier named step of a Sequence node.
+	StepsSource Source = "$steps"
+)
+
+const (
+	openDelim  = "{{"
+	closeDelim = "}}"
+)
+
+// Expression is a placeholder of a template.
+type Expression struct {
+	Source Source
+	// StepName is the referenced step of a $steps expression
+	StepName string
+	// Path is the gjson path selecting a value of the source document, the whole document when empty
+	Path string
+}
+
+type segment struct {
+	text       string
+	expression *Expression
+}
+
+// Template is a parsed step template.
+type Template struct {
+	segments []segment
+}
+
+// Context holds the documents the expressions of a template select from.
+type Context struct {
+	Request  []byte
+	Response []byte
+	// Steps are the outputs of the earlier named steps
+	Steps map[string][]byte
+}
+
+// Parse parses a template, and checks that it yields a JSON document.
+func Parse(text string) (*Template, error) {
+	t := &Template{}
+	rest := text
+	for {
+		start := strings.Index(rest, openDelim)
+		if start < 0 {
+			t.segments = append(t.segments, segment{text: rest})
+			break
+		}
+		end := strings.Index(rest[start:], closeDelim)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated %q in template", openDelim)
+		}
+		end += start
+		expression, err := parseExpression(strings.TrimSpace(rest[start+len(openDelim) : end]))
+		if err != nil {
+			return nil, err
+		}
+		t.segments = append(t.segments, segment{text: rest[:start]}, segment{expression: expression})
+		rest = rest[end+len(closeDelim):]
+	}
+
+	// every placeholder is a JSON value, so that the template is a JSON document once they are
+	// replaced. The new lines around the value are not valid within a string, which catches the
+	// quoted placeholders.
+	var buf bytes.Buffer
+	for _, s := range t.segments {
+		if s.expression != nil {
+			buf.WriteString("\nnull\n")
+		} else {
+			buf.WriteString(s.text)
+		}
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("template is not a JSON document")
+	}
+	return t, nil
+}
+
+func parseExpression(text string) (*Expression, error) {
+	for _, source := range []Source{RequestSource, ResponseSource, StepsSource} {
+		if text != string(source) && !strings.HasPrefix(text, string(source)+".") {
+			continue
+		}
+		expression := &Expression{Source: source, Path: strings.TrimPrefix(strings.TrimPrefix(text, string(source)), ".")}
+		if source == StepsSource {
+			stepName, path, _ := strings.Cut(expression.Path, ".")
+			if stepName == "" {
+				return nil, fmt.Errorf("no step name in expression %q", text)
+			}
+			expression.StepName = stepName
+			expression.Path = path
+		}
+		return expression, nil
+	}
+	return nil, fmt.Errorf("expression %q must start with %s, %s or %s.<step name>", text, RequestSource, ResponseSource, StepsSource)
+}
+
+// Expressions returns the placeholders of the template.
+func (t *Template) Expressions() []Expression {
+	var expressions []Expression
+	for _, s := range t.segments {
+		if s.expression != nil {
+			expressions = append(expressions, *s.expression)
+		}
+	}
+	return expressions
+}
+
+// Render replaces the placeholders of the template with the values they select from the context.
+func (t *Template) Render(ctx Context) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, s := range t.segments {
+		if s.expression == nil {
+			buf.WriteString(s.text)
+			continue
+		}
+		value, err := ctx.value(s.expression)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("rendered template is not a JSON document")
+	}
+	return buf.Bytes(), nil
+}
+
+func (ctx Context) value(expression *Expression) ([]byte, error) {
+	var document []byte
+	name := string(expression.Source)
+	switch expression.Source {
+	case RequestSource:
+		document = ctx.Request
+	case ResponseSource:
+		document = ctx.Response
+	case StepsSource:
+		output, ok := ctx.Steps[expression.StepName]
+		if !ok {
+			return []byte("null"), nil
+		}
+		document = output
+		name = string(StepsSource) + "." + expression.StepName
+	}
+	if !gjson.ValidBytes(document) {
+		return nil, fmt.Errorf("%s is not a JSON document", name)
+	}
+	if expression.Path == "" {
+		return bytes.TrimSpace(document), nil
+	}
+	result := gjson.GetBytes(document, expression.Path)
+	if !result.Exists() {
+		return []byte("null"), nil
+	}
+	return []byte(result.Raw), nil
+}
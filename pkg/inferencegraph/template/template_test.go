@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := map[string]struct {
+		template    string
+		expressions []Expression
+		err         string
+	}{
+		"plain document": {
+			template: `{"instances": [1, 2], "parameters": {"nested": {"value": 1}}}`,
+		},
+		"placeholders": {
+			template: `{"instances": {{ $request.instances }}, "previous": {{$response}}, "scores": {{ $steps.classifier.predictions.0 }}}`,
+			expressions: []Expression{
+				{Source: RequestSource, Path: "instances"},
+				{Source: ResponseSource},
+				{Source: StepsSource, StepName: "classifier", Path: "predictions.0"},
+			},
+		},
+		"unterminated placeholder": {
+			template: `{"instances": {{ $request.instances }`,
+			err:      "unterminated",
+		},
+		"unknown source": {
+			template: `{"instances": {{ $input.instances }}}`,
+			err:      "must start with",
+		},
+		"step without name": {
+			template: `{"instances": {{ $steps }}}`,
+			err:      "no step name",
+		},
+		"quoted placeholder": {
+			template: `{"instances": "{{ $request.instances }}"}`,
+			err:      "not a JSON document",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			tmpl, err := Parse(scenario.template)
+			if scenario.err != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(scenario.err)))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(tmpl.Expressions()).To(gomega.Equal(scenario.expressions))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tmpl, err := Parse(`{"instances": {{ $request.instances }}, "label": {{ $response.predictions.0 }}, "features": {{ $steps.preprocess }}, "missing": {{ $steps.skipped.value }}}`)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	output, err := tmpl.Render(Context{
+		Request:  []byte(`{"instances": [[1, 2]]}`),
+		Response: []byte(`{"predictions": ["cat", "dog"]}`),
+		Steps: map[string][]byte{
+			"preprocess": []byte(` {"values": [0.5]} `),
+		},
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(output).To(gomega.MatchJSON(`{"instances": [[1, 2]], "label": "cat", "features": {"values": [0.5]}, "missing": null}`))
+
+	_, err = tmpl.Render(Context{Request: []byte(`not json`), Response: []byte(`{}`)})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("$request is not a JSON document")))
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultRetryInitialBackoff        = 100 * time.Millisecond
+	DefaultRetryMaxBackoff            = 10 * time.Second
+	DefaultCircuitBreakerResetTimeout = 30 * time.Second
+	DefaultMaxRetriesInFlight         = 1000
+)
+
+// ErrCircuitOpen is returned for the deliveries to a destination whose circuit is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// StatusError is returned when the logger URL answers a CloudEvent with a non 2xx status.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("event rejected with status code %d: %s", e.StatusCode, e.Message)
+}
+
+// isRetriable returns whether a failed delivery may succeed when retried. The events rejected
+// by the destination, other than for throttling or server errors, are not retried.
+func isRetriable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusRequestTimeout ||
+			statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// RetryPolicy is the retry policy of the failed CloudEvent deliveries.
+type RetryPolicy struct {
+	// MaxAttempts is the number of delivery attempts of an event, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled with every retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts.
+	MaxBackoff time.Duration
+}
+
+// Backoff returns the wait before the given retry, counted from 1. The wait is drawn between
+// half and the whole of the exponential backoff, so that the retries of the events which failed
+// together are spread out.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.MaxBackoff)
+	half := backoff / 2
+	return half + rand.N(backoff-half+1) // #nosec G404 -- jitter does not need a secure source
+}
+
+// CircuitBreaker fails the deliveries to a destination right away for a while, once a number of
+// consecutive deliveries to it have failed, so that an unavailable destination does not pile up
+// retries. After the reset timeout, a single trial delivery is let through: its success closes
+// the circuit, and its failure opens it again.
+type CircuitBreaker struct {
+	threshold    int
+	resetTimeout time.Duration
+	now          func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func NewCircuitBreaker(threshold int, resetTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:    threshold,
+		resetTimeout: resetTimeout,
+		now:          time.Now,
+		circuits:     map[string]*circuit{},
+	}
+}
+
+// Allow returns whether a delivery to the destination may be attempted.
+func (b *CircuitBreaker) Allow(destination string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[destination]
+	if !ok || c.failures < b.threshold {
+		return true
+	}
+	if c.trial || b.now().Before(c.openUntil) {
+		return false
+	}
+	c.trial = true
+	return true
+}
+
+// Record records the outcome of a delivery to the destination, and returns whether it opened
+// the circuit.
+func (b *CircuitBreaker) Record(destination string, success bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if success {
+		delete(b.circuits, destination)
+		return false
+	}
+	c, ok := b.circuits[destination]
+	if !ok {
+		c = &circuit{}
+		b.circuits[destination] = c
+	}
+	c.trial = false
+	c.failures++
+	if c.failures < b.threshold {
+		return false
+	}
+	c.openUntil = b.now().Add(b.resetTimeout)
+	return true
+}
+
+// Delivery is the delivery policy of the CloudEvents sent by the workers. The failed deliveries
+// are retried, and the events which still could not be delivered are sent to the dead letter URL.
+type Delivery struct {
+	Retry RetryPolicy
+	// CircuitBreaker tracks the destinations by host, when set
+	CircuitBreaker *CircuitBreaker
+	// DeadLetterUrl can be any logger URL. The events are sent as CloudEvents to an HTTP URL,
+	// and stored with DeadLetterStore otherwise.
+	DeadLetterUrl   *url.URL
+	DeadLetterStore Store
+	// MaxRetriesInFlight limits the events waiting for a retry, DefaultMaxRetriesInFlight by default. The
+	// events which fail beyond it are not retried, so that the retries of an unavailable destination do not
+	// pile up in memory.
+	MaxRetriesInFlight int
+
+	retriesInFlight atomic.Int64
+}
+
+func (d *Delivery) maxAttempts() int {
+	if d == nil {
+		return 1
+	}
+	return max(d.Retry.MaxAttempts, 1)
+}
+
+// acquireRetry reserves a retry in flight, and returns false when the limit is reached.
+func (d *Delivery) acquireRetry() bool {
+	limit := int64(d.MaxRetriesInFlight)
+	if limit <= 0 {
+		limit = DefaultMaxRetriesInFlight
+	}
+	if d.retriesInFlight.Add(1) > limit {
+		d.retriesInFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+// releaseRetry releases a retry in flight once it is attempted.
+func (d *Delivery) releaseRetry() {
+	d.retriesInFlight.Add(-1)
+}
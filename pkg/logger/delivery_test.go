@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func deliveryRequest(t *testing.T, logUrl string) LogRequest {
+	request := spoolRequest(t, logUrl, "0123")
+	request.SourceUri = &url.URL{Scheme: "http", Host: "localhost:9081"}
+	return request
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for range 100 {
+		g.Expect(policy.Backoff(1)).To(gomega.BeNumerically("~", 75*time.Millisecond, 25*time.Millisecond))
+		g.Expect(policy.Backoff(3)).To(gomega.BeNumerically("~", 300*time.Millisecond, 100*time.Millisecond))
+		g.Expect(policy.Backoff(8)).To(gomega.BeNumerically("~", 750*time.Millisecond, 250*time.Millisecond))
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	g.Expect(breaker.Record("logger", false)).To(gomega.BeFalse())
+	g.Expect(breaker.Allow("logger")).To(gomega.BeTrue())
+	g.Expect(breaker.Record("logger", false)).To(gomega.BeTrue())
+	g.Expect(breaker.Allow("logger")).To(gomega.BeFalse())
+	// the circuits are per destination
+	g.Expect(breaker.Allow("other")).To(gomega.BeTrue())
+
+	// a single trial delivery once the reset timeout has passed
+	now = now.Add(time.Minute)
+	g.Expect(breaker.Allow("logger")).To(gomega.BeTrue())
+	g.Expect(breaker.Allow("logger")).To(gomega.BeFalse())
+	g.Expect(breaker.Record("logger", false)).To(gomega.BeTrue())
+	g.Expect(breaker.Allow("logger")).To(gomega.BeFalse())
+
+	now = now.Add(time.Minute)
+	g.Expect(breaker.Allow("logger")).To(gomega.BeTrue())
+	g.Expect(breaker.Record("logger", true)).To(gomega.BeFalse())
+	g.Expect(breaker.Allow("logger")).To(gomega.BeTrue())
+}
+
+func TestDeliveryRetriesFailedEvents(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var calls atomic.Int32
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer logSvc.Close()
+
+	delivered := testutil.ToFloat64(cloudEventsDelivered)
+	retried := testutil.ToFloat64(cloudEventsRetried)
+	delivery := &Delivery{
+		Retry: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+	}
+	StartDispatcher(1, &MockStore{}, &ImmediateBatch{}, nil, delivery, logger)
+	g.Expect(QueueLogRequest(deliveryRequest(t, logSvc.URL))).To(gomega.Succeed())
+
+	g.Eventually(func() float64 { return testutil.ToFloat64(cloudEventsDelivered) - delivered }).Should(gomega.Equal(1.0))
+	g.Expect(calls.Load()).To(gomega.Equal(int32(3)))
+	g.Expect(testutil.ToFloat64(cloudEventsRetried) - retried).To(gomega.Equal(2.0))
+}
+
+func TestDeliveryDeadLettersFailedEvents(t *testing.T) {
+	scenarios := map[string]struct {
+		status        int
+		expectedCalls int32
+	}{
+		"retried until the last attempt": {
+			status:        http.StatusInternalServerError,
+			expectedCalls: 2,
+		},
+		"rejected events are not retried": {
+			status:        http.StatusBadRequest,
+			expectedCalls: 1,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			logger, _ := pkglogging.NewLogger("", "INFO")
+
+			var calls atomic.Int32
+			logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				rw.WriteHeader(scenario.status)
+			}))
+			defer logSvc.Close()
+
+			deadLetterUrl, _ := url.Parse("s3://bucket/dead-letter")
+			store := NewMockStore(nil)
+			deadLettered := testutil.ToFloat64(cloudEventsDeadLettered)
+			delivery := &Delivery{
+				Retry:           RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+				DeadLetterUrl:   deadLetterUrl,
+				DeadLetterStore: store,
+			}
+			StartDispatcher(1, &MockStore{}, &ImmediateBatch{}, nil, delivery, logger)
+			g.Expect(QueueLogRequest(deliveryRequest(t, logSvc.URL))).To(gomega.Succeed())
+
+			select {
+			case request := <-store.ResponseChan:
+				g.Expect(request.Url).To(gomega.Equal(deadLetterUrl))
+				g.Expect(request.Id).To(gomega.Equal("0123"))
+			case <-time.After(5 * time.Second):
+				t.Fatal("the event was not dead lettered")
+			}
+			g.Eventually(func() float64 { return testutil.ToFloat64(cloudEventsDeadLettered) - deadLettered }).Should(gomega.Equal(1.0))
+			g.Expect(calls.Load()).To(gomega.Equal(scenario.expectedCalls))
+		})
+	}
+}
+
+func TestDeliveryLimitsRetriesInFlight(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var calls atomic.Int32
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer logSvc.Close()
+
+	deadLetterUrl, _ := url.Parse("s3://bucket/dead-letter")
+	store := NewMockStore(nil)
+	retried := testutil.ToFloat64(cloudEventsRetried)
+	// the retry of the first event waits long enough to still be in flight when the second event fails
+	delivery := &Delivery{
+		Retry:              RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour},
+		DeadLetterUrl:      deadLetterUrl,
+		DeadLetterStore:    store,
+		MaxRetriesInFlight: 1,
+	}
+	StartDispatcher(1, &MockStore{}, &ImmediateBatch{}, nil, delivery, logger)
+	g.Expect(QueueLogRequest(deliveryRequest(t, logSvc.URL))).To(gomega.Succeed())
+	g.Eventually(func() float64 { return testutil.ToFloat64(cloudEventsRetried) - retried }).Should(gomega.Equal(1.0))
+
+	second := deliveryRequest(t, logSvc.URL)
+	second.Id = "4567"
+	g.Expect(QueueLogRequest(second)).To(gomega.Succeed())
+	select {
+	case request := <-store.ResponseChan:
+		g.Expect(request.Id).To(gomega.Equal("4567"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the event was not dead lettered")
+	}
+	g.Expect(calls.Load()).To(gomega.Equal(int32(2)))
+	g.Expect(testutil.ToFloat64(cloudEventsRetried) - retried).To(gomega.Equal(1.0))
+}
+
+func TestDeliveryCircuitBreaker(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var calls atomic.Int32
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer logSvc.Close()
+
+	deadLetter := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer deadLetter.Close()
+	deadLetterUrl, _ := url.Parse(deadLetter.URL)
+	deadLettered := testutil.ToFloat64(cloudEventsDeadLettered)
+
+	// the circuit opens on the first failure, so that the retries are not sent
+	delivery := &Delivery{
+		Retry:          RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		CircuitBreaker: NewCircuitBreaker(1, time.Hour),
+		DeadLetterUrl:  deadLetterUrl,
+	}
+	StartDispatcher(1, &MockStore{}, &ImmediateBatch{}, nil, delivery, logger)
+	g.Expect(QueueLogRequest(deliveryRequest(t, logSvc.URL))).To(gomega.Succeed())
+
+	g.Eventually(func() float64 { return testutil.ToFloat64(cloudEventsDeadLettered) - deadLettered }).Should(gomega.Equal(1.0))
+	g.Expect(calls.Load()).To(gomega.Equal(int32(1)))
+}
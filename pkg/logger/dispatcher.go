@@ -18,13 +18,18 @@ package logger
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/zap"
 )
 
 var WorkerQueue chan chan LogRequest
 
-func StartDispatcher(nworkers int, store Store, batchStrategy BatchStrategy, logger *zap.SugaredLogger) {
+// StartDispatcher starts the workers delivering the log requests as CloudEvents and the batch
+// pipeline storing them in the blob store. When a spool is given, the batches and the events
+// which fail to be delivered are persisted in it and retried. The delivery policy of the events
+// is given by delivery, a single attempt is made when it is nil.
+func StartDispatcher(nworkers int, store Store, batchStrategy BatchStrategy, spool *Spool, delivery *Delivery, logger *zap.SugaredLogger) {
 	// Reinitialize WorkQueue so that any previous dispatcher goroutines
 	// (from prior calls, e.g. in tests) lose their channel reference and
 	// cannot compete for work items.
@@ -37,6 +42,8 @@ func StartDispatcher(nworkers int, store Store, batchStrategy BatchStrategy, log
 	for i := range nworkers {
 		logger.Info("Starting worker ", i+1)
 		worker := NewWorker(i+1, WorkerQueue, logger)
+		worker.Spool = spool
+		worker.Delivery = delivery
 		worker.Start()
 	}
 
@@ -57,10 +64,33 @@ func StartDispatcher(nworkers int, store Store, batchStrategy BatchStrategy, log
 			}
 			if err := store.Store(batch[0].Url, batch); err != nil {
 				logger.Errorf("Failed to store batch: %v", err)
+				spoolBatch(spool, batch, logger)
 			}
 		}
 	}()
 
+	if spool != nil {
+		sender := NewWorker(0, nil, logger)
+		go spool.Run(context.Background(), func(batch []LogRequest) error {
+			if GetStorageStrategy(batch[0].Url.String()) == HttpStorage {
+				for _, req := range batch {
+					if err := sender.sendHttpCloudEvent(req); err != nil {
+						if !isRetriable(err) {
+							logger.Errorf("Dropping the spooled cloud event %s rejected by %s: %v", req.Id, req.Url, err)
+							continue
+						}
+						return err
+					}
+				}
+				return nil
+			}
+			if store == nil {
+				return errors.New("logger store not configured")
+			}
+			return store.Store(batch[0].Url, batch)
+		})
+	}
+
 	// Dispatcher goroutine: read from WorkQueue, split HTTP vs blob.
 	go func() {
 		for work := range WorkQueue {
@@ -80,3 +110,13 @@ func StartDispatcher(nworkers int, store Store, batchStrategy BatchStrategy, log
 		}
 	}()
 }
+
+// spoolBatch persists a batch which failed to be delivered, when a spool is configured.
+func spoolBatch(spool *Spool, batch []LogRequest, logger *zap.SugaredLogger) {
+	if spool == nil {
+		return
+	}
+	if err := spool.Put(batch); err != nil {
+		logger.Errorf("Failed to spool the log batch: %v", err)
+	}
+}
@@ -17,8 +17,9 @@ limitations under the License.
 package logger
 
 import (
-	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -34,6 +35,22 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 )
 
+// Error classes of the logged responses
+const (
+	// ErrorClassClient is a 4xx response which is not a timeout or throttling
+	ErrorClassClient = "client_error"
+	// ErrorClassServer is a 5xx response which is not a timeout or unavailability
+	ErrorClassServer = "server_error"
+	// ErrorClassTimeout is a request or gateway timeout, or a request whose deadline expired
+	ErrorClassTimeout = "timeout"
+	// ErrorClassThrottled is a 429 response
+	ErrorClassThrottled = "throttled"
+	// ErrorClassUnavailable is a 502 or 503 response
+	ErrorClassUnavailable = "unavailable"
+	// ErrorClassCanceled is a request cancelled by the client
+	ErrorClassCanceled = "canceled"
+)
+
 // loggingResponseWriter is a wrapper around an http.ResponseWriter that logs the response body
 // It implements the http.ResponseWriter and http.Flusher interfaces
 type loggingResponseWriter struct {
@@ -41,16 +58,39 @@ type loggingResponseWriter struct {
 	http.Flusher
 	statusCode     int
 	responseBuffer *bytes.Buffer // buffer to store the response body for logging
+	maxBodySize    int           // max number of bytes captured for logging, unlimited when 0
+	truncated      bool          // whether the captured response body was truncated
+	wroteHeader    bool
+	stream         *sseAssembler // reassembles server-sent events responses
 	log            logr.Logger
 }
 
+// detectStream switches to reassembling the response when it is a server-sent events stream.
+func (w *loggingResponseWriter) detectStream() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if isEventStream(w.ResponseWriter.Header().Get("Content-Type")) {
+		w.stream = newSSEAssembler(w.maxBodySize)
+	}
+}
+
 func (w *loggingResponseWriter) Write(b []byte) (int, error) {
-	n, err := w.responseBuffer.Write(b)
+	w.detectStream()
+	var err error
+	if w.stream != nil {
+		_, err = w.stream.Write(b)
+	} else {
+		var captured []byte
+		captured, w.truncated = capture(b, w.responseBuffer.Len(), w.maxBodySize, w.truncated)
+		_, err = w.responseBuffer.Write(captured)
+	}
 	if err != nil {
 		w.log.Error(err, "Failed to write response buffer")
-		return n, err
+		return 0, err
 	}
-	n, err = w.ResponseWriter.Write(b)
+	n, err := w.ResponseWriter.Write(b)
 	if err != nil {
 		w.log.Error(err, "Failed to write response")
 		return n, err
@@ -59,6 +99,7 @@ func (w *loggingResponseWriter) Write(b []byte) (int, error) {
 }
 
 func (w *loggingResponseWriter) WriteHeader(statusCode int) {
+	w.detectStream()
 	w.statusCode = statusCode
 	w.ResponseWriter.WriteHeader(statusCode)
 }
@@ -73,6 +114,24 @@ func (w *loggingResponseWriter) Flush() {
 	}
 }
 
+// body returns the captured response body and whether it was truncated. Server-sent
+// events responses are returned as the completion reassembled from the stream.
+func (w *loggingResponseWriter) body() ([]byte, bool) {
+	if w.stream != nil {
+		return w.stream.record()
+	}
+	return w.responseBuffer.Bytes(), w.truncated
+}
+
+// capture returns the part of b which fits in maxBodySize once size bytes have
+// already been captured, and whether the body is now truncated.
+func capture(b []byte, size int, maxBodySize int, truncated bool) ([]byte, bool) {
+	if maxBodySize <= 0 || size+len(b) <= maxBodySize {
+		return b, truncated
+	}
+	return b[:max(maxBodySize-size, 0)], true
+}
+
 type LoggerHandler struct {
 	log              logr.Logger
 	logUrl           *url.URL
@@ -87,27 +146,36 @@ type LoggerHandler struct {
 	annotations      map[string]string
 	certName         string
 	tlsSkipVerify    bool
+	maxBodySize      int
+	// redactor masks the sensitive fields of the logged payloads, nothing is redacted when nil
+	redactor *Redactor
+	// samplingPercentage is the percentage of the calls which are logged
+	samplingPercentage int
 }
 
 func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
 	inferenceService string, namespace string, endpoint string, component string, next http.Handler, metadataHeaders []string,
-	certName string, annotations map[string]string, tlsSkipVerify bool,
+	certName string, annotations map[string]string, tlsSkipVerify bool, maxBodySize int, redactor *Redactor,
+	samplingPercentage int,
 ) http.Handler {
 	logf.SetLogger(zap.New())
 	return &LoggerHandler{
-		log:              logf.Log.WithName("Logger"),
-		logUrl:           logUrl,
-		sourceUri:        sourceUri,
-		logMode:          logMode,
-		inferenceService: inferenceService,
-		namespace:        namespace,
-		component:        component,
-		endpoint:         endpoint,
-		next:             next,
-		annotations:      annotations,
-		metadataHeaders:  metadataHeaders,
-		certName:         certName,
-		tlsSkipVerify:    tlsSkipVerify,
+		log:                logf.Log.WithName("Logger"),
+		logUrl:             logUrl,
+		sourceUri:          sourceUri,
+		logMode:            logMode,
+		inferenceService:   inferenceService,
+		namespace:          namespace,
+		component:          component,
+		endpoint:           endpoint,
+		next:               next,
+		annotations:        annotations,
+		metadataHeaders:    metadataHeaders,
+		certName:           certName,
+		tlsSkipVerify:      tlsSkipVerify,
+		maxBodySize:        maxBodySize,
+		redactor:           redactor,
+		samplingPercentage: samplingPercentage,
 	}
 }
 
@@ -119,6 +187,12 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	// Get or Create an ID
+	id := getOrCreateID(r)
+	if !sampled(id, eh.samplingPercentage) {
+		eh.next.ServeHTTP(w, r)
+		return
+	}
 	// Record the time when the request arrives
 	requestTime := time.Now()
 	// Read request payload
@@ -139,71 +213,114 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Get or Create an ID
-	id := getOrCreateID(r)
+	metadata = eh.redactor.redactHeaders(metadata)
+
 	contentType := r.Header.Get("Content-Type")
+	requestBody, truncated := capture(eh.redactor.redactBody(body), 0, eh.maxBodySize, false)
+	requestLog := LogRequest{
+		Url:              eh.logUrl,
+		Bytes:            &requestBody,
+		ContentType:      contentType,
+		ReqType:          CEInferenceRequest,
+		Id:               id,
+		SourceUri:        eh.sourceUri,
+		InferenceService: eh.inferenceService,
+		Namespace:        eh.namespace,
+		Endpoint:         eh.endpoint,
+		Component:        eh.component,
+		Annotations:      eh.annotations,
+		Metadata:         metadata,
+		CertName:         eh.certName,
+		TlsSkipVerify:    eh.tlsSkipVerify,
+		OccurrenceTime:   requestTime,
+		Truncated:        truncated,
+	}
 	// log Request
 	if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogRequest {
+		if err := QueueLogRequest(requestLog); err != nil {
+			eh.log.Error(err, "Failed to log request")
+		}
+	}
+
+	// Proxy Request
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+	// TODO: Set a reasonable initial buffer size
+	var responseBuf bytes.Buffer
+	lrw := &loggingResponseWriter{ResponseWriter: w, responseBuffer: &responseBuf, maxBodySize: eh.maxBodySize, log: eh.log}
+	eh.next.ServeHTTP(lrw, r)
+	responseBody, truncated := lrw.body()
+	responseBody = eh.redactor.redactBody(responseBody)
+	responseContentType := contentType
+	if lrw.stream != nil {
+		// the stream is logged as the reassembled completion
+		responseContentType = "application/json"
+	}
+	// Record the time when the response is received
+	responseTime := time.Now()
+	statusCode := lrw.statusCode
+	if statusCode == 0 {
+		// the response status is implicitly 200 when the handler never called WriteHeader
+		statusCode = http.StatusOK
+	}
+	errorClass := classifyError(statusCode, r.Context().Err())
+	if errorClass != "" {
+		eh.log.Info("Failed to proxy request", "status code", statusCode, "error class", errorClass)
+	}
+	logResponse := eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogResponse
+	if eh.logMode == v1beta1.LogFailures && errorClass != "" {
+		// the request is only logged once the call is known to have failed
+		if err := QueueLogRequest(requestLog); err != nil {
+			eh.log.Error(err, "Failed to log request")
+		}
+		logResponse = true
+	}
+	// log Response
+	if logResponse {
 		if err := QueueLogRequest(LogRequest{
 			Url:              eh.logUrl,
-			Bytes:            &body,
-			ContentType:      contentType,
-			ReqType:          CEInferenceRequest,
+			Bytes:            &responseBody,
+			ContentType:      responseContentType,
+			ReqType:          CEInferenceResponse,
 			Id:               id,
 			SourceUri:        eh.sourceUri,
 			InferenceService: eh.inferenceService,
 			Namespace:        eh.namespace,
 			Endpoint:         eh.endpoint,
-			Component:        eh.component,
 			Annotations:      eh.annotations,
 			Metadata:         metadata,
+			Component:        eh.component,
 			CertName:         eh.certName,
 			TlsSkipVerify:    eh.tlsSkipVerify,
-			OccurrenceTime:   requestTime,
+			OccurrenceTime:   responseTime,
+			Truncated:        truncated,
+			StatusCode:       statusCode,
+			LatencyMs:        float64(responseTime.Sub(requestTime).Microseconds()) / 1000,
+			ErrorClass:       errorClass,
 		}); err != nil {
-			eh.log.Error(err, "Failed to log request")
+			eh.log.Error(err, "Failed to log response")
 		}
 	}
+}
 
-	// Proxy Request
-	r.Body = io.NopCloser(bytes.NewBuffer(body))
-	// TODO: Set a reasonable initial buffer size
-	var responseBuf bytes.Buffer
-	lrw := &loggingResponseWriter{ResponseWriter: w, responseBuffer: &responseBuf, log: eh.log}
-	eh.next.ServeHTTP(lrw, r)
-	// Read the response body from the buffer
-	reader := bufio.NewReader(lrw.responseBuffer)
-	responseBody, err := io.ReadAll(reader)
-	if err != nil {
-		eh.log.Error(err, "Failed to read response body")
-	}
-	// Record the time when the response is received
-	responseTime := time.Now()
-	// log Response
-	if lrw.statusCode == http.StatusOK {
-		if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogResponse {
-			if err := QueueLogRequest(LogRequest{
-				Url:              eh.logUrl,
-				Bytes:            &responseBody,
-				ContentType:      contentType,
-				ReqType:          CEInferenceResponse,
-				Id:               id,
-				SourceUri:        eh.sourceUri,
-				InferenceService: eh.inferenceService,
-				Namespace:        eh.namespace,
-				Endpoint:         eh.endpoint,
-				Annotations:      eh.annotations,
-				Metadata:         metadata,
-				Component:        eh.component,
-				CertName:         eh.certName,
-				TlsSkipVerify:    eh.tlsSkipVerify,
-				OccurrenceTime:   responseTime,
-			}); err != nil {
-				eh.log.Error(err, "Failed to log response")
-			}
-		}
-	} else {
-		eh.log.Info("Failed to proxy request", "status code", lrw.statusCode)
+// classifyError returns the error class of a call from the response status code and
+// the error of the request context, or an empty string when the call succeeded.
+func classifyError(statusCode int, ctxErr error) string {
+	switch {
+	case errors.Is(ctxErr, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(ctxErr, context.DeadlineExceeded),
+		statusCode == http.StatusRequestTimeout, statusCode == http.StatusGatewayTimeout:
+		return ErrorClassTimeout
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorClassThrottled
+	case statusCode == http.StatusBadGateway, statusCode == http.StatusServiceUnavailable:
+		return ErrorClassUnavailable
+	case statusCode >= http.StatusInternalServerError:
+		return ErrorClassServer
+	case statusCode >= http.StatusBadRequest:
+		return ErrorClassClient
+	default:
+		return ""
 	}
 }
 
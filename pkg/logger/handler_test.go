@@ -18,7 +18,9 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -76,10 +78,10 @@ func TestLogger(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 
-	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, logger)
+	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, nil, "", nil, true)
+		"default", httpProxy, nil, "", nil, true, 0, nil, 100)
 
 	oh.ServeHTTP(w, r)
 
@@ -148,10 +150,10 @@ func TestLoggerWithMetadata(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 
-	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, logger)
+	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, []string{"Foo", "Fizz"}, "", nil, true)
+		"default", httpProxy, []string{"Foo", "Fizz"}, "", nil, true, 0, nil, 100)
 
 	oh.ServeHTTP(w, r)
 
@@ -220,10 +222,10 @@ func TestLoggerWithAnnotation(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 
-	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, logger)
+	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, nil, "", map[string]string{"Foo": "Bar", "Fizz": "Buzz"}, true)
+		"default", httpProxy, nil, "", map[string]string{"Foo": "Bar", "Fizz": "Buzz"}, true, 0, nil, 100)
 
 	oh.ServeHTTP(w, r)
 
@@ -265,10 +267,10 @@ func TestBadResponse(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 
-	StartDispatcher(1, &MockStore{}, &ImmediateBatch{}, logger)
+	StartDispatcher(1, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, nil, "", nil, true)
+		"default", httpProxy, nil, "", nil, true, 0, nil, 100)
 
 	oh.ServeHTTP(w, r)
 	g.Expect(w.Code).To(gomega.Equal(400))
@@ -331,14 +333,14 @@ func TestLoggerWithS3Store(t *testing.T) {
 	}
 	store := NewMockStore(spec)
 
-	StartDispatcher(5, store, &ImmediateBatch{}, logger)
+	StartDispatcher(5, store, &ImmediateBatch{}, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 
 	logSvcUrl, err := url.Parse("s3://bucket")
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, []string{"Foo"}, "", map[string]string{"test-annotation": "test-value"}, true)
+		"default", httpProxy, []string{"Foo"}, "", map[string]string{"test-annotation": "test-value"}, true, 0, nil, 100)
 
 	oh.ServeHTTP(w, r)
 
@@ -426,10 +428,10 @@ func TestLoggerCloudEventTimestamps(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 
-	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, logger)
+	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, nil, "", nil, true)
+		"default", httpProxy, nil, "", nil, true, 0, nil, 100)
 
 	oh.ServeHTTP(w, r)
 
@@ -440,3 +442,282 @@ func TestLoggerCloudEventTimestamps(t *testing.T) {
 	g.Expect(resTimestamps.ceTime.After(reqTimestamps.ceTime) ||
 		resTimestamps.ceTime.Equal(reqTimestamps.ceTime)).To(gomega.BeTrue())
 }
+
+func TestLoggerEventStream(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	predictorRequest := []byte(`{"model":"llama","stream":true,"messages":[{"role":"user","content":"Hi"}]}`)
+
+	type logEvent struct {
+		ceType    string
+		truncated string
+		body      []byte
+	}
+	logChan := make(chan logEvent, 2)
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		logChan <- logEvent{ceType: req.Header.Get("Ce-Type"), truncated: req.Header.Get("Ce-Truncated"), body: b}
+		_, err = rw.Write([]byte(`ok`))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer logSvc.Close()
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.WriteHeader(http.StatusOK)
+		for _, frame := range []string{chatStream, "data: [DONE]\n\n"} {
+			_, err = rw.Write([]byte(frame))
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			rw.(http.Flusher).Flush()
+		}
+	}))
+	defer predictor.Close()
+
+	reader := bytes.NewReader(predictorRequest)
+	r := httptest.NewRequest(http.MethodPost, "http://a/openai/v1/chat/completions", reader)
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	pkgtest.SetupTestLogger()
+	logSvcUrl, err := url.Parse(logSvc.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
+	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
+		"default", httpProxy, nil, "", nil, true, 30, nil, 100)
+
+	oh.ServeHTTP(w, r)
+	// the client still receives the whole stream
+	g.Expect(w.Body.String()).To(gomega.Equal(chatStream + "data: [DONE]\n\n"))
+
+	events := map[string]logEvent{}
+	for range 2 {
+		event := <-logChan
+		events[event.ceType] = event
+	}
+	g.Expect(events[CEInferenceRequest].body).To(gomega.Equal(predictorRequest[:30]))
+	g.Expect(events[CEInferenceRequest].truncated).To(gomega.Equal("true"))
+	g.Expect(events[CEInferenceResponse].truncated).To(gomega.BeEmpty())
+	g.Expect(events[CEInferenceResponse].body).To(gomega.MatchJSON(`{"id":"cmpl-1","object":"chat.completion","created":1700000000,"model":"llama",` +
+		`"choices":[{"index":0,"message":{"role":"assistant","content":"Hello world"},"finish_reason":"stop"}],` +
+		`"usage":{"prompt_tokens":0,"completion_tokens":2,"total_tokens":2}}`))
+}
+
+func TestLoggerFailuresMode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	predictorRequest := []byte(`{"instances":[[0,0,0]]}`)
+
+	type logEvent struct {
+		ceType     string
+		id         string
+		statusCode string
+		errorClass string
+		latency    string
+	}
+	logChan := make(chan logEvent, 4)
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		logChan <- logEvent{
+			ceType:     req.Header.Get("Ce-Type"),
+			id:         req.Header.Get("Ce-Id"),
+			statusCode: req.Header.Get("Ce-Statuscode"),
+			errorClass: req.Header.Get("Ce-Errorclass"),
+			latency:    req.Header.Get("Ce-Latencyms"),
+		}
+		_, err = rw.Write([]byte(`ok`))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer logSvc.Close()
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		if req.URL.Path == "/fail" {
+			http.Error(rw, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, err = rw.Write([]byte(`{"predictions":[1]}`))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	pkgtest.SetupTestLogger()
+	logSvcUrl, err := url.Parse(logSvc.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
+	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogFailures, "mymodel", "default", "default",
+		"default", httpProxy, nil, "", nil, true, 0, nil, 100)
+
+	for _, path := range []string{"/ok", "/fail"} {
+		r := httptest.NewRequest(http.MethodPost, "http://a"+path, bytes.NewReader(predictorRequest))
+		r.Header.Set(CloudEventsIdHeader, path)
+		w := httptest.NewRecorder()
+		oh.ServeHTTP(w, r)
+	}
+
+	events := map[string]logEvent{}
+	for range 2 {
+		event := <-logChan
+		events[event.ceType] = event
+	}
+	g.Consistently(logChan, 200*time.Millisecond).ShouldNot(gomega.Receive())
+	g.Expect(events[CEInferenceRequest].id).To(gomega.Equal("/fail"))
+	g.Expect(events[CEInferenceRequest].statusCode).To(gomega.BeEmpty())
+	g.Expect(events[CEInferenceResponse].id).To(gomega.Equal("/fail"))
+	g.Expect(events[CEInferenceResponse].statusCode).To(gomega.Equal("503"))
+	g.Expect(events[CEInferenceResponse].errorClass).To(gomega.Equal(ErrorClassUnavailable))
+	g.Expect(events[CEInferenceResponse].latency).ToNot(gomega.BeEmpty())
+}
+
+func TestLoggerNonOKResponse(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type outcome struct {
+		statusCode string
+		errorClass string
+	}
+	responseChan := make(chan outcome, 1)
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		responseChan <- outcome{statusCode: req.Header.Get("Ce-Statuscode"), errorClass: req.Header.Get("Ce-Errorclass")}
+		_, err = rw.Write([]byte(`ok`))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer logSvc.Close()
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusCreated)
+		_, err := rw.Write([]byte(`{"created":true}`))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	pkgtest.SetupTestLogger()
+	logSvcUrl, err := url.Parse(logSvc.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	StartDispatcher(1, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
+	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogResponse, "mymodel", "default", "default",
+		"default", httpProxy, nil, "", nil, true, 0, nil, 100)
+
+	r := httptest.NewRequest(http.MethodPost, "http://a", bytes.NewReader([]byte(`{}`)))
+	w := httptest.NewRecorder()
+	oh.ServeHTTP(w, r)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+
+	g.Expect(<-responseChan).To(gomega.Equal(outcome{statusCode: "201"}))
+}
+
+func TestClassifyError(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		statusCode int
+		ctxErr     error
+		expected   string
+	}{
+		"OK":          {statusCode: http.StatusOK, expected: ""},
+		"NoContent":   {statusCode: http.StatusNoContent, expected: ""},
+		"BadRequest":  {statusCode: http.StatusBadRequest, expected: ErrorClassClient},
+		"NotFound":    {statusCode: http.StatusNotFound, expected: ErrorClassClient},
+		"Throttled":   {statusCode: http.StatusTooManyRequests, expected: ErrorClassThrottled},
+		"Timeout":     {statusCode: http.StatusGatewayTimeout, expected: ErrorClassTimeout},
+		"Deadline":    {statusCode: http.StatusBadGateway, ctxErr: context.DeadlineExceeded, expected: ErrorClassTimeout},
+		"Unavailable": {statusCode: http.StatusServiceUnavailable, expected: ErrorClassUnavailable},
+		"ServerError": {statusCode: http.StatusInternalServerError, expected: ErrorClassServer},
+		"Canceled":    {statusCode: http.StatusBadGateway, ctxErr: context.Canceled, expected: ErrorClassCanceled},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(classifyError(scenario.statusCode, scenario.ctxErr)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestLoggerRedactionAndSampling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	predictorRequest := []byte(`{"user":{"email":"a@b.c"},"instances":[[0,0,0]]}`)
+	predictorResponse := []byte(`{"predictions":[1],"email":"a@b.c"}`)
+
+	logChan := make(chan string, 4)
+	logSvc := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		logChan <- req.Header.Get("Ce-Id") + " " + req.Header.Get("Ce-Metadata") + " " + string(b)
+		_, err = rw.Write([]byte(`ok`))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer logSvc.Close()
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		// the predictor still receives the original payload
+		g.Expect(b).To(gomega.Equal(predictorRequest))
+		_, err = rw.Write(predictorResponse)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}))
+	defer predictor.Close()
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	pkgtest.SetupTestLogger()
+	logSvcUrl, err := url.Parse(logSvc.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	StartDispatcher(5, &MockStore{}, &ImmediateBatch{}, nil, nil, logger)
+	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
+	redactor, err := NewRedactor([]string{"user.email", "email"}, []string{"Authorization"}, v1beta1.RedactionMask)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
+		"default", httpProxy, []string{"Authorization"}, "", nil, true, 0, redactor, 50)
+
+	// find a sampled and a dropped request ID
+	ids := map[bool]string{}
+	for i := 0; len(ids) < 2; i++ {
+		id := fmt.Sprintf("request-%d", i)
+		ids[sampled(id, 50)] = id
+	}
+	for _, id := range ids {
+		r := httptest.NewRequest(http.MethodPost, "http://a", bytes.NewReader(predictorRequest))
+		r.Header.Set(CloudEventsIdHeader, id)
+		r.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		oh.ServeHTTP(w, r)
+		g.Expect(w.Body.Bytes()).To(gomega.Equal(predictorResponse))
+	}
+
+	logged := []string{<-logChan, <-logChan}
+	g.Consistently(logChan, 200*time.Millisecond).ShouldNot(gomega.Receive())
+	g.Expect(logged).To(gomega.ConsistOf(
+		ids[true]+` {"Authorization":["[REDACTED]"]} {"user":{"email":"[REDACTED]"},"instances":[[0,0,0]]}`,
+		ids[true]+` {"Authorization":["[REDACTED]"]} {"predictions":[1],"email":"[REDACTED]"}`,
+	))
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/credentials/kafka"
+)
+
+const (
+	KafkaAcksAll    = "all"
+	KafkaAcksLeader = "leader"
+	KafkaAcksNone   = "none"
+
+	kafkaClientId = "kserve-logger"
+	// the header names of the CloudEvents Kafka protocol binding in binary mode
+	kafkaHeaderPrefix      = "ce_"
+	kafkaContentTypeHeader = "content-type"
+)
+
+// KafkaProducer is the part of the Kafka client used by the KafkaStore.
+type KafkaProducer interface {
+	ProduceSync(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults
+}
+
+// KafkaStore publishes the log requests to a Kafka topic as CloudEvents in binary mode, keyed
+// by the request ID so that the request and the response of a call land in the same partition.
+type KafkaStore struct {
+	producer KafkaProducer
+	log      *zap.SugaredLogger
+}
+
+var _ Store = &KafkaStore{}
+
+func NewKafkaStore(producer KafkaProducer, log *zap.SugaredLogger) *KafkaStore {
+	return &KafkaStore{
+		producer: producer,
+		log:      log,
+	}
+}
+
+// NewKafkaStoreForURL creates a KafkaStore producing to the brokers of a kafka://broker1,broker2/topic
+// URL. The TLS and SASL settings are read from the environment set from the logger storage secret.
+func NewKafkaStoreForURL(logUrl *url.URL, acks string, log *zap.SugaredLogger) (*KafkaStore, error) {
+	if logUrl.Host == "" {
+		return nil, errors.New("no broker specified in url")
+	}
+	opts, err := kafkaClientOptions(acks)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, kgo.SeedBrokers(strings.Split(logUrl.Host, ",")...))
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	return NewKafkaStore(client, log), nil
+}
+
+// Store publishes every log request of the batch as its own CloudEvent, in a single produce call.
+func (s *KafkaStore) Store(logUrl *url.URL, batch []LogRequest) error {
+	if logUrl == nil {
+		return errors.New("log url is invalid")
+	}
+	if len(batch) == 0 {
+		return errors.New("empty batch")
+	}
+	topic := strings.TrimPrefix(logUrl.Path, "/")
+	if topic == "" || strings.Contains(topic, "/") {
+		return fmt.Errorf("invalid topic %q in url", topic)
+	}
+
+	records := make([]*kgo.Record, 0, len(batch))
+	for _, logReq := range batch {
+		event, err := newCloudEvent(logReq, s.log)
+		if err != nil {
+			return err
+		}
+		record := &kgo.Record{
+			Topic: topic,
+			Key:   []byte(logReq.Id),
+		}
+		ctx := binding.WithForceBinary(context.Background())
+		if _, err := binding.Write(ctx, binding.ToMessage(&event), nil, (*kafkaRecordWriter)(record)); err != nil {
+			return fmt.Errorf("while encoding cloudevent: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := s.producer.ProduceSync(context.Background(), records...).FirstErr(); err != nil {
+		return fmt.Errorf("while publishing to kafka: %w", err)
+	}
+	s.log.Infof("Published %d log records to kafka topic %s", len(records), topic)
+	return nil
+}
+
+// kafkaRecordWriter writes a CloudEvent to a Kafka record in binary mode.
+type kafkaRecordWriter kgo.Record
+
+var _ binding.BinaryWriter = (*kafkaRecordWriter)(nil)
+
+func (r *kafkaRecordWriter) Start(_ context.Context) error {
+	r.Headers = nil
+	return nil
+}
+
+func (r *kafkaRecordWriter) End(_ context.Context) error {
+	return nil
+}
+
+func (r *kafkaRecordWriter) SetData(data io.Reader) error {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, data); err != nil {
+		return err
+	}
+	r.Value = buf.Bytes()
+	return nil
+}
+
+func (r *kafkaRecordWriter) SetAttribute(attribute spec.Attribute, value interface{}) error {
+	if attribute.Kind() == spec.DataContentType {
+		return r.setHeader(kafkaContentTypeHeader, value)
+	}
+	return r.setHeader(kafkaHeaderPrefix+attribute.Name(), value)
+}
+
+func (r *kafkaRecordWriter) SetExtension(name string, value interface{}) error {
+	return r.setHeader(kafkaHeaderPrefix+name, value)
+}
+
+func (r *kafkaRecordWriter) setHeader(key string, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	s, err := types.Format(value)
+	if err != nil {
+		return err
+	}
+	r.Headers = append(r.Headers, kgo.RecordHeader{Key: key, Value: []byte(s)})
+	return nil
+}
+
+// kafkaClientOptions returns the client options for the acks and the TLS and SASL settings of the environment.
+func kafkaClientOptions(acks string) ([]kgo.Opt, error) {
+	opts := []kgo.Opt{kgo.ClientID(kafkaClientId)}
+	switch acks {
+	case "", KafkaAcksAll:
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	case KafkaAcksLeader:
+		// idempotent writes require the acks of all the in-sync replicas
+		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	case KafkaAcksNone:
+		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	default:
+		return nil, fmt.Errorf("invalid kafka acks %q, expected one of all, leader or none", acks)
+	}
+
+	protocol := os.Getenv(kafka.KafkaSecurityProtocol)
+	switch protocol {
+	case "", "PLAINTEXT", "SASL_PLAINTEXT":
+	case "SSL", "SASL_SSL":
+		tlsConfig, err := kafkaTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	default:
+		return nil, fmt.Errorf("invalid kafka security protocol %q", protocol)
+	}
+
+	if strings.HasPrefix(protocol, "SASL_") {
+		user, pass := os.Getenv(kafka.KafkaSaslUsername), os.Getenv(kafka.KafkaSaslPassword)
+		mechanism := os.Getenv(kafka.KafkaSaslMechanism)
+		switch mechanism {
+		case "", "PLAIN":
+			opts = append(opts, kgo.SASL(plain.Auth{User: user, Pass: pass}.AsMechanism()))
+		case "SCRAM-SHA-256":
+			opts = append(opts, kgo.SASL(scram.Auth{User: user, Pass: pass}.AsSha256Mechanism()))
+		case "SCRAM-SHA-512":
+			opts = append(opts, kgo.SASL(scram.Auth{User: user, Pass: pass}.AsSha512Mechanism()))
+		default:
+			return nil, fmt.Errorf("unsupported kafka sasl mechanism %q", mechanism)
+		}
+	}
+	return opts, nil
+}
+
+func kafkaTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caCert := os.Getenv(kafka.KafkaCACert); caCert != "" {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("while parsing kafka CA certificate")
+		}
+	}
+	clientCert, clientKey := os.Getenv(kafka.KafkaClientCert), os.Getenv(kafka.KafkaClientKey)
+	if clientCert != "" || clientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("while parsing kafka client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/twmb/franz-go/pkg/kgo"
+	pkglogging "knative.dev/pkg/logging"
+
+	"github.com/kserve/kserve/pkg/credentials/kafka"
+)
+
+type fakeKafkaProducer struct {
+	records []*kgo.Record
+	err     error
+}
+
+func (p *fakeKafkaProducer) ProduceSync(_ context.Context, records ...*kgo.Record) kgo.ProduceResults {
+	results := make(kgo.ProduceResults, 0, len(records))
+	for _, record := range records {
+		if p.err == nil {
+			p.records = append(p.records, record)
+		}
+		results = append(results, kgo.ProduceResult{Record: record, Err: p.err})
+	}
+	return results
+}
+
+func recordHeaders(record *kgo.Record) map[string]string {
+	headers := map[string]string{}
+	for _, header := range record.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	return headers
+}
+
+func TestKafkaStore(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	producer := &fakeKafkaProducer{}
+	store := NewKafkaStore(producer, logger)
+	logUrl, _ := url.Parse("kafka://broker-0:9092,broker-1:9092/inference-logs")
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	request := []byte(`{"instances":[[1,2,3]]}`)
+	response := []byte(`{"predictions":[1]}`)
+	batch := []LogRequest{
+		{
+			Url:              logUrl,
+			Bytes:            &request,
+			ContentType:      "application/json",
+			ReqType:          CEInferenceRequest,
+			Id:               "0123",
+			SourceUri:        sourceUri,
+			InferenceService: "sklearn-iris",
+			Namespace:        "default",
+			Component:        "predictor",
+			OccurrenceTime:   time.Now(),
+		},
+		{
+			Url:            logUrl,
+			Bytes:          &response,
+			ContentType:    "application/json",
+			ReqType:        CEInferenceResponse,
+			Id:             "0123",
+			SourceUri:      sourceUri,
+			OccurrenceTime: time.Now(),
+			StatusCode:     200,
+			LatencyMs:      1.5,
+		},
+	}
+
+	g.Expect(store.Store(logUrl, batch)).To(gomega.Succeed())
+	g.Expect(producer.records).To(gomega.HaveLen(2))
+
+	record := producer.records[0]
+	g.Expect(record.Topic).To(gomega.Equal("inference-logs"))
+	g.Expect(string(record.Key)).To(gomega.Equal("0123"))
+	g.Expect(record.Value).To(gomega.Equal(request))
+	headers := recordHeaders(record)
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_specversion", "1.0"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_id", "0123"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_type", CEInferenceRequest))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_source", "http://localhost:9081/"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_"+InferenceServiceAttr, "sklearn-iris"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_"+NamespaceAttr, "default"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("content-type", "application/json"))
+
+	record = producer.records[1]
+	g.Expect(string(record.Key)).To(gomega.Equal("0123"))
+	g.Expect(record.Value).To(gomega.Equal(response))
+	headers = recordHeaders(record)
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_type", CEInferenceResponse))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_"+StatusCodeAttr, "200"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_"+LatencyAttr, "1.5"))
+}
+
+func TestKafkaStoreErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	data := []byte("{}")
+	request := LogRequest{Bytes: &data, ReqType: CEInferenceRequest, Id: "0123", SourceUri: &url.URL{}}
+
+	producer := &fakeKafkaProducer{}
+	store := NewKafkaStore(producer, logger)
+	g.Expect(store.Store(nil, []LogRequest{request})).To(gomega.HaveOccurred())
+	noTopic, _ := url.Parse("kafka://broker:9092")
+	g.Expect(store.Store(noTopic, []LogRequest{request})).To(gomega.MatchError(gomega.ContainSubstring("topic")))
+
+	producer.err = errors.New("not enough replicas")
+	logUrl, _ := url.Parse("kafka://broker:9092/logs")
+	g.Expect(store.Store(logUrl, []LogRequest{request})).To(gomega.MatchError(gomega.ContainSubstring("not enough replicas")))
+}
+
+func TestKafkaClientOptions(t *testing.T) {
+	scenarios := map[string]struct {
+		acks     string
+		env      map[string]string
+		expected string
+	}{
+		"default": {},
+		"leader acks": {
+			acks: KafkaAcksLeader,
+		},
+		"invalid acks": {
+			acks:     "2",
+			expected: "invalid kafka acks",
+		},
+		"sasl scram": {
+			env: map[string]string{
+				kafka.KafkaSecurityProtocol: "SASL_SSL",
+				kafka.KafkaSaslMechanism:    "SCRAM-SHA-512",
+				kafka.KafkaSaslUsername:     "logger",
+				kafka.KafkaSaslPassword:     "password",
+			},
+		},
+		"invalid protocol": {
+			env: map[string]string{
+				kafka.KafkaSecurityProtocol: "TLS",
+			},
+			expected: "invalid kafka security protocol",
+		},
+		"unsupported mechanism": {
+			env: map[string]string{
+				kafka.KafkaSecurityProtocol: "SASL_PLAINTEXT",
+				kafka.KafkaSaslMechanism:    "GSSAPI",
+			},
+			expected: "unsupported kafka sasl mechanism",
+		},
+		"invalid ca cert": {
+			env: map[string]string{
+				kafka.KafkaSecurityProtocol: "SSL",
+				kafka.KafkaCACert:           "not a certificate",
+			},
+			expected: "CA certificate",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			for key, value := range scenario.env {
+				t.Setenv(key, value)
+			}
+			opts, err := kafkaClientOptions(scenario.acks)
+			if scenario.expected != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(scenario.expected)))
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			client, err := kgo.NewClient(append(opts, kgo.SeedBrokers("localhost:9092"))...)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			client.Close()
+		})
+	}
+}
+
+func TestKafkaStorageStrategy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(GetStorageStrategy("kafka://broker:9092/logs")).To(gomega.Equal(KafkaStorage))
+}
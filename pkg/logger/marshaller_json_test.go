@@ -371,6 +371,17 @@ func TestJSONMarshallerHandlerVaryingRecordContent(t *testing.T) {
 				TlsSkipVerify: true,
 			},
 		},
+		{
+			name: "with response outcome",
+			logRequest: LogRequest{
+				Id:         "with-outcome",
+				ReqType:    CEInferenceResponse,
+				Truncated:  true,
+				StatusCode: 503,
+				LatencyMs:  12.5,
+				ErrorClass: ErrorClassUnavailable,
+			},
+		},
 		{
 			name: "fully populated",
 			logRequest: LogRequest{
@@ -26,20 +26,24 @@ import (
 // CSV and Parquet marshallers. All complex types (URL, bytes, maps) are
 // converted to string representations for tabular storage.
 type logRecord struct {
-	Url              string `parquet:"url"              csv:"url"`
-	Bytes            string `parquet:"bytes"            csv:"bytes"`
-	ContentType      string `parquet:"contentType"      csv:"contentType"`
-	ReqType          string `parquet:"reqType"          csv:"reqType"`
-	Id               string `parquet:"id"               csv:"id"`
-	SourceUri        string `parquet:"sourceUri"        csv:"sourceUri"`
-	InferenceService string `parquet:"inferenceService" csv:"inferenceService"`
-	Namespace        string `parquet:"namespace"        csv:"namespace"`
-	Component        string `parquet:"component"        csv:"component"`
-	Endpoint         string `parquet:"endpoint"         csv:"endpoint"`
-	Metadata         string `parquet:"metadata"         csv:"metadata"`
-	Annotations      string `parquet:"annotations"      csv:"annotations"`
-	CertName         string `parquet:"certName"         csv:"certName"`
-	TlsSkipVerify    bool   `parquet:"tlsSkipVerify"    csv:"tlsSkipVerify"`
+	Url              string  `parquet:"url"              csv:"url"`
+	Bytes            string  `parquet:"bytes"            csv:"bytes"`
+	ContentType      string  `parquet:"contentType"      csv:"contentType"`
+	ReqType          string  `parquet:"reqType"          csv:"reqType"`
+	Id               string  `parquet:"id"               csv:"id"`
+	SourceUri        string  `parquet:"sourceUri"        csv:"sourceUri"`
+	InferenceService string  `parquet:"inferenceService" csv:"inferenceService"`
+	Namespace        string  `parquet:"namespace"        csv:"namespace"`
+	Component        string  `parquet:"component"        csv:"component"`
+	Endpoint         string  `parquet:"endpoint"         csv:"endpoint"`
+	Metadata         string  `parquet:"metadata"         csv:"metadata"`
+	Annotations      string  `parquet:"annotations"      csv:"annotations"`
+	CertName         string  `parquet:"certName"         csv:"certName"`
+	TlsSkipVerify    bool    `parquet:"tlsSkipVerify"    csv:"tlsSkipVerify"`
+	Truncated        bool    `parquet:"truncated"        csv:"truncated"`
+	StatusCode       int     `parquet:"statusCode"       csv:"statusCode"`
+	LatencyMs        float64 `parquet:"latencyMs"        csv:"latencyMs"`
+	ErrorClass       string  `parquet:"errorClass"       csv:"errorClass"`
 }
 
 // logRecordColumns returns the CSV header row as a slice of column names
@@ -60,6 +64,10 @@ func logRecordColumns() []string {
 		"annotations",
 		"certName",
 		"tlsSkipVerify",
+		"truncated",
+		"statusCode",
+		"latencyMs",
+		"errorClass",
 	}
 }
 
@@ -69,7 +77,7 @@ func logRecordColumns() []string {
 // - Metadata: json.Marshal(map), empty string if nil
 // - Annotations: json.Marshal(map), empty string if nil
 // - All other string fields: direct copy
-// - TlsSkipVerify: direct copy
+// - TlsSkipVerify, Truncated, StatusCode, LatencyMs, ErrorClass: direct copy
 func toLogRecord(req LogRequest) logRecord {
 	record := logRecord{
 		ContentType:      req.ContentType,
@@ -81,6 +89,10 @@ func toLogRecord(req LogRequest) logRecord {
 		Endpoint:         req.Endpoint,
 		CertName:         req.CertName,
 		TlsSkipVerify:    req.TlsSkipVerify,
+		Truncated:        req.Truncated,
+		StatusCode:       req.StatusCode,
+		LatencyMs:        req.LatencyMs,
+		ErrorClass:       req.ErrorClass,
 	}
 
 	// Convert URL to string
@@ -133,5 +145,9 @@ func logRecordToStrings(record logRecord) []string {
 		record.Annotations,
 		record.CertName,
 		strconv.FormatBool(record.TlsSkipVerify),
+		strconv.FormatBool(record.Truncated),
+		strconv.Itoa(record.StatusCode),
+		strconv.FormatFloat(record.LatencyMs, 'f', -1, 64),
+		record.ErrorClass,
 	}
 }
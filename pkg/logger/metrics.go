@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	spoolDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_logger_spool_depth",
+		Help: "Number of log batches waiting in the spool to be delivered",
+	})
+	spoolBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_logger_spool_bytes",
+		Help: "Size of the log batches waiting in the spool to be delivered",
+	})
+	spoolRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_logger_spool_retries_total",
+		Help: "Number of failed delivery retries of spooled log batches",
+	})
+	spoolDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_logger_spool_dropped_total",
+		Help: "Number of log batches dropped from the spool",
+	}, []string{"reason"})
+	cloudEventsDelivered = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_logger_cloudevents_delivered_total",
+		Help: "Number of log cloud events delivered to the logger URL",
+	})
+	cloudEventsRetried = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_logger_cloudevents_retried_total",
+		Help: "Number of retried deliveries of log cloud events",
+	})
+	cloudEventsDeadLettered = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_logger_cloudevents_dead_lettered_total",
+		Help: "Number of log cloud events sent to the dead letter URL after failing to be delivered",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(spoolDepth, spoolBytes, spoolRetries, spoolDropped)
+	prometheus.MustRegister(cloudEventsDelivered, cloudEventsRetried, cloudEventsDeadLettered)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// RedactedValue replaces the redacted values in mask mode
+const RedactedValue = "[REDACTED]"
+
+// Redactor masks or hashes the sensitive body fields and metadata headers of the log records.
+type Redactor struct {
+	fields  []string
+	headers map[string]bool
+	mode    v1beta1.LoggerRedactionMode
+}
+
+// NewRedactor creates a Redactor for the given GJSON field paths and header names. It returns
+// nil when there is nothing to redact.
+func NewRedactor(fields []string, headers []string, mode v1beta1.LoggerRedactionMode) (*Redactor, error) {
+	switch mode {
+	case "":
+		mode = v1beta1.RedactionMask
+	case v1beta1.RedactionMask, v1beta1.RedactionHash:
+	default:
+		return nil, fmt.Errorf("invalid redaction mode %s", mode)
+	}
+	if len(fields) == 0 && len(headers) == 0 {
+		return nil, nil
+	}
+	redactor := &Redactor{
+		fields:  fields,
+		headers: make(map[string]bool, len(headers)),
+		mode:    mode,
+	}
+	for _, header := range headers {
+		redactor.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	return redactor, nil
+}
+
+// replacement returns the value which replaces a redacted value.
+func (r *Redactor) replacement(value []byte) string {
+	if r.mode == v1beta1.RedactionHash {
+		sum := sha256.Sum256(value)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return RedactedValue
+}
+
+// redactBody returns a copy of the body with the values of the redacted fields replaced.
+// A body which is not valid JSON, or whose redacted values cannot be located, is replaced
+// as a whole.
+func (r *Redactor) redactBody(body []byte) []byte {
+	if r == nil || len(r.fields) == 0 || len(body) == 0 {
+		return body
+	}
+	if !gjson.ValidBytes(body) {
+		return []byte(r.replacement(body))
+	}
+	type span struct {
+		start, end  int
+		replacement []byte
+	}
+	var spans []span
+	for _, field := range r.fields {
+		for _, value := range matches(gjson.GetBytes(body, field)) {
+			if value.Index <= 0 && value.Raw != string(body) {
+				// the value has no position in the body, e.g. the result of a nested query or
+				// a modifier, so the body cannot be logged without it
+				return []byte(r.replacement(body))
+			}
+			raw := []byte(value.Raw)
+			if value.Type == gjson.String {
+				raw = []byte(value.Str)
+			}
+			replacement, _ := json.Marshal(r.replacement(raw))
+			spans = append(spans, span{value.Index, value.Index + len(value.Raw), replacement})
+		}
+	}
+	// keep the outermost values only, the values nested in a redacted value are redacted with it
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start || spans[i].start == spans[j].start && spans[i].end > spans[j].end
+	})
+	outer := make([]span, 0, len(spans))
+	for _, s := range spans {
+		if len(outer) > 0 && s.start < outer[len(outer)-1].end {
+			continue
+		}
+		outer = append(outer, s)
+	}
+	redacted := make([]byte, 0, len(body))
+	last := 0
+	for _, s := range outer {
+		redacted = append(redacted, body[last:s.start]...)
+		redacted = append(redacted, s.replacement...)
+		last = s.end
+	}
+	return append(redacted, body[last:]...)
+}
+
+// matches returns the values of a GJSON result, flattening the results of the queries
+// on arrays such as "inputs.#.data".
+func matches(result gjson.Result) []gjson.Result {
+	if !result.Exists() {
+		return nil
+	}
+	if result.Indexes == nil {
+		return []gjson.Result{result}
+	}
+	values := result.Array()
+	flat := make([]gjson.Result, 0, len(values))
+	for i, value := range values {
+		if i < len(result.Indexes) {
+			value.Index = result.Indexes[i]
+		}
+		flat = append(flat, value)
+	}
+	return flat
+}
+
+// redactHeaders returns a copy of the metadata headers with the values of the redacted headers replaced.
+func (r *Redactor) redactHeaders(metadata map[string][]string) map[string][]string {
+	if r == nil || len(r.headers) == 0 {
+		return metadata
+	}
+	redacted := make(map[string][]string, len(metadata))
+	for name, values := range metadata {
+		if !r.headers[http.CanonicalHeaderKey(name)] {
+			redacted[name] = values
+			continue
+		}
+		redacted[name] = make([]string, 0, len(values))
+		for _, value := range values {
+			redacted[name] = append(redacted[name], r.replacement([]byte(value)))
+		}
+	}
+	return redacted
+}
+
+// sampled reports whether the call with the given request ID is logged. The decision only
+// depends on the ID, so the request and the response of a call are sampled together.
+func sampled(id string, percentage int) bool {
+	if percentage >= 100 {
+		return true
+	}
+	if percentage <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return h.Sum32()%100 < uint32(percentage)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func TestRedactBody(t *testing.T) {
+	emailHash := sha256.Sum256([]byte("a@b.c"))
+	body := `{"messages":[{"role":"user","content":"my ssn is 123"},{"role":"assistant","content":"ok"}],"user":{"email":"a@b.c","age":42},"model":"llama"}`
+	scenarios := map[string]struct {
+		fields   []string
+		mode     v1beta1.LoggerRedactionMode
+		body     string
+		expected string
+	}{
+		"MaskField": {
+			fields:   []string{"user.email"},
+			body:     body,
+			expected: `{"messages":[{"role":"user","content":"my ssn is 123"},{"role":"assistant","content":"ok"}],"user":{"email":"[REDACTED]","age":42},"model":"llama"}`,
+		},
+		"MaskArrayQuery": {
+			fields:   []string{"messages.#.content", "user.age"},
+			body:     body,
+			expected: `{"messages":[{"role":"user","content":"[REDACTED]"},{"role":"assistant","content":"[REDACTED]"}],"user":{"email":"a@b.c","age":"[REDACTED]"},"model":"llama"}`,
+		},
+		"MaskNestedValues": {
+			fields:   []string{"user", "user.email"},
+			body:     body,
+			expected: `{"messages":[{"role":"user","content":"my ssn is 123"},{"role":"assistant","content":"ok"}],"user":"[REDACTED]","model":"llama"}`,
+		},
+		"HashField": {
+			fields: []string{"user.email"},
+			mode:   v1beta1.RedactionHash,
+			body:   body,
+			expected: `{"messages":[{"role":"user","content":"my ssn is 123"},{"role":"assistant","content":"ok"}],"user":{"email":"sha256:` +
+				hex.EncodeToString(emailHash[:]) + `","age":42},"model":"llama"}`,
+		},
+		"MissingField": {
+			fields:   []string{"inputs.#.data"},
+			body:     body,
+			expected: body,
+		},
+		"NestedArrayQuery": {
+			fields:   []string{"a.#.b.#.c"},
+			body:     `{"a":[{"b":[{"c":1},{"c":2}]}]}`,
+			expected: RedactedValue,
+		},
+		"InvalidJSON": {
+			fields:   []string{"user.email"},
+			body:     `{"user":{"email":"a@b.c"`,
+			expected: RedactedValue,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			redactor, err := NewRedactor(scenario.fields, nil, scenario.mode)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			original := []byte(scenario.body)
+			g.Expect(string(redactor.redactBody(original))).To(gomega.Equal(scenario.expected))
+			g.Expect(string(original)).To(gomega.Equal(scenario.body))
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	redactor, err := NewRedactor(nil, []string{"authorization", "X-User"}, v1beta1.RedactionHash)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	redacted := redactor.redactHeaders(map[string][]string{
+		"Authorization": {"Bearer token"},
+		"X-User":        {"alice", "bob"},
+		"Foo":           {"bar"},
+	})
+	g.Expect(redacted["Authorization"]).To(gomega.Equal([]string{redactor.replacement([]byte("Bearer token"))}))
+	g.Expect(redacted["Authorization"][0]).To(gomega.HavePrefix("sha256:"))
+	g.Expect(redacted["X-User"]).To(gomega.HaveLen(2))
+	g.Expect(redacted["X-User"][0]).ToNot(gomega.Equal(redacted["X-User"][1]))
+	g.Expect(redacted["Foo"]).To(gomega.Equal([]string{"bar"}))
+}
+
+func TestNewRedactor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	redactor, err := NewRedactor(nil, nil, v1beta1.RedactionMask)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(redactor).To(gomega.BeNil())
+	// a nil redactor leaves the payloads untouched
+	g.Expect(redactor.redactBody([]byte(`{"a":1}`))).To(gomega.Equal([]byte(`{"a":1}`)))
+	_, err = NewRedactor([]string{"a"}, nil, "encrypt")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestSampled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	count := 0
+	for i := range 10000 {
+		id := fmt.Sprintf("request-%d", i)
+		if sampled(id, 25) {
+			count++
+		}
+		// the decision is deterministic for a request ID
+		g.Expect(sampled(id, 25)).To(gomega.Equal(sampled(id, 25)))
+		g.Expect(sampled(id, 100)).To(gomega.BeTrue())
+		g.Expect(sampled(id, 0)).To(gomega.BeFalse())
+	}
+	g.Expect(count).To(gomega.BeNumerically("~", 2500, 250))
+}
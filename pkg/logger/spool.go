@@ -0,0 +1,316 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	spoolFileExtension = ".json"
+	spoolTempExtension = ".tmp"
+
+	DefaultSpoolMinBackoff = time.Second
+	DefaultSpoolMaxBackoff = 5 * time.Minute
+)
+
+// Reasons of the spooled batches drops
+const (
+	spoolDropEvicted   = "evicted"
+	spoolDropOversized = "oversized"
+	spoolDropCorrupted = "corrupted"
+)
+
+// spooledRequest is the spooled form of a LogRequest. The URLs are kept as strings, since
+// url.URL does not survive a JSON round trip with its user info.
+type spooledRequest struct {
+	LogRequest
+	Url       string `json:"url,omitempty"`
+	SourceUri string `json:"sourceUri,omitempty"`
+}
+
+func encodeBatch(batch []LogRequest) ([]byte, error) {
+	spooled := make([]spooledRequest, 0, len(batch))
+	for _, req := range batch {
+		r := spooledRequest{LogRequest: req}
+		if req.Url != nil {
+			r.Url = req.Url.String()
+		}
+		if req.SourceUri != nil {
+			r.SourceUri = req.SourceUri.String()
+		}
+		spooled = append(spooled, r)
+	}
+	return json.Marshal(spooled)
+}
+
+func decodeBatch(data []byte) ([]LogRequest, error) {
+	var spooled []spooledRequest
+	if err := json.Unmarshal(data, &spooled); err != nil {
+		return nil, err
+	}
+	batch := make([]LogRequest, 0, len(spooled))
+	for _, r := range spooled {
+		req := r.LogRequest
+		var err error
+		if req.Url, err = url.Parse(r.Url); err != nil {
+			return nil, err
+		}
+		if r.SourceUri != "" {
+			if req.SourceUri, err = url.Parse(r.SourceUri); err != nil {
+				return nil, err
+			}
+		}
+		batch = append(batch, req)
+	}
+	return batch, nil
+}
+
+type spoolEntry struct {
+	name string
+	size int64
+}
+
+// Spool is a write-ahead directory for the log batches which could not be delivered. The
+// batches are written as files, so that they survive a restart of the agent, and they are
+// retried oldest first with exponential backoff until they are delivered. When the spool
+// exceeds its size limit, the oldest batches are evicted.
+type Spool struct {
+	dir        string
+	maxBytes   int64
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	log        *zap.SugaredLogger
+
+	mu sync.Mutex
+	// entries holds the spooled batches, oldest first.
+	entries []spoolEntry
+	size    int64
+	seq     uint64
+	wake    chan struct{}
+}
+
+// NewSpool creates a Spool in the given directory, which is created if needed. The batches
+// left in the directory by a previous run are loaded to be retried.
+func NewSpool(dir string, maxBytes int64, minBackoff, maxBackoff time.Duration, log *zap.SugaredLogger) (*Spool, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("invalid spool size limit %d", maxBytes)
+	}
+	if minBackoff <= 0 || maxBackoff < minBackoff {
+		return nil, fmt.Errorf("invalid spool backoff %s-%s", minBackoff, maxBackoff)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	s := &Spool{
+		dir:        dir,
+		maxBytes:   maxBytes,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+		log:        log,
+		wake:       make(chan struct{}, 1),
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+	// the file names start with the spool time, and ReadDir sorts them, so that the entries are
+	// in spool order
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		name := file.Name()
+		if strings.HasSuffix(name, spoolTempExtension) {
+			// interrupted write
+			_ = os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		if !strings.HasSuffix(name, spoolFileExtension) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		s.entries = append(s.entries, spoolEntry{name: name, size: info.Size()})
+		s.size += info.Size()
+	}
+	if len(s.entries) > 0 {
+		log.Infof("Loaded %d log batches from the spool %s", len(s.entries), dir)
+	}
+	s.evict(0)
+	s.updateMetrics()
+	return s, nil
+}
+
+// Put persists a batch which could not be delivered, evicting the oldest batches when the
+// spool would exceed its size limit.
+func (s *Spool) Put(batch []LogRequest) error {
+	data, err := encodeBatch(batch)
+	if err != nil {
+		return fmt.Errorf("failed to encode the log batch: %w", err)
+	}
+	size := int64(len(data))
+	if size > s.maxBytes {
+		spoolDropped.WithLabelValues(spoolDropOversized).Inc()
+		return fmt.Errorf("log batch of %d bytes exceeds the spool size limit", size)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evict(size)
+	s.seq++
+	name := fmt.Sprintf("%020d-%010d%s", time.Now().UnixNano(), s.seq, spoolFileExtension)
+	path := filepath.Join(s.dir, name)
+	// write to a temporary file first, so that a crash never leaves a partial batch behind
+	if err := os.WriteFile(path+spoolTempExtension, data, 0o600); err != nil {
+		return fmt.Errorf("failed to spool the log batch: %w", err)
+	}
+	if err := os.Rename(path+spoolTempExtension, path); err != nil {
+		_ = os.Remove(path + spoolTempExtension)
+		return fmt.Errorf("failed to spool the log batch: %w", err)
+	}
+	s.entries = append(s.entries, spoolEntry{name: name, size: size})
+	s.size += size
+	s.updateMetrics()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// evict removes the oldest batches until a batch of the given size fits in the spool.
+// The caller must hold the lock.
+func (s *Spool) evict(size int64) {
+	for len(s.entries) > 0 && s.size+size > s.maxBytes {
+		oldest := s.entries[0]
+		s.entries = s.entries[1:]
+		s.size -= oldest.size
+		if err := os.Remove(filepath.Join(s.dir, oldest.name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			s.log.Errorf("Failed to remove the evicted log batch %s: %v", oldest.name, err)
+		}
+		spoolDropped.WithLabelValues(spoolDropEvicted).Inc()
+		s.log.Warnf("Spool size limit reached, evicted the log batch %s", oldest.name)
+	}
+}
+
+// remove deletes a batch from the spool, unless it has already been evicted.
+func (s *Spool) remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, entry := range s.entries {
+		if entry.name == name {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			s.size -= entry.size
+			if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				s.log.Errorf("Failed to remove the delivered log batch %s: %v", name, err)
+			}
+			break
+		}
+	}
+	s.updateMetrics()
+}
+
+// oldest returns the oldest spooled batch name, or false when the spool is empty.
+func (s *Spool) oldest() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.entries) == 0 {
+		return "", false
+	}
+	return s.entries[0].name, true
+}
+
+// Len returns the number of spooled batches.
+func (s *Spool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// updateMetrics must be called with the lock held.
+func (s *Spool) updateMetrics() {
+	spoolDepth.Set(float64(len(s.entries)))
+	spoolBytes.Set(float64(s.size))
+}
+
+// Run retries the delivery of the spooled batches, oldest first, until the context is
+// cancelled. The wait between two failed deliveries doubles up to the max backoff, and is
+// reset when a delivery succeeds.
+func (s *Spool) Run(ctx context.Context, deliver func(batch []LogRequest) error) {
+	backoff := s.minBackoff
+	for {
+		name, ok := s.oldest()
+		if !ok {
+			select {
+			case <-s.wake:
+			case <-ctx.Done():
+				return
+			}
+			// the batch has just failed to be delivered, so do not retry it right away
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			// evicted while it was read
+			s.remove(name)
+			continue
+		}
+		var batch []LogRequest
+		if err == nil {
+			batch, err = decodeBatch(data)
+		}
+		if err != nil || len(batch) == 0 {
+			s.log.Errorf("Dropping the unreadable log batch %s: %v", name, err)
+			spoolDropped.WithLabelValues(spoolDropCorrupted).Inc()
+			s.remove(name)
+			continue
+		}
+		if err := deliver(batch); err != nil {
+			spoolRetries.Inc()
+			s.log.Warnf("Failed to deliver the spooled log batch %s, retrying in %s: %v", name, backoff, err)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			backoff = min(2*backoff, s.maxBackoff)
+			continue
+		}
+		s.remove(name)
+		backoff = s.minBackoff
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+
+	"github.com/kserve/kserve/pkg/agent/storage"
+)
+
+// fakeProvider is a storage.Provider whose first uploads fail.
+type fakeProvider struct {
+	mu       sync.Mutex
+	failures int
+	uploads  chan string
+}
+
+var _ storage.Provider = &fakeProvider{}
+
+func (p *fakeProvider) DownloadModel(_ string, _ string, _ string) error {
+	return nil
+}
+
+func (p *fakeProvider) UploadObject(bucket string, key string, _ []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("bucket unavailable")
+	}
+	p.uploads <- bucket + "/" + key
+	return nil
+}
+
+func spoolRequest(t *testing.T, logUrl string, id string) LogRequest {
+	u, err := url.Parse(logUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(`{"instances":[[1,2,3]]}`)
+	return LogRequest{
+		Url:         u,
+		Bytes:       &data,
+		ContentType: "application/json",
+		ReqType:     CEInferenceRequest,
+		Id:          id,
+	}
+}
+
+func TestSpoolRetriesFailedBatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	server := httptest.NewServer(NewJSONMarshallerHandler())
+	defer server.Close()
+	provider := &fakeProvider{failures: 3, uploads: make(chan string, 1)}
+	store := NewBlobStore("logger", NewHTTPMarshaller(server.URL+"/marshal", &http.Client{}), provider, logger)
+
+	spool, err := NewSpool(t.TempDir(), 1<<20, 10*time.Millisecond, 40*time.Millisecond, logger)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	retries := testutil.ToFloat64(spoolRetries)
+
+	StartDispatcher(1, store, &ImmediateBatch{}, spool, nil, logger)
+	g.Expect(QueueLogRequest(spoolRequest(t, "s3://bucket/prefix", "0123"))).To(gomega.Succeed())
+
+	select {
+	case key := <-provider.uploads:
+		g.Expect(key).To(gomega.Equal("bucket/prefix/logger/0123-request.json"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the spooled batch was not delivered")
+	}
+	// the first upload failure spools the batch, the next two are spool retries
+	g.Expect(testutil.ToFloat64(spoolRetries) - retries).To(gomega.Equal(2.0))
+	g.Eventually(spool.Len).Should(gomega.Equal(0))
+}
+
+func TestSpoolEvictsOldestBatches(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	batch := []LogRequest{spoolRequest(t, "s3://bucket", "0")}
+	data, err := encodeBatch(batch)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// room for two batches
+	dir := t.TempDir()
+	spool, err := NewSpool(dir, int64(2*len(data)+len(data)/2), time.Millisecond, time.Millisecond, logger)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	evicted := testutil.ToFloat64(spoolDropped.WithLabelValues(spoolDropEvicted))
+
+	for _, id := range []string{"0", "1", "2"} {
+		g.Expect(spool.Put([]LogRequest{spoolRequest(t, "s3://bucket", id)})).To(gomega.Succeed())
+	}
+	g.Expect(spool.Len()).To(gomega.Equal(2))
+	g.Expect(testutil.ToFloat64(spoolDropped.WithLabelValues(spoolDropEvicted)) - evicted).To(gomega.Equal(1.0))
+	files, err := os.ReadDir(dir)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(files).To(gomega.HaveLen(2))
+
+	var delivered []string
+	ctx, cancel := context.WithCancel(context.Background())
+	go spool.Run(ctx, func(batch []LogRequest) error {
+		delivered = append(delivered, batch[0].Id)
+		if len(delivered) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	<-ctx.Done()
+	g.Expect(delivered).To(gomega.Equal([]string{"1", "2"}))
+
+	oversized := make([]byte, 3*len(data))
+	batch[0].Bytes = &oversized
+	g.Expect(spool.Put(batch)).To(gomega.HaveOccurred())
+}
+
+func TestSpoolReloadsAfterRestart(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	dir := t.TempDir()
+	spool, err := NewSpool(dir, 1<<20, time.Millisecond, time.Millisecond, logger)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(spool.Put([]LogRequest{spoolRequest(t, "abfs://container@account/logs", "0")})).To(gomega.Succeed())
+	g.Expect(spool.Put([]LogRequest{spoolRequest(t, "http://broker", "1")})).To(gomega.Succeed())
+	// an interrupted write and a corrupted batch
+	g.Expect(os.WriteFile(filepath.Join(dir, "99999999999999999999-0000000001.json.tmp"), []byte("[{"), 0o600)).To(gomega.Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "99999999999999999999-0000000002.json"), []byte("[{"), 0o600)).To(gomega.Succeed())
+
+	restarted, err := NewSpool(dir, 1<<20, time.Millisecond, time.Millisecond, logger)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(restarted.Len()).To(gomega.Equal(3))
+
+	delivered := make(chan LogRequest, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go restarted.Run(ctx, func(batch []LogRequest) error {
+		delivered <- batch[0]
+		return nil
+	})
+	first, second := <-delivered, <-delivered
+	g.Expect(first.Id).To(gomega.Equal("0"))
+	g.Expect(first.Url.User.Username()).To(gomega.Equal("container"))
+	g.Expect(*first.Bytes).To(gomega.Equal([]byte(`{"instances":[[1,2,3]]}`)))
+	g.Expect(second.Url.String()).To(gomega.Equal("http://broker"))
+	g.Eventually(restarted.Len).Should(gomega.Equal(0))
+	files, err := os.ReadDir(dir)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(files).To(gomega.BeEmpty())
+}
@@ -33,6 +33,9 @@ const (
 	GCSStorage   StorageStrategy = "gcs"
 	AzureStorage StorageStrategy = "abfs"
 	HttpStorage  StorageStrategy = "http"
+	KafkaStorage StorageStrategy = "kafka"
+	FileStorage  StorageStrategy = "file"
+	PVCStorage   StorageStrategy = "pvc"
 )
 
 const (
@@ -55,6 +58,12 @@ func GetStorageStrategy(url string) StorageStrategy {
 		return GCSStorage
 	case strings.HasPrefix(url, "abfs"):
 		return AzureStorage
+	case strings.HasPrefix(url, "kafka"):
+		return KafkaStorage
+	case strings.HasPrefix(url, "file"):
+		return FileStorage
+	case strings.HasPrefix(url, "pvc"):
+		return PVCStorage
 	default:
 		return DefaultStorage
 	}
@@ -107,6 +116,10 @@ func NewStoreForScheme(scheme string, logStorePath string, marshaller Marshaller
 		scheme += "://"
 	}
 	protocol := storage.Protocol(scheme)
+	switch protocol {
+	case storage.File, storage.PVC:
+		return NewFileStore(logStorePath, marshaller, FileRotation{}, log), nil
+	}
 	provider, err := storage.GetProvider(map[storage.Protocol]storage.Provider{}, protocol)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage provider: %w", err)
@@ -147,7 +160,7 @@ func (s *BlobStore) Store(logUrl *url.URL, batch []LogRequest) error {
 	}
 
 	// Use the first record for object key generation (prefix, id, type).
-	objectKey, err := s.getObjectKey(configPrefix, &batch[0], response.Extension)
+	objectKey, err := getObjectKey(s.storePath, configPrefix, &batch[0], response.Extension)
 	if err != nil {
 		s.log.Error(err)
 		return err
@@ -162,7 +175,7 @@ func (s *BlobStore) Store(logUrl *url.URL, batch []LogRequest) error {
 	return nil
 }
 
-func (s *BlobStore) getObjectPrefix(configPrefix string, request *LogRequest) (string, error) {
+func getObjectPrefix(storePath string, configPrefix string, request *LogRequest) (string, error) {
 	if request == nil {
 		return "", errors.New("log request is invalid")
 	}
@@ -181,18 +194,21 @@ func (s *BlobStore) getObjectPrefix(configPrefix string, request *LogRequest) (s
 		parts = append(parts, request.Component)
 	}
 
-	if s.storePath != "" {
-		parts = append(parts, s.storePath)
+	if storePath != "" {
+		parts = append(parts, storePath)
 	}
 	return path.Join(parts...), nil
 }
 
-func (s *BlobStore) getObjectKey(configPrefix string, request *LogRequest, extension string) (string, error) {
+// getObjectKey returns the key of the object storing a batch, laid out as
+// <configPrefix>/<namespace>/<inferenceService>/<component>/<storePath>/<id>-<type>.<extension>
+// after the first log request of the batch.
+func getObjectKey(storePath string, configPrefix string, request *LogRequest, extension string) (string, error) {
 	if request == nil {
 		return "", errors.New("log request is invalid")
 	}
 
-	prefix, err := s.getObjectPrefix(configPrefix, request)
+	prefix, err := getObjectPrefix(storePath, configPrefix, request)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,312 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// FileRotation controls when the FileStore starts a new file. When neither limit is set,
+// every batch is written to its own file.
+type FileRotation struct {
+	// MaxFileSize is the size in bytes of the parts of a file from which a new file is started.
+	MaxFileSize int64
+	// MaxFileAge is the time after which a new file is started.
+	MaxFileAge time.Duration
+}
+
+func (r FileRotation) enabled() bool {
+	return r.MaxFileSize > 0 || r.MaxFileAge > 0
+}
+
+// FileStore writes the marshalled log batches to a local directory, either given by a
+// file:///path URL or by a pvc://claim/path URL, in which case the claim is expected to be
+// mounted at constants.LoggerPvcMountPath. The files are laid out like the blob store objects.
+//
+// The files are always written to a temporary file first and renamed, so that the readers
+// never see a partial file. With rotation, every batch is written to a part file of the
+// current file, and its records are appended to a hidden segment file next to it. Once the
+// current file reaches its size or age limit, the records of the segment are marshalled into
+// the current file and its parts are removed. The parts left behind by a restart are kept.
+type FileStore struct {
+	storePath  string
+	marshaller Marshaller
+	rotation   FileRotation
+	log        *zap.SugaredLogger
+	now        func() time.Time
+
+	mu sync.Mutex
+	// the file being filled, its directory, its creation time, and the number and size of its parts
+	activePath  string
+	activeRoot  string
+	activeSince time.Time
+	activeParts int
+	activeSize  int64
+}
+
+var _ Store = &FileStore{}
+
+func NewFileStore(logStorePath string, marshaller Marshaller, rotation FileRotation, log *zap.SugaredLogger) *FileStore {
+	return &FileStore{
+		storePath:  logStorePath,
+		marshaller: marshaller,
+		rotation:   rotation,
+		log:        log,
+		now:        time.Now,
+	}
+}
+
+func (s *FileStore) Store(logUrl *url.URL, batch []LogRequest) error {
+	if logUrl == nil {
+		return errors.New("log url is invalid")
+	}
+	if len(batch) == 0 {
+		return errors.New("empty batch")
+	}
+	root, err := fileStoreRoot(logUrl)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.activePath != "" && s.rotation.MaxFileAge > 0 && s.now().Sub(s.activeSince) >= s.rotation.MaxFileAge {
+		s.rotate()
+	}
+	if s.activePath != "" && s.activeRoot != root {
+		s.rotate()
+	}
+
+	response, err := s.marshaller.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log batch: %w", err)
+	}
+
+	filePath := s.activePath
+	if filePath == "" {
+		// the file is named after the first record it holds
+		key, err := getObjectKey(s.storePath, "", &batch[0], response.Extension)
+		if err != nil {
+			return err
+		}
+		filePath = filepath.Join(root, filepath.FromSlash(key))
+		if !strings.HasPrefix(filePath, root+string(filepath.Separator)) {
+			return fmt.Errorf("log file %s is outside of the log directory %s", filePath, root)
+		}
+	}
+	if !s.rotation.enabled() {
+		if err := writeFileAtomic(filePath, response.Data); err != nil {
+			return fmt.Errorf("failed to write log file: %w", err)
+		}
+		s.log.Infof("Successfully wrote %d log records to %s", len(batch), filePath)
+		return nil
+	}
+
+	partPath := filePartPath(filePath, s.activeParts+1)
+	if err := writeFileAtomic(partPath, response.Data); err != nil {
+		return fmt.Errorf("failed to write log file: %w", err)
+	}
+	if err := appendSegment(fileSegmentPath(filePath), batch); err != nil {
+		_ = os.Remove(partPath)
+		return fmt.Errorf("failed to write log file: %w", err)
+	}
+	s.log.Infof("Successfully wrote %d log records to %s", len(batch), partPath)
+
+	if s.activePath == "" {
+		s.activePath = filePath
+		s.activeRoot = root
+		s.activeSince = s.now()
+	}
+	s.activeParts++
+	s.activeSize += int64(len(response.Data))
+	if s.rotation.MaxFileSize > 0 && s.activeSize >= s.rotation.MaxFileSize {
+		s.rotate()
+	}
+	return nil
+}
+
+// rotate rolls the parts of the current file up into it, so that the next batch starts a new
+// one. The batches are already stored in the parts, so a failed roll up leaves them in place.
+// The caller must hold the lock.
+func (s *FileStore) rotate() {
+	if err := s.rollUp(); err != nil {
+		s.log.Errorf("Failed to roll up the parts of log file %s, keeping them: %v", s.activePath, err)
+	}
+	s.activePath = ""
+	s.activeRoot = ""
+	s.activeParts = 0
+	s.activeSize = 0
+}
+
+func (s *FileStore) rollUp() error {
+	segmentPath := fileSegmentPath(s.activePath)
+	if s.activeParts == 1 {
+		// a single part already holds every record of the file
+		if err := os.Rename(filePartPath(s.activePath, 1), s.activePath); err != nil {
+			return err
+		}
+		return os.Remove(segmentPath)
+	}
+
+	records, err := readSegment(segmentPath)
+	if err != nil {
+		return err
+	}
+	response, err := s.marshaller.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log batch: %w", err)
+	}
+	// the file is written before its parts are removed, so that no record is ever missing
+	if err := writeFileAtomic(s.activePath, response.Data); err != nil {
+		return err
+	}
+	for part := 1; part <= s.activeParts; part++ {
+		if err := os.Remove(filePartPath(s.activePath, part)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	s.log.Infof("Successfully rolled up %d log records to %s", len(records), s.activePath)
+	return os.Remove(segmentPath)
+}
+
+// filePartPath returns the path of a part of a log file, as in 0-request.part-1.json.
+func filePartPath(filePath string, part int) string {
+	ext := filepath.Ext(filePath)
+	return fmt.Sprintf("%s.part-%d%s", strings.TrimSuffix(filePath, ext), part, ext)
+}
+
+// fileSegmentPath returns the path of the hidden file holding the records of a log file until it is rolled up.
+func fileSegmentPath(filePath string) string {
+	return filepath.Join(filepath.Dir(filePath), "."+filepath.Base(filePath)+".segment")
+}
+
+// appendSegment appends the records to the segment as JSON lines. The segment is truncated
+// back to its previous size when the records cannot be written.
+func appendSegment(path string, records []LogRequest) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for i := range records {
+		if err = encoder.Encode(&records[i]); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		_ = file.Truncate(info.Size())
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func readSegment(path string) ([]LogRequest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	records := []LogRequest{}
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for {
+		var record LogRequest
+		if err := decoder.Decode(&record); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read log segment %s: %w", path, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// fileStoreRoot returns the local directory of a file:// or pvc:// log URL.
+func fileStoreRoot(logUrl *url.URL) (string, error) {
+	switch GetStorageStrategy(logUrl.String()) {
+	case FileStorage:
+		if logUrl.Host != "" && logUrl.Host != "localhost" {
+			return "", fmt.Errorf("file url %s must have an absolute path, as in file:///var/log/kserve", logUrl)
+		}
+		if logUrl.Path == "" {
+			return "", errors.New("no path specified in file url")
+		}
+		return filepath.Clean(logUrl.Path), nil
+	case PVCStorage:
+		if logUrl.Host == "" {
+			return "", errors.New("no claim specified in pvc url")
+		}
+		root := filepath.Join(constants.LoggerPvcMountPath, logUrl.Path)
+		if relPath, err := filepath.Rel(constants.LoggerPvcMountPath, root); err != nil || strings.HasPrefix(relPath, "..") {
+			return "", fmt.Errorf("path traversal detected in pvc url %s", logUrl)
+		}
+		return root, nil
+	default:
+		return "", fmt.Errorf("unsupported file store url %s", logUrl)
+	}
+}
+
+// writeFileAtomic writes the data to a temporary file of the target directory and renames
+// it, so that the file is either fully written or left unchanged.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func mockFileStore(t *testing.T, rotation FileRotation) (*FileStore, *url.URL, string) {
+	server := httptest.NewServer(NewJSONMarshallerHandler())
+	t.Cleanup(server.Close)
+	log, _ := pkglogging.NewLogger("", "INFO")
+	store := NewFileStore("logger", NewHTTPMarshaller(server.URL+"/marshal", &http.Client{}), rotation, log)
+
+	dir := t.TempDir()
+	logUrl, err := url.Parse("file://" + dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, logUrl, dir
+}
+
+func fileRequest(id string) LogRequest {
+	data := []byte(`{"instances":[[1,2,3]]}`)
+	return LogRequest{
+		Bytes:            &data,
+		ContentType:      "application/json",
+		ReqType:          CEInferenceRequest,
+		Id:               id,
+		Namespace:        "ns",
+		InferenceService: "inference",
+		Component:        "predictor",
+	}
+}
+
+// readLogFiles returns the number of records of every file of the log directory,
+// but of the segments of the files being filled.
+func readLogFiles(t *testing.T, dir string) map[string]int {
+	files := map[string]int{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.HasSuffix(path, ".segment") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		var records []LogRequest
+		if json.Unmarshal(data, &records) == nil {
+			files[rel] = len(records)
+			return nil
+		}
+		var record LogRequest
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		files[rel] = 1
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestFileStoreWritesEveryBatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	store, logUrl, dir := mockFileStore(t, FileRotation{})
+
+	g.Expect(store.Store(logUrl, []LogRequest{fileRequest("0")})).To(gomega.Succeed())
+	g.Expect(store.Store(logUrl, []LogRequest{fileRequest("1"), fileRequest("2")})).To(gomega.Succeed())
+
+	// no temporary file is left behind
+	g.Expect(readLogFiles(t, dir)).To(gomega.Equal(map[string]int{
+		"ns/inference/predictor/logger/0-request.json": 1,
+		"ns/inference/predictor/logger/1-request.json": 2,
+	}))
+}
+
+func TestFileStoreSizeRotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	data, err := json.Marshal(fileRequest("0"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	// the file is rotated once it holds three records
+	store, logUrl, dir := mockFileStore(t, FileRotation{MaxFileSize: int64(3 * len(data))})
+
+	for _, id := range []string{"0", "1"} {
+		g.Expect(store.Store(logUrl, []LogRequest{fileRequest(id)})).To(gomega.Succeed())
+	}
+	// the batches are readable before the file is rotated
+	g.Expect(readLogFiles(t, dir)).To(gomega.Equal(map[string]int{
+		"ns/inference/predictor/logger/0-request.part-1.json": 1,
+		"ns/inference/predictor/logger/0-request.part-2.json": 1,
+	}))
+	g.Expect(filepath.Join(dir, "ns/inference/predictor/logger/.0-request.json.segment")).To(gomega.BeARegularFile())
+
+	for _, id := range []string{"2", "3"} {
+		g.Expect(store.Store(logUrl, []LogRequest{fileRequest(id)})).To(gomega.Succeed())
+	}
+	g.Expect(readLogFiles(t, dir)).To(gomega.Equal(map[string]int{
+		"ns/inference/predictor/logger/0-request.json":        3,
+		"ns/inference/predictor/logger/3-request.part-1.json": 1,
+	}))
+	g.Expect(filepath.Join(dir, "ns/inference/predictor/logger/.0-request.json.segment")).ToNot(gomega.BeAnExistingFile())
+}
+
+func TestFileStoreAgeRotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	store, logUrl, dir := mockFileStore(t, FileRotation{MaxFileAge: time.Minute})
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	g.Expect(store.Store(logUrl, []LogRequest{fileRequest("0")})).To(gomega.Succeed())
+	now = now.Add(30 * time.Second)
+	g.Expect(store.Store(logUrl, []LogRequest{fileRequest("1")})).To(gomega.Succeed())
+	now = now.Add(30 * time.Second)
+	g.Expect(store.Store(logUrl, []LogRequest{fileRequest("2")})).To(gomega.Succeed())
+	now = now.Add(time.Minute)
+	g.Expect(store.Store(logUrl, []LogRequest{fileRequest("3"), fileRequest("4")})).To(gomega.Succeed())
+
+	// a file of a single part is renamed
+	g.Expect(readLogFiles(t, dir)).To(gomega.Equal(map[string]int{
+		"ns/inference/predictor/logger/0-request.json":        2,
+		"ns/inference/predictor/logger/2-request.json":        1,
+		"ns/inference/predictor/logger/3-request.part-1.json": 2,
+	}))
+}
+
+func TestFileStoreErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	store, logUrl, _ := mockFileStore(t, FileRotation{})
+
+	g.Expect(store.Store(nil, []LogRequest{fileRequest("0")})).To(gomega.HaveOccurred())
+	g.Expect(store.Store(logUrl, nil)).To(gomega.HaveOccurred())
+
+	relative, _ := url.Parse("file://logs/dir")
+	g.Expect(store.Store(relative, []LogRequest{fileRequest("0")})).To(gomega.MatchError(gomega.ContainSubstring("absolute path")))
+	noClaim, _ := url.Parse("pvc:///logs")
+	g.Expect(store.Store(noClaim, []LogRequest{fileRequest("0")})).To(gomega.MatchError(gomega.ContainSubstring("claim")))
+
+	request := fileRequest("../../../../../escape")
+	g.Expect(store.Store(logUrl, []LogRequest{request})).To(gomega.MatchError(gomega.ContainSubstring("outside")))
+}
+
+func TestFileStoreRoot(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pvcUrl, _ := url.Parse("pvc://logs-claim/inference/logs")
+	g.Expect(fileStoreRoot(pvcUrl)).To(gomega.Equal("/mnt/logs/inference/logs"))
+	fileUrl, _ := url.Parse("file:///var/log/kserve/")
+	g.Expect(fileStoreRoot(fileUrl)).To(gomega.Equal("/var/log/kserve"))
+	traversal, _ := url.Parse("pvc://logs-claim/../../etc")
+	_, err := fileStoreRoot(traversal)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("path traversal")))
+	g.Expect(GetStorageStrategy(pvcUrl.String())).To(gomega.Equal(PVCStorage))
+	g.Expect(GetStorageStrategy(fileUrl.String())).To(gomega.Equal(FileStorage))
+}
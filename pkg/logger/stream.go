@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"sort"
+	"strings"
+)
+
+const (
+	EventStreamContentType = "text/event-stream"
+	sseDataField           = "data:"
+	sseDoneMessage         = "[DONE]"
+)
+
+// isEventStream reports whether the content type is a server-sent events stream.
+func isEventStream(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == EventStreamContentType
+}
+
+type streamUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type streamMessage struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content"`
+}
+
+type streamChoice struct {
+	Index        int            `json:"index"`
+	Message      *streamMessage `json:"message,omitempty"`
+	Text         *string        `json:"text,omitempty"`
+	FinishReason string         `json:"finish_reason,omitempty"`
+}
+
+// streamCompletion is the completion reassembled from the chunks of an OpenAI compatible stream.
+type streamCompletion struct {
+	Id      string         `json:"id,omitempty"`
+	Object  string         `json:"object,omitempty"`
+	Created int64          `json:"created,omitempty"`
+	Model   string         `json:"model,omitempty"`
+	Choices []streamChoice `json:"choices"`
+	Usage   *streamUsage   `json:"usage,omitempty"`
+}
+
+type streamChunk struct {
+	Id      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	Model   string `json:"model"`
+	Choices []struct {
+		Index int `json:"index"`
+		Delta *struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"delta"`
+		Text         *string `json:"text"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *streamUsage `json:"usage"`
+}
+
+// sseAssembler reassembles a server-sent events response as it is written, so that an
+// OpenAI compatible streaming completion is logged as one completion record instead of
+// the raw data frames. Only the completion text is kept in memory, up to maxBodySize bytes.
+type sseAssembler struct {
+	maxBodySize int
+	// line holds the partial line of the last write.
+	line []byte
+	// event holds the data lines of the event being read.
+	event      []string
+	completion streamCompletion
+	choices    map[int]*streamChoice
+	// contentChunks counts the chunks carrying content, used as the completion token
+	// count when the server does not report the usage.
+	contentChunks int
+	// raw holds the data of the events which are not completion chunks.
+	raw       bytes.Buffer
+	size      int
+	truncated bool
+}
+
+func newSSEAssembler(maxBodySize int) *sseAssembler {
+	return &sseAssembler{
+		maxBodySize: maxBodySize,
+		choices:     map[int]*streamChoice{},
+	}
+}
+
+func (s *sseAssembler) Write(b []byte) (int, error) {
+	s.line = append(s.line, b...)
+	for {
+		i := bytes.IndexByte(s.line, '\n')
+		if i < 0 {
+			break
+		}
+		s.readLine(strings.TrimSuffix(string(s.line[:i]), "\r"))
+		s.line = s.line[i+1:]
+	}
+	return len(b), nil
+}
+
+func (s *sseAssembler) readLine(line string) {
+	if line == "" {
+		s.dispatch()
+		return
+	}
+	if data, ok := strings.CutPrefix(line, sseDataField); ok {
+		s.event = append(s.event, strings.TrimPrefix(data, " "))
+	}
+}
+
+// dispatch merges the event which has been read into the completion.
+func (s *sseAssembler) dispatch() {
+	if len(s.event) == 0 {
+		return
+	}
+	data := strings.Join(s.event, "\n")
+	s.event = nil
+	if data == sseDoneMessage {
+		return
+	}
+	var chunk streamChunk
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil || chunk.Choices == nil && chunk.Usage == nil {
+		if s.raw.Len() > 0 {
+			s.raw.WriteByte('\n')
+		}
+		s.raw.WriteString(s.capture(data))
+		return
+	}
+	if chunk.Id != "" {
+		s.completion.Id = chunk.Id
+	}
+	if chunk.Model != "" {
+		s.completion.Model = chunk.Model
+	}
+	if chunk.Created != 0 {
+		s.completion.Created = chunk.Created
+	}
+	if chunk.Object != "" {
+		s.completion.Object = strings.TrimSuffix(chunk.Object, ".chunk")
+	}
+	if chunk.Usage != nil {
+		s.completion.Usage = chunk.Usage
+	}
+	for _, c := range chunk.Choices {
+		choice, ok := s.choices[c.Index]
+		if !ok {
+			choice = &streamChoice{Index: c.Index}
+			s.choices[c.Index] = choice
+		}
+		if c.Delta != nil {
+			if choice.Message == nil {
+				choice.Message = &streamMessage{}
+			}
+			if c.Delta.Role != "" {
+				choice.Message.Role = c.Delta.Role
+			}
+			if c.Delta.Content != "" {
+				choice.Message.Content += s.capture(c.Delta.Content)
+				s.contentChunks++
+			}
+		}
+		if c.Text != nil {
+			if choice.Text == nil {
+				choice.Text = new(string)
+			}
+			if *c.Text != "" {
+				*choice.Text += s.capture(*c.Text)
+				s.contentChunks++
+			}
+		}
+		if c.FinishReason != nil {
+			choice.FinishReason = *c.FinishReason
+		}
+	}
+}
+
+// capture returns the part of the content which fits in the max body size.
+func (s *sseAssembler) capture(content string) string {
+	if s.maxBodySize <= 0 {
+		return content
+	}
+	remaining := s.maxBodySize - s.size
+	if len(content) > remaining {
+		content = content[:max(remaining, 0)]
+		s.truncated = true
+	}
+	s.size += len(content)
+	return content
+}
+
+// record returns the reassembled completion as JSON, or the data of the events when the
+// stream did not contain any completion chunk, and whether the content was truncated.
+func (s *sseAssembler) record() ([]byte, bool) {
+	if len(s.line) > 0 {
+		s.readLine(strings.TrimSuffix(string(s.line), "\r"))
+		s.line = nil
+	}
+	s.dispatch()
+	if len(s.choices) == 0 && s.completion.Usage == nil {
+		return s.raw.Bytes(), s.truncated
+	}
+	s.completion.Choices = make([]streamChoice, 0, len(s.choices))
+	for _, choice := range s.choices {
+		s.completion.Choices = append(s.completion.Choices, *choice)
+	}
+	sort.Slice(s.completion.Choices, func(i, j int) bool {
+		return s.completion.Choices[i].Index < s.completion.Choices[j].Index
+	})
+	if s.completion.Usage == nil {
+		s.completion.Usage = &streamUsage{
+			CompletionTokens: s.contentChunks,
+			TotalTokens:      s.contentChunks,
+		}
+	}
+	body, err := json.Marshal(s.completion)
+	if err != nil {
+		return s.raw.Bytes(), s.truncated
+	}
+	return body, s.truncated
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+const chatStream = "data: {\"id\":\"cmpl-1\",\"object\":\"chat.completion.chunk\",\"created\":1700000000,\"model\":\"llama\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"\"}}]}\n\n" +
+	"data: {\"id\":\"cmpl-1\",\"object\":\"chat.completion.chunk\",\"created\":1700000000,\"model\":\"llama\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hello\"}}]}\n\n" +
+	"data: {\"id\":\"cmpl-1\",\"object\":\"chat.completion.chunk\",\"created\":1700000000,\"model\":\"llama\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\" world\"},\"finish_reason\":\"stop\"}]}\n\n"
+
+func TestSSEAssembler(t *testing.T) {
+	scenarios := map[string]struct {
+		writes      []string
+		maxBodySize int
+		expected    string
+		truncated   bool
+	}{
+		"ChatCompletion": {
+			writes:   []string{chatStream, "data: [DONE]\n\n"},
+			expected: `{"id":"cmpl-1","object":"chat.completion","created":1700000000,"model":"llama","choices":[{"index":0,"message":{"role":"assistant","content":"Hello world"},"finish_reason":"stop"}],"usage":{"prompt_tokens":0,"completion_tokens":2,"total_tokens":2}}`,
+		},
+		"ReportedUsage": {
+			writes:   []string{chatStream, "data: {\"id\":\"cmpl-1\",\"object\":\"chat.completion.chunk\",\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":3,\"total_tokens\":8}}\n\n", "data: [DONE]\n\n"},
+			expected: `{"id":"cmpl-1","object":"chat.completion","created":1700000000,"model":"llama","choices":[{"index":0,"message":{"role":"assistant","content":"Hello world"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":3,"total_tokens":8}}`,
+		},
+		"SplitFrames": {
+			writes:   []string{chatStream[:40], chatStream[40:170], chatStream[170:]},
+			expected: `{"id":"cmpl-1","object":"chat.completion","created":1700000000,"model":"llama","choices":[{"index":0,"message":{"role":"assistant","content":"Hello world"},"finish_reason":"stop"}],"usage":{"prompt_tokens":0,"completion_tokens":2,"total_tokens":2}}`,
+		},
+		"TextCompletion": {
+			writes: []string{
+				"data: {\"id\":\"cmpl-2\",\"object\":\"text_completion\",\"model\":\"gpt2\",\"choices\":[{\"index\":0,\"text\":\"foo\"},{\"index\":1,\"text\":\"bar\"}]}\r\n\r\n",
+				"data: {\"id\":\"cmpl-2\",\"object\":\"text_completion\",\"model\":\"gpt2\",\"choices\":[{\"index\":1,\"text\":\"baz\",\"finish_reason\":\"length\"}]}\r\n\r\n",
+			},
+			expected: `{"id":"cmpl-2","object":"text_completion","model":"gpt2","choices":[{"index":0,"text":"foo"},{"index":1,"text":"barbaz","finish_reason":"length"}],"usage":{"prompt_tokens":0,"completion_tokens":3,"total_tokens":3}}`,
+		},
+		"Truncated": {
+			writes:      []string{chatStream},
+			maxBodySize: 7,
+			expected:    `{"id":"cmpl-1","object":"chat.completion","created":1700000000,"model":"llama","choices":[{"index":0,"message":{"role":"assistant","content":"Hello w"},"finish_reason":"stop"}],"usage":{"prompt_tokens":0,"completion_tokens":2,"total_tokens":2}}`,
+			truncated:   true,
+		},
+		"RawEvents": {
+			writes:   []string{"event: message\ndata: first\n\n: comment\ndata: second\ndata: line\n\n"},
+			expected: "first\nsecond\nline",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			assembler := newSSEAssembler(scenario.maxBodySize)
+			for _, write := range scenario.writes {
+				n, err := assembler.Write([]byte(write))
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(n).To(gomega.Equal(len(write)))
+			}
+			record, truncated := assembler.record()
+			if json.Valid([]byte(scenario.expected)) {
+				g.Expect(record).To(gomega.MatchJSON(scenario.expected))
+			} else {
+				g.Expect(string(record)).To(gomega.Equal(scenario.expected))
+			}
+			g.Expect(truncated).To(gomega.Equal(scenario.truncated))
+		})
+	}
+}
+
+func TestIsEventStream(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(isEventStream("text/event-stream")).To(gomega.BeTrue())
+	g.Expect(isEventStream("text/event-stream; charset=utf-8")).To(gomega.BeTrue())
+	g.Expect(isEventStream("application/json")).To(gomega.BeFalse())
+	g.Expect(isEventStream("")).To(gomega.BeFalse())
+}
@@ -37,4 +37,10 @@ type LogRequest struct {
 	CertName         string              `json:"certName,omitempty"`
 	TlsSkipVerify    bool                `json:"tlsSkipVerify,omitempty"`
 	OccurrenceTime   time.Time           `json:"occurrenceTime"`
+	// Truncated is set when the body exceeded the max captured body size
+	Truncated bool `json:"truncated,omitempty"`
+	// StatusCode, LatencyMs and ErrorClass describe the outcome of the call in response records
+	StatusCode int     `json:"statusCode,omitempty"`
+	LatencyMs  float64 `json:"latencyMs,omitempty"`
+	ErrorClass string  `json:"errorClass,omitempty"`
 }
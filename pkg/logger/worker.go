@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -49,6 +50,10 @@ const (
 	EndpointAttr     = "endpoint"
 	AnnotationAttr   = "annotations"
 	RecordedTimeAttr = "recordedtime"
+	TruncatedAttr    = "truncated"
+	StatusCodeAttr   = "statuscode"
+	LatencyAttr      = "latencyms"
+	ErrorClassAttr   = "errorclass"
 
 	LoggerWorkerQueueSize = 100
 	CloudEventsIdHeader   = "Ce-Id"
@@ -82,6 +87,10 @@ type Worker struct {
 	Work        chan LogRequest
 	WorkerQueue chan chan LogRequest
 	QuitChan    chan bool
+	// Spool persists the events which fail to be delivered, when set
+	Spool *Spool
+	// Delivery is the retry and dead letter policy of the events, a single attempt is made when nil
+	Delivery *Delivery
 }
 
 func (w *Worker) sendHttpCloudEvent(logReq LogRequest) error {
@@ -119,6 +128,33 @@ func (w *Worker) sendHttpCloudEvent(logReq LogRequest) error {
 	if err != nil {
 		return fmt.Errorf("while creating new cloudevents client: %w", err)
 	}
+	event, err := newCloudEvent(logReq, w.Log)
+	if err != nil {
+		return err
+	}
+	ceCtx := cloudevents.WithEncodingBinary(context.Background())
+	res := c.Send(ceCtx, event)
+	if cloudevents.IsUndelivered(res) {
+		return fmt.Errorf("while sending event: %w", res)
+	} else {
+		var httpResult *cehttp.Result
+		if cloudevents.ResultAs(res, &httpResult) {
+			if httpResult.StatusCode < http.StatusOK || httpResult.StatusCode >= http.StatusMultipleChoices {
+				return &StatusError{
+					StatusCode: httpResult.StatusCode,
+					Message:    fmt.Sprintf(httpResult.Format, httpResult.Args...),
+				}
+			}
+			w.Log.Infof("Sent with status code %d", httpResult.StatusCode)
+		} else {
+			w.Log.Infof("Send did not return an HTTP response: %s", res)
+		}
+	}
+	return nil
+}
+
+// newCloudEvent creates the CloudEvent carrying a log request.
+func newCloudEvent(logReq LogRequest, log *zap.SugaredLogger) (cloudevents.Event, error) {
 	event := cloudevents.NewEvent(cloudevents.VersionV1)
 	event.SetID(logReq.Id)
 	event.SetType(logReq.ReqType)
@@ -132,40 +168,36 @@ func (w *Worker) sendHttpCloudEvent(logReq LogRequest) error {
 
 	encodedMetadata, err := json.Marshal(logReq.Metadata)
 	if err != nil {
-		return fmt.Errorf("could not encode metadata as json: %w", err)
+		return event, fmt.Errorf("could not encode metadata as json: %w", err)
 	}
 	event.SetExtension(MetadataAttr, string(encodedMetadata))
 
 	if len(logReq.Annotations) > 0 {
 		bits, err := json.Marshal(logReq.Annotations)
 		if err != nil {
-			w.Log.Errorf("failed to marshal annotations: %w", err)
+			log.Errorf("failed to marshal annotations: %w", err)
 		} else {
 			event.SetExtension(AnnotationAttr, string(bits))
 		}
 	}
 
+	if logReq.Truncated {
+		event.SetExtension(TruncatedAttr, true)
+	}
+	if logReq.StatusCode != 0 {
+		event.SetExtension(StatusCodeAttr, logReq.StatusCode)
+		// cloud events extension attributes do not support floating point numbers
+		event.SetExtension(LatencyAttr, strconv.FormatFloat(logReq.LatencyMs, 'f', -1, 64))
+	}
+	if logReq.ErrorClass != "" {
+		event.SetExtension(ErrorClassAttr, logReq.ErrorClass)
+	}
+
 	event.SetSource(logReq.SourceUri.String())
 	if err := event.SetData(logReq.ContentType, *logReq.Bytes); err != nil {
-		return fmt.Errorf("while setting cloudevents data: %w", err)
-	}
-	ceCtx := cloudevents.WithEncodingBinary(context.Background())
-	res := c.Send(ceCtx, event)
-	if cloudevents.IsUndelivered(res) {
-		return fmt.Errorf("while sending event: %w", res)
-	} else {
-		var httpResult *cehttp.Result
-		if cloudevents.ResultAs(res, &httpResult) {
-			var err error
-			if httpResult.StatusCode != http.StatusOK {
-				err = fmt.Errorf(httpResult.Format, httpResult.Args...)
-			}
-			w.Log.Infof("Sent with status code %d, error: %v", httpResult.StatusCode, err)
-		} else {
-			w.Log.Infof("Send did not return an HTTP response: %s", res)
-		}
+		return event, fmt.Errorf("while setting cloudevents data: %w", err)
 	}
-	return nil
+	return event, nil
 }
 
 // Start begins the worker goroutine. Workers handle HTTP CloudEvents delivery.
@@ -180,9 +212,7 @@ func (w *Worker) Start() {
 			case work := <-w.Work:
 				w.Log.Infof("Received work request %d, url: %s, requestId: %s", w.ID, work.Url.String(), work.Id)
 
-				if err := w.sendHttpCloudEvent(work); err != nil {
-					w.Log.Error(err, "Failed to send cloud event, url: %s", work.Url.String())
-				}
+				w.deliver(work, 1)
 
 			case <-w.QuitChan:
 				w.Log.Infof("worker %d stopping\n", w.ID)
@@ -192,6 +222,81 @@ func (w *Worker) Start() {
 	}()
 }
 
+// deliver sends an event, and schedules its retry when the delivery fails. The retries wait in
+// timers, so that the worker can take the next events meanwhile. The events which could not be
+// delivered after all the attempts, or while too many retries are in flight, are sent to the dead
+// letter URL, or spooled.
+func (w *Worker) deliver(logReq LogRequest, attempt int) {
+	err := w.send(logReq)
+	if err == nil {
+		cloudEventsDelivered.Inc()
+		return
+	}
+	if attempt < w.Delivery.maxAttempts() && isRetriable(err) {
+		if w.Delivery.acquireRetry() {
+			backoff := w.Delivery.Retry.Backoff(attempt)
+			cloudEventsRetried.Inc()
+			w.Log.Warnf("Failed to send cloud event, url: %s, attempt %d, retrying in %s: %v", logReq.Url, attempt, backoff, err)
+			time.AfterFunc(backoff, func() {
+				w.Delivery.releaseRetry()
+				w.deliver(logReq, attempt+1)
+			})
+			return
+		}
+		w.Log.Warnf("Failed to send cloud event, url: %s, attempt %d, too many retries in flight: %v", logReq.Url, attempt, err)
+	}
+	w.Log.Errorf("Failed to send cloud event, url: %s, after %d attempts: %v", logReq.Url, attempt, err)
+	if w.deadLetter(logReq) {
+		return
+	}
+	if isRetriable(err) {
+		spoolBatch(w.Spool, []LogRequest{logReq}, w.Log)
+	}
+}
+
+// send sends an event, unless the circuit of its destination is open.
+func (w *Worker) send(logReq LogRequest) error {
+	if w.Delivery == nil || w.Delivery.CircuitBreaker == nil {
+		return w.sendHttpCloudEvent(logReq)
+	}
+	breaker := w.Delivery.CircuitBreaker
+	destination := logReq.Url.Host
+	if !breaker.Allow(destination) {
+		return ErrCircuitOpen
+	}
+	err := w.sendHttpCloudEvent(logReq)
+	// the events rejected by the destination show that it is available
+	if breaker.Record(destination, err == nil || !isRetriable(err)) {
+		w.Log.Warnf("Opened the circuit of the logger destination %s: %v", destination, err)
+	}
+	return err
+}
+
+// deadLetter sends an event which could not be delivered to the dead letter URL, and returns
+// whether it succeeded.
+func (w *Worker) deadLetter(logReq LogRequest) bool {
+	if w.Delivery == nil || w.Delivery.DeadLetterUrl == nil {
+		return false
+	}
+	deadLetterUrl := w.Delivery.DeadLetterUrl
+	logReq.Url = deadLetterUrl
+	var err error
+	switch {
+	case GetStorageStrategy(deadLetterUrl.String()) == HttpStorage:
+		err = w.sendHttpCloudEvent(logReq)
+	case w.Delivery.DeadLetterStore == nil:
+		err = errors.New("dead letter store not configured")
+	default:
+		err = w.Delivery.DeadLetterStore.Store(deadLetterUrl, []LogRequest{logReq})
+	}
+	if err != nil {
+		w.Log.Errorf("Failed to send cloud event to the dead letter url %s: %v", deadLetterUrl, err)
+		return false
+	}
+	cloudEventsDeadLettered.Inc()
+	return true
+}
+
 // Stop tells the worker to stop listening for work requests.
 //
 // Note that the worker will only stop *after* it has finished its work.
@@ -264,8 +264,8 @@ func TestCreateEmptyModelConfig(t *testing.T) {
 			},
 		},
 	}
-	shardStrategy := memory.MemoryStrategy{}
-	shardId := shardStrategy.GetShard(isvc)[0]
+	shardStrategy := memory.NewMemoryStrategy(isvc, nil)
+	shardId := shardStrategy.GetShard()[0]
 	expected := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      constants.ModelConfigName(isvc.Name, shardId),
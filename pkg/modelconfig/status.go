@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelconfig
+
+import "time"
+
+// ModelState is the state of a model in the model server of a predictor replica
+type ModelState string
+
+const (
+	// ModelLoading is the state of a model being downloaded or loaded
+	ModelLoading ModelState = "Loading"
+	// ModelLoaded is the state of a model loaded by the model server
+	ModelLoaded ModelState = "Loaded"
+	// ModelFailed is the state of a model which failed to be downloaded or loaded
+	ModelFailed ModelState = "Failed"
+)
+
+// ModelStatus is the state of a model reported by the model agent of a predictor replica
+type ModelStatus struct {
+	Name  string     `json:"name"`
+	State ModelState `json:"state"`
+	// Error is the reason why the model failed to be downloaded or loaded, or why the last attempt
+	// failed while the model is being retried
+	Error string `json:"error,omitempty"`
+	// Attempts is the number of attempts made to download and load the model
+	Attempts int `json:"attempts,omitempty"`
+	// LastTransitionTime is when the model entered its state
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// ModelStatuses is the response of the model status endpoint of the model agent
+type ModelStatuses struct {
+	Models []ModelStatus `json:"models"`
+}
+
+// Get returns the status of a model, or nil when the model is not reported.
+func (s ModelStatuses) Get(name string) *ModelStatus {
+	for i := range s.Models {
+		if s.Models[i].Name == name {
+			return &s.Models[i]
+		}
+	}
+	return nil
+}
@@ -40,6 +40,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphStatus":          schema_pkg_apis_serving_v1alpha1_InferenceGraphStatus(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter":               schema_pkg_apis_serving_v1alpha1_InferenceRouter(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep":                 schema_pkg_apis_serving_v1alpha1_InferenceStep(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCache":            schema_pkg_apis_serving_v1alpha1_InferenceStepCache(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepRetry":            schema_pkg_apis_serving_v1alpha1_InferenceStepRetry(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceTarget":               schema_pkg_apis_serving_v1alpha1_InferenceTarget(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.LLMInferenceService":           schema_pkg_apis_serving_v1alpha1_LLMInferenceService(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.LLMInferenceServiceConfig":     schema_pkg_apis_serving_v1alpha1_LLMInferenceServiceConfig(ref),
@@ -64,6 +66,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimePodSpec":         schema_pkg_apis_serving_v1alpha1_ServingRuntimePodSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeSpec":            schema_pkg_apis_serving_v1alpha1_ServingRuntimeSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeStatus":          schema_pkg_apis_serving_v1alpha1_ServingRuntimeStatus(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SplitterStickiness":            schema_pkg_apis_serving_v1alpha1_SplitterStickiness(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageContainerSpec":          schema_pkg_apis_serving_v1alpha1_StorageContainerSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageHelper":                 schema_pkg_apis_serving_v1alpha1_StorageHelper(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SupportedModelFormat":          schema_pkg_apis_serving_v1alpha1_SupportedModelFormat(ref),
@@ -109,10 +112,14 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.IngressConfig":                  schema_pkg_apis_serving_v1beta1_IngressConfig(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LightGBMSpec":                   schema_pkg_apis_serving_v1beta1_LightGBMSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LocalModelConfig":               schema_pkg_apis_serving_v1beta1_LocalModelConfig(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerCircuitBreakerSpec":       schema_pkg_apis_serving_v1beta1_LoggerCircuitBreakerSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerRedactionSpec":            schema_pkg_apis_serving_v1beta1_LoggerRedactionSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerRetrySpec":                schema_pkg_apis_serving_v1beta1_LoggerRetrySpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec":                     schema_pkg_apis_serving_v1beta1_LoggerSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerStorageSpec":              schema_pkg_apis_serving_v1beta1_LoggerStorageSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.MetricTarget":                   schema_pkg_apis_serving_v1beta1_MetricTarget(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.MetricsSpec":                    schema_pkg_apis_serving_v1beta1_MetricsSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelBatchConfig":               schema_pkg_apis_serving_v1beta1_ModelBatchConfig(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelCopies":                    schema_pkg_apis_serving_v1beta1_ModelCopies(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelFormat":                    schema_pkg_apis_serving_v1beta1_ModelFormat(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelRevisionStates":            schema_pkg_apis_serving_v1beta1_ModelRevisionStates(ref),
@@ -652,12 +659,25 @@ func schema_pkg_apis_serving_v1alpha1_InferenceGraphSpec(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"tracing": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tracing enables the OTLP trace spans of the router for every node and step of the graph. When present (even as an empty object `{}`), tracing is enabled with the TracingSpec defaults.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.TracingSpec"),
+						},
+					},
+					"enableMetrics": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnableMetrics exposes the Prometheus metrics of the nodes and steps of the graph on the /metrics path of the router.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"nodes"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InfereceGraphRouterTimeouts", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InfereceGraphRouterTimeouts", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.TracingSpec", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
@@ -761,12 +781,32 @@ func schema_pkg_apis_serving_v1alpha1_InferenceRouter(ref common.ReferenceCallba
 							},
 						},
 					},
+					"aggregation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Aggregation of the step responses of an Ensemble node, defaults to Merge",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"quorum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of steps of an Ensemble node which must succeed. When set, the failures and timeouts of the soft dependency steps are left out of the aggregation as long as the quorum is met. Otherwise, any step error fails the node.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"sticky": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sticky routes the requests of a Splitter node by the hash of a key read from the request instead of randomly, so that the requests with the same key go to the same step for the given weights. The requests without the key are routed randomly.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SplitterStickiness"),
+						},
+					},
 				},
 				Required: []string{"routerType"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SplitterStickiness"},
 	}
 }
 
@@ -805,6 +845,13 @@ func schema_pkg_apis_serving_v1alpha1_InferenceStep(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol used to call the service, either v1, v2 or grpc-v2. The grpc-v2 services are called with the ModelInfer method of the v2 GRPCInferenceService, for the model named in the /v2/models/<name>/infer path of the url. Defaults to the protocol of the predictor of the InferenceService, or to REST for the services given by url.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"data": {
 						SchemaProps: spec.SchemaProps{
 							Description: "request data sent to the next route with input/output from the previous step $request $response.predictions",
@@ -819,16 +866,53 @@ func schema_pkg_apis_serving_v1alpha1_InferenceStep(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"mapOutputsToInputs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If true, maps the 'outputs' tensors of the previous step's response to the 'inputs' tensors of this step's request, for services using the Open Inference Protocol (v2). The binary tensor data of the response is passed through as is.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"tensorMapping": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Renames the tensors mapped by mapOutputsToInputs, from the output name of the previous step to the input name of this step. The other tensors keep their name.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"input": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JSON template of the request sent to the step, used instead of data and mapPredictionsToInstances. Every {{ expression }} placeholder is replaced with the JSON value selected by a gjson path from $request, $response or the output of an earlier step of a Sequence node, e.g. {\"instances\": {{ $request.instances }}, \"labels\": {{ $steps.classifier.predictions }}}",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"output": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JSON template of the step output built from the step response, which is $response in the template, and from the same sources as the input template.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"weight": {
 						SchemaProps: spec.SchemaProps{
-							Description: "the weight for split of the traffic, only used for Split Router when weight is specified all the routing targets should be sum to 100",
+							Description: "the weight for split of the traffic, only used for Split Router when weight is specified all the routing targets should be sum to 100. Also the weight of the step responses in the WeightedMean aggregation of an Ensemble router",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
 					},
 					"condition": {
 						SchemaProps: spec.SchemaProps{
-							Description: "routing based on the condition",
+							Description: "routing based on the condition, a gjson path which matches when it selects a value, or with the cel: prefix, a CEL expression over request.body, request.headers and, in Sequence nodes, the previous step response. The step of a Switch node without a condition is its default step.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -840,6 +924,129 @@ func schema_pkg_apis_serving_v1alpha1_InferenceStep(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"retry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retry policy of the step. The step is attempted once when not set.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepRetry"),
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time limit of each attempt of the step (e.g. \"500ms\", \"5s\"), overriding the routerTimeouts.serviceClient timeout of the graph.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fallback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Node or service called with the step request when the step still fails after its retries, either with an error or with an unsuccessful status code.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceTarget"),
+						},
+					},
+					"cache": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cache of the successful responses of the step, keyed by the step request and the cached headers. The step target is not called for the requests whose response is cached, so a step targeting a node caches the response of the whole node.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCache"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCache", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepRetry", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceTarget"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_InferenceStepCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InferenceStepCache defines how the router caches the responses of an inference step.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ttl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time the responses are cached for (e.g. \"30s\", \"10m\").",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxEntries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max number of responses cached for the step, the least recently used ones are evicted first. Defaults to 1000.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"headers": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Request headers which are part of the cache key, for the steps whose response depends on them. The other headers are ignored.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"ttl"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_InferenceStepRetry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InferenceStepRetry defines how the router retries a failed inference step.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"attempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max number of attempts of the step, including the first one. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"backoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Wait before the first retry (e.g. \"100ms\"), doubled with every retry. Defaults to \"100ms\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"retryableStatusCodes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Response status codes for which the step is retried. The step is always retried when the target cannot be reached. Defaults to 502, 503 and 504.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int32",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -874,6 +1081,13 @@ func schema_pkg_apis_serving_v1alpha1_InferenceTarget(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol used to call the service, either v1, v2 or grpc-v2. The grpc-v2 services are called with the ModelInfer method of the v2 GRPCInferenceService, for the model named in the /v2/models/<name>/infer path of the url. Defaults to the protocol of the predictor of the InferenceService, or to REST for the services given by url.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -2264,6 +2478,33 @@ func schema_pkg_apis_serving_v1alpha1_ServingRuntimeStatus(ref common.ReferenceC
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_SplitterStickiness(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SplitterStickiness defines the key of the requests which a Splitter node routes consistently. Exactly one of header and field must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"header": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Header whose value is the key of the request",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"field": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field is the gjson path (https://github.com/tidwall/gjson/blob/master/SYNTAX.md) of the field of the JSON request whose value is the key of the request",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_StorageContainerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3191,9 +3432,39 @@ func schema_pkg_apis_serving_v1beta1_Batcher(ref common.ReferenceCallback) commo
 							Format:      "int32",
 						},
 					},
+					"targetLatency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the target p99 latency of the model server in milliseconds. When set, the batch size of each model is adjusted between 1 and maxBatchSize to keep the latency under the target.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxQueueDepth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max number of requests waiting for a batch response per model. Requests beyond the limit are rejected with 503 and a Retry-After header.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"modelBatchConfigs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max batch size and the max latency of the models which are batched differently from the other models served by the predictor.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelBatchConfig"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelBatchConfig"},
 	}
 }
 
@@ -7466,6 +7737,117 @@ func schema_pkg_apis_serving_v1beta1_LocalModelConfig(ref common.ReferenceCallba
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_LoggerCircuitBreakerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoggerCircuitBreakerSpec specifies when the logger stops delivering to an unavailable destination",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"failureThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of consecutive failed deliveries to a destination which opens its circuit. Defaults to 5.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"resetTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time during which the deliveries to a destination with an open circuit fail right away, after which a trial delivery is let through (e.g. \"30s\"). Defaults to \"30s\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_LoggerRedactionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoggerRedactionSpec specifies the payload fields and metadata headers to redact before logging",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GJSON paths of the request and response body fields to redact, e.g. \"inputs.#.data\" or \"messages.#.content\". Bodies which are not valid JSON are redacted as a whole when fields are set.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the metadata HTTP headers to redact.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies how the redacted values are replaced. <br /> Valid values are: <br /> - \"mask\" (default): replace with a fixed placeholder; <br /> - \"hash\": replace with the SHA-256 hash of the value <br />",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_LoggerRetrySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoggerRetrySpec specifies how the failed deliveries of the logger cloud events are retried",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max number of delivery attempts of an event, including the first one. Defaults to 3.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"initialBackoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Wait before the first retry (e.g. \"100ms\"). The wait doubles with every retry, with a random jitter. Defaults to \"100ms\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxBackoff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max wait between two delivery attempts. Defaults to \"10s\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_LoggerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -7482,7 +7864,7 @@ func schema_pkg_apis_serving_v1beta1_LoggerSpec(ref common.ReferenceCallback) co
 					},
 					"mode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br />",
+							Description: "Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response; <br /> - \"failures\": log request and response of the calls which failed <br />",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -7544,11 +7926,50 @@ func schema_pkg_apis_serving_v1beta1_LoggerSpec(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"maxBodySize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max number of bytes of the request and response bodies captured in a log record. Larger bodies are truncated and the record is marked as truncated. Defaults to no limit.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"redaction": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Redaction policy applied to the payloads and metadata headers before they are logged.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerRedactionSpec"),
+						},
+					},
+					"samplingPercentage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Percentage of the calls to log. Sampling is deterministic by request ID, so the request and the response of a sampled call are always logged together. Defaults to 100.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"retry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retry policy of the cloud events which fail to be delivered to the logger URL. By default, a single delivery attempt is made.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerRetrySpec"),
+						},
+					},
+					"circuitBreaker": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Circuit breaking of the logger URL destinations.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerCircuitBreakerSpec"),
+						},
+					},
+					"deadLetterUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Logger URL receiving the cloud events which could not be delivered after all the retries, e.g. \"s3://bucket/dead-letter\". Any URL supported by the logger can be used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerStorageSpec"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerCircuitBreakerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerRedactionSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerRetrySpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerStorageSpec"},
 	}
 }
 
@@ -7684,6 +8105,44 @@ func schema_pkg_apis_serving_v1beta1_MetricsSpec(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_ModelBatchConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ModelBatchConfig overrides the batching configuration of a single model",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the model, as in the path of its requests",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxBatchSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max number of requests to trigger a batch of the model",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxLatency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max latency to trigger a batch of the model",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name", "maxBatchSize", "maxLatency"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_ModelCopies(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OtelResourceAttributeEnvVars returns the standard k8s resource attribute env
+// vars that are always injected when tracing is enabled. These use the downward
+// API to populate node and pod names, then compose OTEL_RESOURCE_ATTRIBUTES with
+// the name of the owning resource under the given attribute, e.g. llmisvc.name.
+// This is shared between the LLMInferenceService and InferenceGraph controllers.
+func OtelResourceAttributeEnvVars(namespace, nameAttribute, name string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name: "OTEL_RESOURCE_ATTRIBUTES_NODE_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "spec.nodeName",
+				},
+			},
+		},
+		{
+			Name: "OTEL_RESOURCE_ATTRIBUTES_POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.name",
+				},
+			},
+		},
+		{
+			Name: "OTEL_RESOURCE_ATTRIBUTES",
+			Value: "k8s.namespace.name=" + namespace +
+				",k8s.node.name=$(OTEL_RESOURCE_ATTRIBUTES_NODE_NAME)" +
+				",k8s.pod.name=$(OTEL_RESOURCE_ATTRIBUTES_POD_NAME)" +
+				"," + nameAttribute + "=" + name,
+		},
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
@@ -45,6 +47,22 @@ const (
 	LoggerArgumentMarshallerPort      = "--log-marshaller-port"
 	LoggerArgumentBatchSize           = "--log-batch-size"
 	LoggerArgumentBatchInterval       = "--log-batch-interval"
+	LoggerArgumentMaxBodySize         = "--log-max-body-size"
+	LoggerArgumentRedactField         = "--log-redact-field"
+	LoggerArgumentRedactHeaders       = "--log-redact-headers"
+	LoggerArgumentRedactMode          = "--log-redact-mode"
+	LoggerArgumentSamplingPercentage  = "--log-sampling-percentage"
+	LoggerArgumentKafkaAcks           = "--log-kafka-acks"
+	LoggerArgumentMaxFileSize         = "--log-max-file-size"
+	LoggerArgumentMaxFileAge          = "--log-max-file-age"
+	LoggerArgumentRetryMaxAttempts    = "--log-retry-max-attempts"
+	LoggerArgumentRetryInitialBackoff = "--log-retry-initial-backoff"
+	LoggerArgumentRetryMaxBackoff     = "--log-retry-max-backoff"
+	LoggerArgumentBreakerThreshold    = "--log-circuit-breaker-threshold"
+	LoggerArgumentBreakerResetTimeout = "--log-circuit-breaker-reset-timeout"
+	LoggerArgumentDeadLetterUrl       = "--log-dead-letter-url"
+	LoggerArgumentSpoolDir            = "--log-spool-dir"
+	LoggerArgumentSpoolMaxBytes       = "--log-spool-max-bytes"
 	LoggerArgumentInferenceService    = "--inference-service"
 	LoggerArgumentNamespace           = "--namespace"
 	LoggerArgumentEndpoint            = "--endpoint"
@@ -65,19 +83,26 @@ type AgentConfig struct {
 }
 
 type LoggerConfig struct {
-	Image         string                     `json:"image"`
-	CpuRequest    string                     `json:"cpuRequest"`
-	CpuLimit      string                     `json:"cpuLimit"`
-	MemoryRequest string                     `json:"memoryRequest"`
-	MemoryLimit   string                     `json:"memoryLimit"`
-	DefaultUrl    string                     `json:"defaultUrl"`
-	CaBundle      string                     `json:"caBundle"`
-	CaCertFile    string                     `json:"caCertFile"`
-	TlsSkipVerify bool                       `json:"tlsSkipVerify"`
-	Store         *v1beta1.LoggerStorageSpec `json:"storage"`
-	MarshallerURL string                     `json:"marshallerUrl,omitempty"`
-	BatchSize     int                        `json:"batchSize,omitempty"`
-	BatchInterval string                     `json:"batchInterval,omitempty"`
+	Image              string                            `json:"image"`
+	CpuRequest         string                            `json:"cpuRequest"`
+	CpuLimit           string                            `json:"cpuLimit"`
+	MemoryRequest      string                            `json:"memoryRequest"`
+	MemoryLimit        string                            `json:"memoryLimit"`
+	DefaultUrl         string                            `json:"defaultUrl"`
+	CaBundle           string                            `json:"caBundle"`
+	CaCertFile         string                            `json:"caCertFile"`
+	TlsSkipVerify      bool                              `json:"tlsSkipVerify"`
+	Store              *v1beta1.LoggerStorageSpec        `json:"storage"`
+	MarshallerURL      string                            `json:"marshallerUrl,omitempty"`
+	BatchSize          int                               `json:"batchSize,omitempty"`
+	BatchInterval      string                            `json:"batchInterval,omitempty"`
+	MaxBodySize        int                               `json:"maxBodySize,omitempty"`
+	Redaction          *v1beta1.LoggerRedactionSpec      `json:"redaction,omitempty"`
+	SamplingPercentage *int                              `json:"samplingPercentage,omitempty"`
+	SpoolSizeLimit     string                            `json:"spoolSizeLimit,omitempty"`
+	Retry              *v1beta1.LoggerRetrySpec          `json:"retry,omitempty"`
+	CircuitBreaker     *v1beta1.LoggerCircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+	DeadLetterURL      string                            `json:"deadLetterUrl,omitempty"`
 }
 
 type AgentInjector struct {
@@ -137,6 +162,24 @@ func getLoggerConfigs(pod *corev1.Pod, configMap *corev1.ConfigMap, isvc *v1beta
 		if isvc.Spec.Predictor.Logger.BatchInterval != nil {
 			loggerConfig.BatchInterval = *isvc.Spec.Predictor.Logger.BatchInterval
 		}
+		if isvc.Spec.Predictor.Logger.MaxBodySize != nil {
+			loggerConfig.MaxBodySize = *isvc.Spec.Predictor.Logger.MaxBodySize
+		}
+		if isvc.Spec.Predictor.Logger.Redaction != nil {
+			loggerConfig.Redaction = isvc.Spec.Predictor.Logger.Redaction
+		}
+		if isvc.Spec.Predictor.Logger.SamplingPercentage != nil {
+			loggerConfig.SamplingPercentage = isvc.Spec.Predictor.Logger.SamplingPercentage
+		}
+		if isvc.Spec.Predictor.Logger.Retry != nil {
+			loggerConfig.Retry = isvc.Spec.Predictor.Logger.Retry
+		}
+		if isvc.Spec.Predictor.Logger.CircuitBreaker != nil {
+			loggerConfig.CircuitBreaker = isvc.Spec.Predictor.Logger.CircuitBreaker
+		}
+		if isvc.Spec.Predictor.Logger.DeadLetterURL != nil {
+			loggerConfig.DeadLetterURL = *isvc.Spec.Predictor.Logger.DeadLetterURL
+		}
 	} else {
 		if isvc == nil {
 			log.Info("The Inference Service is not found. The global ConfigMap will be used as the logger configuration", "name", pod.Name, "namespace", pod.Namespace)
@@ -220,8 +263,28 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			args = append(args, BatcherArgumentMaxLatency)
 			args = append(args, maxLatency)
 		}
+
+		targetLatency, ok := pod.Annotations[constants.BatcherTargetLatencyInternalAnnotationKey]
+		if ok {
+			args = append(args, BatcherArgumentTargetLatency)
+			args = append(args, targetLatency)
+		}
+
+		maxQueueDepth, ok := pod.Annotations[constants.BatcherMaxQueueDepthInternalAnnotationKey]
+		if ok {
+			args = append(args, BatcherArgumentMaxQueueDepth)
+			args = append(args, maxQueueDepth)
+		}
+
+		modelBatchConfig, ok := pod.Annotations[constants.BatcherModelBatchConfigInternalAnnotationKey]
+		if ok {
+			args = append(args, BatcherArgumentModelBatchConfig)
+			args = append(args, modelBatchConfig)
+		}
 	}
 	// Only inject if the logger required annotations are set
+	var spoolSizeLimit *resource.Quantity
+	logClaimName := ""
 	if injectLogger {
 		logUrl, ok := pod.Annotations[constants.LoggerSinkUrlInternalAnnotationKey]
 		if !ok {
@@ -244,13 +307,43 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			}
 		}
 		storageFormat := ""
+		kafkaAcks := ""
+		maxFileSize := ""
+		maxFileAge := ""
 		if ag.loggerConfig.Store != nil {
 			if ag.loggerConfig.Store.Parameters != nil {
 				format, ok := (*ag.loggerConfig.Store.Parameters)[constants.LoggerFormatKey]
 				if ok {
 					storageFormat = format
 				}
+				kafkaAcks = (*ag.loggerConfig.Store.Parameters)[constants.LoggerKafkaAcksKey]
+				if size, ok := (*ag.loggerConfig.Store.Parameters)[constants.LoggerMaxFileSizeKey]; ok {
+					quantity, err := resource.ParseQuantity(size)
+					if err != nil {
+						return fmt.Errorf("invalid logger max file size %q: %w", size, err)
+					}
+					maxFileSize = strconv.FormatInt(quantity.Value(), 10)
+				}
+				if age, ok := (*ag.loggerConfig.Store.Parameters)[constants.LoggerMaxFileAgeKey]; ok {
+					if _, err := time.ParseDuration(age); err != nil {
+						return fmt.Errorf("invalid logger max file age %q: %w", age, err)
+					}
+					maxFileAge = age
+				}
+			}
+		}
+		// a pvc://claim/path log url writes to the claim mounted on the agent
+		var err error
+		logClaimName, err = loggerClaimName(logUrl, ag.loggerConfig.DeadLetterURL)
+		if err != nil {
+			return err
+		}
+		if ag.loggerConfig.SpoolSizeLimit != "" {
+			limit, err := resource.ParseQuantity(ag.loggerConfig.SpoolSizeLimit)
+			if err != nil {
+				return fmt.Errorf("invalid logger spool size limit %q: %w", ag.loggerConfig.SpoolSizeLimit, err)
 			}
+			spoolSizeLimit = &limit
 		}
 		loggerArgs := []string{
 			LoggerArgumentLogUrl,
@@ -285,6 +378,63 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 		if ag.loggerConfig.BatchInterval != "" {
 			loggerArgs = append(loggerArgs, LoggerArgumentBatchInterval, ag.loggerConfig.BatchInterval)
 		}
+		if ag.loggerConfig.MaxBodySize > 0 {
+			loggerArgs = append(loggerArgs, LoggerArgumentMaxBodySize, strconv.Itoa(ag.loggerConfig.MaxBodySize))
+		}
+		if redaction := ag.loggerConfig.Redaction; redaction != nil {
+			// field paths may contain commas, so each of them is passed as its own argument
+			for _, field := range redaction.Fields {
+				loggerArgs = append(loggerArgs, LoggerArgumentRedactField, field)
+			}
+			if len(redaction.Headers) > 0 {
+				loggerArgs = append(loggerArgs, LoggerArgumentRedactHeaders, strings.Join(redaction.Headers, ","))
+			}
+			if redaction.Mode != "" {
+				loggerArgs = append(loggerArgs, LoggerArgumentRedactMode, string(redaction.Mode))
+			}
+		}
+		if ag.loggerConfig.SamplingPercentage != nil {
+			loggerArgs = append(loggerArgs, LoggerArgumentSamplingPercentage, strconv.Itoa(*ag.loggerConfig.SamplingPercentage))
+		}
+		if kafkaAcks != "" {
+			loggerArgs = append(loggerArgs, LoggerArgumentKafkaAcks, kafkaAcks)
+		}
+		if retry := ag.loggerConfig.Retry; retry != nil {
+			maxAttempts := constants.LoggerDefaultRetryMaxAttempts
+			if retry.MaxAttempts != nil {
+				maxAttempts = *retry.MaxAttempts
+			}
+			loggerArgs = append(loggerArgs, LoggerArgumentRetryMaxAttempts, strconv.Itoa(maxAttempts))
+			if retry.InitialBackoff != nil {
+				loggerArgs = append(loggerArgs, LoggerArgumentRetryInitialBackoff, *retry.InitialBackoff)
+			}
+			if retry.MaxBackoff != nil {
+				loggerArgs = append(loggerArgs, LoggerArgumentRetryMaxBackoff, *retry.MaxBackoff)
+			}
+		}
+		if breaker := ag.loggerConfig.CircuitBreaker; breaker != nil {
+			threshold := constants.LoggerDefaultBreakerThreshold
+			if breaker.FailureThreshold != nil {
+				threshold = *breaker.FailureThreshold
+			}
+			loggerArgs = append(loggerArgs, LoggerArgumentBreakerThreshold, strconv.Itoa(threshold))
+			if breaker.ResetTimeout != nil {
+				loggerArgs = append(loggerArgs, LoggerArgumentBreakerResetTimeout, *breaker.ResetTimeout)
+			}
+		}
+		if ag.loggerConfig.DeadLetterURL != "" {
+			loggerArgs = append(loggerArgs, LoggerArgumentDeadLetterUrl, ag.loggerConfig.DeadLetterURL)
+		}
+		if maxFileSize != "" {
+			loggerArgs = append(loggerArgs, LoggerArgumentMaxFileSize, maxFileSize)
+		}
+		if maxFileAge != "" {
+			loggerArgs = append(loggerArgs, LoggerArgumentMaxFileAge, maxFileAge)
+		}
+		if spoolSizeLimit != nil {
+			loggerArgs = append(loggerArgs, LoggerArgumentSpoolDir, constants.LoggerSpoolMountPath,
+				LoggerArgumentSpoolMaxBytes, strconv.FormatInt(spoolSizeLimit.Value(), 10))
+		}
 		logHeaderMetadata, ok := pod.Annotations[constants.LoggerMetadataHeadersInternalAnnotationKey]
 		if ok {
 			loggerArgs = append(loggerArgs, LoggerArgumentMetadataHeaders)
@@ -437,6 +587,73 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 		},
 	}
 
+	// The logger and the batcher record their metrics on a separate port of the agent, which is scraped
+	// unless the pod already declares the port to scrape
+	if injectLogger || injectBatcher {
+		agentContainer.Ports = append(agentContainer.Ports, corev1.ContainerPort{
+			Name:          constants.AgentMetricsPortName,
+			ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+			Protocol:      "TCP",
+		})
+		if _, ok := pod.Annotations[constants.PrometheusPortAnnotationKey]; !ok {
+			pod.Annotations[constants.PrometheusPortAnnotationKey] = strconv.Itoa(constants.InferenceServiceDefaultAgentMetricsPort)
+			pod.Annotations[constants.PrometheusPathAnnotationKey] = constants.DefaultPrometheusPath
+		}
+	}
+
+	// If the logger spool is enabled, back it with an emptyDir so that it survives the agent restarts
+	if spoolSizeLimit != nil {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: constants.LoggerSpoolVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					SizeLimit: spoolSizeLimit,
+				},
+			},
+		})
+		agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      constants.LoggerSpoolVolume,
+			MountPath: constants.LoggerSpoolMountPath,
+		})
+	}
+
+	// If the logger writes to a PVC, mount the claim on the agent
+	if logClaimName != "" {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: constants.LoggerPvcVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: logClaimName,
+				},
+			},
+		})
+		agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      constants.LoggerPvcVolume,
+			MountPath: constants.LoggerPvcMountPath,
+		})
+	}
+
+	// Mount the claims of the pvc:// TrainedModels read-only on the agent, where the storage initializer mounts them
+	if claims, ok := pod.Annotations[constants.AgentPvcClaimsAnnotationKey]; ok && injectPuller {
+		for i, claim := range strings.Split(claims, ",") {
+			volumeName := fmt.Sprintf("%s-%d", constants.AgentModelPvcVolumePrefix, i)
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claim,
+						ReadOnly:  true,
+					},
+				},
+			})
+			agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: PvcSourceMountPath + "/" + claim,
+				ReadOnly:  true,
+			})
+		}
+	}
+
 	// If the Logger TLS bundle ConfigMap is specified, mount it
 	if injectLogger && ag.loggerConfig.CaBundle != "" {
 		// Optional. If the ConfigMap is not found, this will not make the Pod fail
@@ -565,6 +782,26 @@ func mountVolumeToContainer(containerName string, pod *corev1.Pod, additionalVol
 	pod.Spec.Containers = mountedContainers
 }
 
+// loggerClaimName returns the claim of the pvc:// logger URLs, which share the claim mounted
+// on the agent.
+func loggerClaimName(logUrls ...string) (string, error) {
+	claimName := ""
+	for _, logUrl := range logUrls {
+		if !strings.HasPrefix(logUrl, "pvc://") {
+			continue
+		}
+		parsed, err := url.Parse(logUrl)
+		if err != nil || parsed.Host == "" {
+			return "", fmt.Errorf("invalid logger pvc url %q", logUrl)
+		}
+		if claimName != "" && claimName != parsed.Host {
+			return "", fmt.Errorf("logger pvc urls must use the same claim, got %q and %q", claimName, parsed.Host)
+		}
+		claimName = parsed.Host
+	}
+	return claimName, nil
+}
+
 func appendVolume(existingVolumes []corev1.Volume, additionalVolume corev1.Volume) []corev1.Volume {
 	if existingVolumes == nil {
 		existingVolumes = []corev1.Volume{}
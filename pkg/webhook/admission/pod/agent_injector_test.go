@@ -239,6 +239,169 @@ func TestAgentInjector(t *testing.T) {
 				},
 			},
 		},
+		"AddAgentWithModelClaims": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.AgentShouldInjectAnnotationKey:          "true",
+						constants.AgentModelConfigVolumeNameAnnotationKey: "modelconfig-deployment-0",
+						constants.AgentModelDirAnnotationKey:              "/mnt/models",
+						constants.AgentModelConfigMountPathAnnotationKey:  "/mnt/configs",
+						constants.AgentPvcClaimsAnnotationKey:             "claim-a,claim-b",
+					},
+					Labels: map[string]string{
+						"serving.kserve.io/inferenceservice": "sklearn",
+						constants.KServiceModelLabel:         "sklearn",
+						constants.KServiceEndpointLabel:      "default",
+						constants.KServiceComponentLabel:     "predictor",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "sa",
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+					},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.AgentShouldInjectAnnotationKey: "true",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "sa",
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name:      constants.AgentContainerName,
+							Image:     agentConfig.Image,
+							Resources: agentResourceRequirement,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "agent-model-pvc-0",
+									ReadOnly:  true,
+									MountPath: "/mnt/pvc/claim-a",
+								},
+								{
+									Name:      "agent-model-pvc-1",
+									ReadOnly:  true,
+									MountPath: "/mnt/pvc/claim-b",
+								},
+								{
+									Name:      constants.ModelDirVolumeName,
+									ReadOnly:  false,
+									MountPath: constants.ModelDir,
+								},
+								{
+									Name:      constants.ModelConfigVolumeName,
+									ReadOnly:  false,
+									MountPath: constants.ModelConfigDir,
+								},
+							},
+							Args: []string{
+								"--enable-puller", "--config-dir", "/mnt/configs", "--model-dir", "/mnt/models", constants.AgentComponentPortArgName,
+								constants.InferenceServiceDefaultHttpPort,
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env: []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										HTTPHeaders: []corev1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "agent-model-pvc-0",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "claim-a",
+									ReadOnly:  true,
+								},
+							},
+						},
+						{
+							Name: "agent-model-pvc-1",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "claim-b",
+									ReadOnly:  true,
+								},
+							},
+						},
+						{
+							Name: "model-dir",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+						{
+							Name: "model-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "modelconfig-deployment-0",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"DoNotAddAgent": {
 			original: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
@@ -365,6 +528,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -436,9 +604,425 @@ func TestAgentInjector(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "deployment",
 					Annotations: map[string]string{
-						constants.LoggerInternalAnnotationKey:        "true",
-						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
-						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+						constants.LoggerInternalAnnotationKey:        "true",
+						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								LoggerArgumentLogUrl,
+								"http://httpbin.org/",
+								LoggerArgumentSourceUri,
+								"deployment",
+								LoggerArgumentMode,
+								"all",
+								LoggerArgumentInferenceService,
+								"sklearn",
+								LoggerArgumentNamespace,
+								"default",
+								LoggerArgumentEndpoint,
+								"default",
+								LoggerArgumentComponent,
+								"predictor",
+								LoggerArgumentMetadataHeaders,
+								"Foo,Bar",
+								LoggerArgumentTlsSkipVerify,
+								"false",
+								constants.AgentComponentPortArgName,
+								constants.InferenceServiceDefaultHttpPort,
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										HTTPHeaders: []corev1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"AddLoggerWithAnnotation": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.LoggerInternalAnnotationKey:                    "true",
+						constants.LoggerSinkUrlInternalAnnotationKey:             "http://httpbin.org/",
+						constants.LoggerModeInternalAnnotationKey:                string(v1beta1.LogAll),
+						constants.LoggerMetadataAnnotationsInternalAnnotationKey: "Foo,Fizz",
+						"Foo":  "Bar",
+						"Fizz": "Buzz",
+					},
+					Labels: map[string]string{
+						"serving.kserve.io/inferenceservice": "sklearn",
+						constants.KServiceModelLabel:         "sklearn",
+						constants.KServiceEndpointLabel:      "default",
+						constants.KServiceComponentLabel:     "predictor",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.LoggerInternalAnnotationKey:        "true",
+						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
+						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								LoggerArgumentLogUrl,
+								"http://httpbin.org/",
+								LoggerArgumentSourceUri,
+								"deployment",
+								LoggerArgumentMode,
+								"all",
+								LoggerArgumentInferenceService,
+								"sklearn",
+								LoggerArgumentNamespace,
+								"default",
+								LoggerArgumentEndpoint,
+								"default",
+								LoggerArgumentComponent,
+								"predictor",
+								LoggerArgumentMetadataAnnotations,
+								"Foo=Bar,Fizz=Buzz",
+								LoggerArgumentTlsSkipVerify,
+								"false",
+								constants.AgentComponentPortArgName,
+								constants.InferenceServiceDefaultHttpPort,
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										HTTPHeaders: []corev1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"DoNotAddLogger": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "sklearn",
+					}},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "sklearn",
+					}},
+				},
+			},
+		},
+		"AddBatcher": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.BatcherInternalAnnotationKey:             "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:   "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey: "30",
+					},
+					Labels: map[string]string{
+						"serving.kserve.io/inferenceservice": "sklearn",
+						constants.KServiceModelLabel:         "sklearn",
+						constants.KServiceEndpointLabel:      "default",
+						constants.KServiceComponentLabel:     "predictor",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.BatcherInternalAnnotationKey:             "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:   "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey: "30",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								BatcherEnableFlag,
+								BatcherArgumentMaxBatchSize,
+								"30",
+								BatcherArgumentMaxLatency,
+								"100",
+								constants.AgentComponentPortArgName,
+								constants.InferenceServiceDefaultHttpPort,
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										HTTPHeaders: []corev1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"AddAdaptiveBatcher": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.BatcherInternalAnnotationKey:              "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:    "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey:  "30",
+						constants.BatcherTargetLatencyInternalAnnotationKey: "50",
+					},
+					Labels: map[string]string{
+						"serving.kserve.io/inferenceservice": "sklearn",
+						constants.KServiceModelLabel:         "sklearn",
+						constants.KServiceEndpointLabel:      "default",
+						constants.KServiceComponentLabel:     "predictor",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											IntVal: 8080,
+										},
+									},
+								},
+								InitialDelaySeconds: 0,
+								TimeoutSeconds:      1,
+								PeriodSeconds:       10,
+								SuccessThreshold:    1,
+								FailureThreshold:    3,
+							},
+						},
+						{
+							Name: "queue-proxy",
+							Env:  []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
+						},
+					},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.BatcherInternalAnnotationKey:              "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:    "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey:  "30",
+						constants.BatcherTargetLatencyInternalAnnotationKey: "50",
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -468,24 +1052,13 @@ func TestAgentInjector(t *testing.T) {
 							Name:  constants.AgentContainerName,
 							Image: loggerConfig.Image,
 							Args: []string{
-								LoggerArgumentLogUrl,
-								"http://httpbin.org/",
-								LoggerArgumentSourceUri,
-								"deployment",
-								LoggerArgumentMode,
-								"all",
-								LoggerArgumentInferenceService,
-								"sklearn",
-								LoggerArgumentNamespace,
-								"default",
-								LoggerArgumentEndpoint,
-								"default",
-								LoggerArgumentComponent,
-								"predictor",
-								LoggerArgumentMetadataHeaders,
-								"Foo,Bar",
-								LoggerArgumentTlsSkipVerify,
-								"false",
+								BatcherEnableFlag,
+								BatcherArgumentMaxBatchSize,
+								"30",
+								BatcherArgumentMaxLatency,
+								"100",
+								BatcherArgumentTargetLatency,
+								"50",
 								constants.AgentComponentPortArgName,
 								constants.InferenceServiceDefaultHttpPort,
 							},
@@ -495,6 +1068,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -518,18 +1096,16 @@ func TestAgentInjector(t *testing.T) {
 				},
 			},
 		},
-		"AddLoggerWithAnnotation": {
+		"AddBatcherWithMaxQueueDepth": {
 			original: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "deployment",
 					Namespace: "default",
 					Annotations: map[string]string{
-						constants.LoggerInternalAnnotationKey:                    "true",
-						constants.LoggerSinkUrlInternalAnnotationKey:             "http://httpbin.org/",
-						constants.LoggerModeInternalAnnotationKey:                string(v1beta1.LogAll),
-						constants.LoggerMetadataAnnotationsInternalAnnotationKey: "Foo,Fizz",
-						"Foo":  "Bar",
-						"Fizz": "Buzz",
+						constants.BatcherInternalAnnotationKey:              "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:    "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey:  "30",
+						constants.BatcherMaxQueueDepthInternalAnnotationKey: "64",
 					},
 					Labels: map[string]string{
 						"serving.kserve.io/inferenceservice": "sklearn",
@@ -568,9 +1144,10 @@ func TestAgentInjector(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "deployment",
 					Annotations: map[string]string{
-						constants.LoggerInternalAnnotationKey:        "true",
-						constants.LoggerSinkUrlInternalAnnotationKey: "http://httpbin.org/",
-						constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+						constants.BatcherInternalAnnotationKey:              "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:    "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey:  "30",
+						constants.BatcherMaxQueueDepthInternalAnnotationKey: "64",
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -600,24 +1177,13 @@ func TestAgentInjector(t *testing.T) {
 							Name:  constants.AgentContainerName,
 							Image: loggerConfig.Image,
 							Args: []string{
-								LoggerArgumentLogUrl,
-								"http://httpbin.org/",
-								LoggerArgumentSourceUri,
-								"deployment",
-								LoggerArgumentMode,
-								"all",
-								LoggerArgumentInferenceService,
-								"sklearn",
-								LoggerArgumentNamespace,
-								"default",
-								LoggerArgumentEndpoint,
-								"default",
-								LoggerArgumentComponent,
-								"predictor",
-								LoggerArgumentMetadataAnnotations,
-								"Foo=Bar,Fizz=Buzz",
-								LoggerArgumentTlsSkipVerify,
-								"false",
+								BatcherEnableFlag,
+								BatcherArgumentMaxBatchSize,
+								"30",
+								BatcherArgumentMaxLatency,
+								"100",
+								BatcherArgumentMaxQueueDepth,
+								"64",
 								constants.AgentComponentPortArgName,
 								constants.InferenceServiceDefaultHttpPort,
 							},
@@ -627,6 +1193,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -650,37 +1221,17 @@ func TestAgentInjector(t *testing.T) {
 				},
 			},
 		},
-		"DoNotAddLogger": {
-			original: &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "deployment",
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{{
-						Name: "sklearn",
-					}},
-				},
-			},
-			expected: &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "deployment",
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{{
-						Name: "sklearn",
-					}},
-				},
-			},
-		},
-		"AddBatcher": {
+		"AddBatcherWithModelBatchConfig": {
 			original: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "deployment",
 					Namespace: "default",
 					Annotations: map[string]string{
-						constants.BatcherInternalAnnotationKey:             "true",
-						constants.BatcherMaxLatencyInternalAnnotationKey:   "100",
-						constants.BatcherMaxBatchSizeInternalAnnotationKey: "30",
+						constants.BatcherInternalAnnotationKey:                 "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:       "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey:     "30",
+						constants.BatcherMaxQueueDepthInternalAnnotationKey:    "64",
+						constants.BatcherModelBatchConfigInternalAnnotationKey: "model1=8:50,model2=4:20",
 					},
 					Labels: map[string]string{
 						"serving.kserve.io/inferenceservice": "sklearn",
@@ -719,9 +1270,11 @@ func TestAgentInjector(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "deployment",
 					Annotations: map[string]string{
-						constants.BatcherInternalAnnotationKey:             "true",
-						constants.BatcherMaxLatencyInternalAnnotationKey:   "100",
-						constants.BatcherMaxBatchSizeInternalAnnotationKey: "30",
+						constants.BatcherInternalAnnotationKey:                 "true",
+						constants.BatcherMaxLatencyInternalAnnotationKey:       "100",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey:     "30",
+						constants.BatcherMaxQueueDepthInternalAnnotationKey:    "64",
+						constants.BatcherModelBatchConfigInternalAnnotationKey: "model1=8:50,model2=4:20",
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -756,6 +1309,10 @@ func TestAgentInjector(t *testing.T) {
 								"30",
 								BatcherArgumentMaxLatency,
 								"100",
+								BatcherArgumentMaxQueueDepth,
+								"64",
+								BatcherArgumentModelBatchConfig,
+								"model1=8:50,model2=4:20",
 								constants.AgentComponentPortArgName,
 								constants.InferenceServiceDefaultHttpPort,
 							},
@@ -765,6 +1322,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -1104,6 +1666,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -1235,6 +1802,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env: []corev1.EnvVar{
 								{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"},
@@ -1521,6 +2093,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -1677,6 +2254,11 @@ func TestAgentInjector(t *testing.T) {
 									ContainerPort: constants.InferenceServiceDefaultAgentPort,
 									Protocol:      "TCP",
 								},
+								{
+									Name:          constants.AgentMetricsPortName,
+									ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+									Protocol:      "TCP",
+								},
 							},
 							Env:       []corev1.EnvVar{{Name: "SERVING_READINESS_PROBE", Value: "{\"tcpSocket\":{\"port\":8080},\"timeoutSeconds\":1,\"periodSeconds\":10,\"successThreshold\":1,\"failureThreshold\":3}"}},
 							Resources: agentResourceRequirement,
@@ -1891,6 +2473,67 @@ func TestAgentInjector(t *testing.T) {
 	}
 }
 
+func TestAgentInjectorMetricsAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset()
+	credentialBuilder := credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{
+		Data: map[string]string{},
+	})
+	injector := &AgentInjector{
+		credentialBuilder,
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    map[string]string
+	}{
+		"BatcherMetricsAreScraped": {
+			annotations: map[string]string{
+				constants.BatcherInternalAnnotationKey: "true",
+			},
+			expected: map[string]string{
+				constants.PrometheusPortAnnotationKey: "9082",
+				constants.PrometheusPathAnnotationKey: constants.DefaultPrometheusPath,
+			},
+		},
+		"DeclaredScrapePortIsKept": {
+			annotations: map[string]string{
+				constants.BatcherInternalAnnotationKey: "true",
+				constants.PrometheusPortAnnotationKey:  "8080",
+			},
+			expected: map[string]string{
+				constants.PrometheusPortAnnotationKey: "8080",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deployment",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "sklearn"}},
+				},
+			}
+			g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+			for key, value := range scenario.expected {
+				g.Expect(pod.Annotations).To(gomega.HaveKeyWithValue(key, value))
+			}
+			agent := pod.Spec.Containers[len(pod.Spec.Containers)-1]
+			g.Expect(agent.Ports).To(gomega.ContainElement(corev1.ContainerPort{
+				Name:          constants.AgentMetricsPortName,
+				ContainerPort: constants.InferenceServiceDefaultAgentMetricsPort,
+				Protocol:      "TCP",
+			}))
+		})
+	}
+}
+
 func TestGetLoggerConfigs(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	url := "s3://bucket/logger"
@@ -1960,6 +2603,156 @@ func TestGetLoggerConfigs(t *testing.T) {
 				gomega.BeNil(),
 			},
 		},
+		{
+			name: "Logger max body size",
+			configMap: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{},
+				ObjectMeta: metav1.ObjectMeta{},
+				Data: map[string]string{
+					LoggerConfigMapKeyName: `{
+						"Image":         "gcr.io/kfserving/logger:latest",
+						"CpuRequest":    "100m",
+						"CpuLimit":      "1",
+						"MemoryRequest": "200Mi",
+						"MemoryLimit":   "1Gi"
+					}`,
+				},
+				BinaryData: map[string][]byte{},
+			},
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							Logger: &v1beta1.LoggerSpec{
+								URL:         &url,
+								Mode:        mode,
+								MaxBodySize: ptr.To(4096),
+							},
+						},
+					},
+				},
+			},
+			pod: pod,
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&LoggerConfig{
+					Image:         "gcr.io/kfserving/logger:latest",
+					CpuRequest:    "100m",
+					CpuLimit:      "1",
+					MemoryRequest: "200Mi",
+					MemoryLimit:   "1Gi",
+					MaxBodySize:   4096,
+				}),
+				gomega.BeNil(),
+			},
+		},
+		{
+			name: "Logger redaction and sampling",
+			configMap: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{},
+				ObjectMeta: metav1.ObjectMeta{},
+				Data: map[string]string{
+					LoggerConfigMapKeyName: `{
+						"Image":         "gcr.io/kfserving/logger:latest",
+						"CpuRequest":    "100m",
+						"CpuLimit":      "1",
+						"MemoryRequest": "200Mi",
+						"MemoryLimit":   "1Gi"
+					}`,
+				},
+				BinaryData: map[string][]byte{},
+			},
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							Logger: &v1beta1.LoggerSpec{
+								URL:  &url,
+								Mode: mode,
+								Redaction: &v1beta1.LoggerRedactionSpec{
+									Fields:  []string{"inputs.#.data"},
+									Headers: []string{"Authorization"},
+									Mode:    v1beta1.RedactionHash,
+								},
+								SamplingPercentage: ptr.To(10),
+							},
+						},
+					},
+				},
+			},
+			pod: pod,
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&LoggerConfig{
+					Image:         "gcr.io/kfserving/logger:latest",
+					CpuRequest:    "100m",
+					CpuLimit:      "1",
+					MemoryRequest: "200Mi",
+					MemoryLimit:   "1Gi",
+					Redaction: &v1beta1.LoggerRedactionSpec{
+						Fields:  []string{"inputs.#.data"},
+						Headers: []string{"Authorization"},
+						Mode:    v1beta1.RedactionHash,
+					},
+					SamplingPercentage: ptr.To(10),
+				}),
+				gomega.BeNil(),
+			},
+		},
+		{
+			name: "Logger retry and dead letter",
+			configMap: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{},
+				ObjectMeta: metav1.ObjectMeta{},
+				Data: map[string]string{
+					LoggerConfigMapKeyName: `{
+						"Image":         "gcr.io/kfserving/logger:latest",
+						"CpuRequest":    "100m",
+						"CpuLimit":      "1",
+						"MemoryRequest": "200Mi",
+						"MemoryLimit":   "1Gi"
+					}`,
+				},
+				BinaryData: map[string][]byte{},
+			},
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							Logger: &v1beta1.LoggerSpec{
+								URL:  &url,
+								Mode: mode,
+								Retry: &v1beta1.LoggerRetrySpec{
+									MaxAttempts:    ptr.To(5),
+									InitialBackoff: ptr.To("200ms"),
+								},
+								CircuitBreaker: &v1beta1.LoggerCircuitBreakerSpec{
+									FailureThreshold: ptr.To(10),
+								},
+								DeadLetterURL: ptr.To("s3://bucket/dead-letter"),
+							},
+						},
+					},
+				},
+			},
+			pod: pod,
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&LoggerConfig{
+					Image:         "gcr.io/kfserving/logger:latest",
+					CpuRequest:    "100m",
+					CpuLimit:      "1",
+					MemoryRequest: "200Mi",
+					MemoryLimit:   "1Gi",
+					Retry: &v1beta1.LoggerRetrySpec{
+						MaxAttempts:    ptr.To(5),
+						InitialBackoff: ptr.To("200ms"),
+					},
+					CircuitBreaker: &v1beta1.LoggerCircuitBreakerSpec{
+						FailureThreshold: ptr.To(10),
+					},
+					DeadLetterURL: "s3://bucket/dead-letter",
+				}),
+				gomega.BeNil(),
+			},
+		},
 		{
 			name: "Logger storage service account nil",
 			configMap: &corev1.ConfigMap{
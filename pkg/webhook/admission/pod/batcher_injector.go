@@ -28,11 +28,14 @@ import (
 )
 
 const (
-	BatcherContainerName        = "batcher"
-	BatcherConfigMapKeyName     = "batcher"
-	BatcherEnableFlag           = "--enable-batcher"
-	BatcherArgumentMaxBatchSize = "--max-batchsize"
-	BatcherArgumentMaxLatency   = "--max-latency"
+	BatcherContainerName            = "batcher"
+	BatcherConfigMapKeyName         = "batcher"
+	BatcherEnableFlag               = "--enable-batcher"
+	BatcherArgumentMaxBatchSize     = "--max-batchsize"
+	BatcherArgumentMaxLatency       = "--max-latency"
+	BatcherArgumentTargetLatency    = "--target-latency"
+	BatcherArgumentMaxQueueDepth    = "--max-queue-depth"
+	BatcherArgumentModelBatchConfig = "--model-batch-config"
 )
 
 type BatcherConfig struct {